@@ -0,0 +1,24 @@
+-- Create the tax brackets table
+CREATE TABLE IF NOT EXISTS tax_brackets (
+    id SERIAL PRIMARY KEY,
+    lower_bound DECIMAL(14, 2) NOT NULL,
+    upper_bound DECIMAL(14, 2),
+    rate DECIMAL(5, 4) NOT NULL,
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+-- Insert the progressive tax brackets if they do not exist
+INSERT INTO tax_brackets (lower_bound, upper_bound, rate)
+SELECT brackets.lower_bound, brackets.upper_bound, brackets.rate
+FROM (VALUES
+    (0, 150000, 0),
+    (150000, 500000, 0.10),
+    (500000, 1000000, 0.15),
+    (1000000, 2000000, 0.20),
+    (2000000, NULL, 0.35)
+) AS brackets (lower_bound, upper_bound, rate)
+WHERE NOT EXISTS (
+    SELECT 1 FROM tax_brackets
+);
@@ -0,0 +1,9 @@
+-- Key the allowances by tax year, the existing amounts applying from the earliest supported tax year as the brackets do
+ALTER TABLE allowances ADD COLUMN IF NOT EXISTS tax_year INTEGER NOT NULL DEFAULT 2017;
+ALTER TABLE allowances ALTER COLUMN tax_year DROP DEFAULT;
+CREATE UNIQUE INDEX IF NOT EXISTS allowances_tax_year_idx ON allowances (tax_year);
+
+-- Key the tax brackets by tax year
+ALTER TABLE tax_brackets ADD COLUMN IF NOT EXISTS tax_year INTEGER NOT NULL DEFAULT 2017;
+ALTER TABLE tax_brackets ALTER COLUMN tax_year DROP DEFAULT;
+CREATE INDEX IF NOT EXISTS tax_brackets_tax_year_idx ON tax_brackets (tax_year);
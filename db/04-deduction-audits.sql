@@ -0,0 +1,25 @@
+-- Create the append-only audit trail of deduction changes
+CREATE TABLE IF NOT EXISTS deduction_audits (
+    id BIGSERIAL PRIMARY KEY,
+    tax_year INTEGER NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    old_amount DECIMAL(10, 2) NOT NULL,
+    new_amount DECIMAL(10, 2) NOT NULL,
+    changed_by VARCHAR(255) NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS deduction_audits_changed_at_idx ON deduction_audits (changed_at DESC, id DESC);
+
+-- Reject updates and deletes so the audit trail stays append-only
+CREATE OR REPLACE FUNCTION reject_deduction_audits_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'deduction_audits is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS deduction_audits_append_only ON deduction_audits;
+CREATE TRIGGER deduction_audits_append_only
+    BEFORE UPDATE OR DELETE ON deduction_audits
+    FOR EACH ROW EXECUTE FUNCTION reject_deduction_audits_change();
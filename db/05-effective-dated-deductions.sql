@@ -0,0 +1,22 @@
+-- Store one effective-dated amount per deduction so changes can be scheduled
+CREATE TABLE IF NOT EXISTS deductions (
+    id SERIAL PRIMARY KEY,
+    tax_year INTEGER NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    amount DECIMAL(10, 2) NOT NULL,
+    effective_from TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS deductions_effective_from_idx ON deductions (tax_year, type, effective_from) WHERE deleted_at IS NULL;
+
+-- Copy the allowances of each tax year over, effective from the start of that year,
+-- keeping the allowances table as the source until it is retired in a migration of its own
+INSERT INTO deductions (tax_year, type, amount, effective_from)
+SELECT a.tax_year, d.type, d.amount, make_timestamptz(a.tax_year, 1, 1, 0, 0, 0, 'UTC')
+FROM allowances a
+CROSS JOIN LATERAL (VALUES ('personal', a.personal), ('donation', a.donation), ('k-receipt', a.k_receipt)) AS d (type, amount)
+WHERE a.deleted_at IS NULL
+ON CONFLICT DO NOTHING;
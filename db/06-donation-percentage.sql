@@ -0,0 +1,17 @@
+-- The donation deduction is a percentage of the income left after the other deductions in every tax year.
+-- The fixed amounts in baht it was configured with, above any percentage, are converted to 10 percent, and
+-- each conversion is recorded in the audit trail as a conversion, as its old and new amounts are in different units.
+WITH previous AS (
+    SELECT id, amount
+    FROM deductions
+    WHERE type = 'donation' AND amount > 100 AND deleted_at IS NULL
+),
+converted AS (
+    UPDATE deductions d SET amount = 10, updated_at = NOW()
+    FROM previous p
+    WHERE d.id = p.id
+    RETURNING d.tax_year, p.amount AS old_amount, d.amount AS new_amount
+)
+INSERT INTO deduction_audits (tax_year, type, action, old_amount, new_amount, changed_by)
+SELECT tax_year, 'donation', 'convert', old_amount, new_amount, 'migration'
+FROM converted;
@@ -0,0 +1,19 @@
+-- Store the late filing rules of each tax year alongside the allowances
+CREATE TABLE IF NOT EXISTS late_filing_rules (
+    id SERIAL PRIMARY KEY,
+    tax_year INTEGER NOT NULL,
+    -- The percentage of the tax due charged for every month or part of a month it is paid late
+    surcharge_rate DECIMAL(5, 2) NOT NULL CHECK (surcharge_rate >= 0 AND surcharge_rate <= 100),
+    -- The fixed penalty of paying late, in baht
+    penalty DECIMAL(10, 2) NOT NULL CHECK (penalty >= 0),
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS late_filing_rules_tax_year_idx ON late_filing_rules (tax_year) WHERE deleted_at IS NULL;
+
+-- The rules apply from the earliest supported tax year, as the allowances and brackets do
+INSERT INTO late_filing_rules (tax_year, surcharge_rate, penalty)
+VALUES (2017, 1.5, 200)
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,12 @@
+-- Create the exchange rates table, the Bank of Thailand average rate of every currency per tax year in baht per unit
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id SERIAL PRIMARY KEY,
+    tax_year INTEGER NOT NULL,
+    currency CHAR(3) NOT NULL,
+    rate DECIMAL(14, 6) NOT NULL CHECK (rate > 0),
+    created_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITHOUT TIME ZONE DEFAULT NOW(),
+    deleted_at TIMESTAMP WITHOUT TIME ZONE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS exchange_rates_currency_idx ON exchange_rates (tax_year, currency) WHERE deleted_at IS NULL;
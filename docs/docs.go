@@ -39,6 +39,155 @@ const docTemplate = `{
                 }
             }
         },
+        "/admin/deductions": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Lists every configurable deduction of the tax year with its amount, limits and default.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin/deductions"
+                ],
+                "summary": "List deductions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax year, defaults to the current year",
+                        "name": "taxYear",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully response with the deductions",
+                        "schema": {
+                            "$ref": "#/definitions/admin.DeductionsListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found if no deductions are configured for the tax year",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error if there is a problem getting the deductions",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/deductions/history": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Lists who changed which deduction, when, and from what amount to what amount, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin/deductions"
+                ],
+                "summary": "List deduction history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax year of the changed deduction",
+                        "name": "taxYear",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "personal",
+                            "donation",
+                            "k-receipt"
+                        ],
+                        "type": "string",
+                        "description": "Deduction type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Username of the admin who made the change",
+                        "name": "changedBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only changes made at or after this time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only changes made before this time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number, starting at 1, at most 100000",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of changes per page, at most 100",
+                        "name": "pageSize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully response with the deduction changes",
+                        "schema": {
+                            "$ref": "#/definitions/admin.DeductionsHistoryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error if there is a problem getting the history",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/admin/deductions/k-receipt": {
             "post": {
                 "security": [
@@ -76,7 +225,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request if the input validation fails"
+                        "description": "Bad request if the input validation fails or the amount is out of the limits"
                     },
                     "401": {
                         "description": "Unauthorized"
@@ -124,7 +273,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request if the input validation fails",
+                        "description": "Bad request if the input validation fails or the amount is out of the limits",
                         "schema": {
                             "$ref": "#/definitions/admin.ErrorResponse"
                         }
@@ -144,9 +293,74 @@ const docTemplate = `{
                 }
             }
         },
-        "/tax/calculations": {
+        "/admin/deductions/schedules": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Lists the scheduled deduction changes that have not taken effect yet, soonest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin/deductions"
+                ],
+                "summary": "List scheduled deductions",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax year of the scheduled deduction",
+                        "name": "taxYear",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "personal",
+                            "donation",
+                            "k-receipt"
+                        ],
+                        "type": "string",
+                        "description": "Deduction type",
+                        "name": "type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully response with the scheduled deductions",
+                        "schema": {
+                            "$ref": "#/definitions/admin.DeductionsSchedulesListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error if there is a problem getting the scheduled deductions",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "This endpoint calculates the tax and potentially applicable tax refund and tax levels based on the provided total income, withholding tax, and allowances.",
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Sets the amount of a deduction of the tax year from a future date on, within the limits of the deduction.\nScheduling the same deduction at the same date again replaces the pending amount.",
                 "consumes": [
                     "application/json"
                 ],
@@ -154,159 +368,2409 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "tax"
+                    "admin/deductions"
                 ],
-                "summary": "Calculate Tax",
+                "summary": "Schedule deduction",
                 "parameters": [
                     {
-                        "description": "Input request for tax calculation",
+                        "description": "Input request for scheduling the deduction",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/tax.CalculationsRequest"
+                            "$ref": "#/definitions/admin.DeductionsScheduleRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully calculated tax and returns the tax details",
+                    "201": {
+                        "description": "Successfully response with the scheduled deduction",
                         "schema": {
-                            "$ref": "#/definitions/tax.CalculationsResponse"
+                            "$ref": "#/definitions/admin.DeductionsScheduleResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad request if the input validation fails",
+                        "description": "Bad request if the input validation fails, the amount is out of the limits or the date is not in the future",
                         "schema": {
-                            "$ref": "#/definitions/tax.ErrorResponse"
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found if the deduction type is unknown or not configured for the tax year",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error if the tax calculations service fails",
+                        "description": "Internal Server Error if there is a problem scheduling the deduction",
                         "schema": {
-                            "$ref": "#/definitions/tax.ErrorResponse"
+                            "$ref": "#/definitions/admin.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/tax/calculations/upload-csv": {
-            "post": {
-                "description": "Uploads a CSV file and parses it to JSON.",
-                "consumes": [
-                    "multipart/form-data"
+        "/admin/deductions/schedules/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
                 ],
+                "description": "Withdraws a scheduled deduction change before it takes effect.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "tax"
+                    "admin/deductions"
                 ],
-                "summary": "Upload CSV file",
+                "summary": "Cancel scheduled deduction",
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "Upload CSV tax file",
-                        "name": "taxFile",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "Scheduled deduction ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully parsed tax data",
+                        "description": "Successfully response with the cancelled deduction",
                         "schema": {
-                            "$ref": "#/definitions/tax.UploadCSVResponse"
+                            "$ref": "#/definitions/admin.DeductionsScheduleResponse"
                         }
                     },
                     "400": {
-                        "description": "Unable to process the file, error in file retrieval or content",
+                        "description": "Bad request if the input validation fails",
                         "schema": {
-                            "$ref": "#/definitions/tax.ErrorResponse"
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found if there is no pending scheduled deduction with the ID",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error, failed to read CSV header or records",
+                        "description": "Internal Server Error if there is a problem cancelling the scheduled deduction",
                         "schema": {
-                            "$ref": "#/definitions/tax.ErrorResponse"
+                            "$ref": "#/definitions/admin.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "admin.DeductionsKReceiptRequest": {
-            "type": "object",
-            "required": [
-                "amount"
-            ],
-            "properties": {
-                "amount": {
-                    "type": "number",
-                    "maximum": 100000,
-                    "minimum": 1,
-                    "example": 50000
-                }
-            }
-        },
-        "admin.DeductionsKReceiptResponse": {
-            "type": "object",
-            "properties": {
-                "kReceipt": {
-                    "type": "number"
-                }
-            }
-        },
-        "admin.DeductionsPersonalRequest": {
-            "type": "object",
-            "properties": {
-                "amount": {
-                    "type": "number",
-                    "maximum": 100000,
-                    "minimum": 10000,
-                    "example": 60000
-                }
-            }
-        },
-        "admin.DeductionsPersonalResponse": {
-            "type": "object",
-            "properties": {
-                "personalDeduction": {
-                    "type": "number"
-                }
-            }
-        },
-        "admin.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string"
-                }
-            }
         },
-        "github_com_ztrixack_assessment-tax_internal_handlers_tax.Allowance": {
-            "type": "object",
-            "required": [
-                "allowanceType"
+        "/admin/deductions/{type}": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Gets the amount, limits and default of a deduction of the tax year.\nThe donation deduction is a percentage of the income left after the other deductions.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin/deductions"
+                ],
+                "summary": "Get deduction",
+                "parameters": [
+                    {
+                        "enum": [
+                            "personal",
+                            "donation",
+                            "k-receipt"
+                        ],
+                        "type": "string",
+                        "description": "Deduction type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tax year, defaults to the current year",
+                        "name": "taxYear",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully response with the deduction",
+                        "schema": {
+                            "$ref": "#/definitions/admin.DeductionsGetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found if the deduction type is unknown or not configured for the tax year",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error if there is a problem getting the deduction",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Sets the amount of a deduction of the tax year, within the limits of the deduction.\nThe donation deduction is set as a percentage of the income left after the other deductions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin/deductions"
+                ],
+                "summary": "Update deduction",
+                "parameters": [
+                    {
+                        "enum": [
+                            "personal",
+                            "donation",
+                            "k-receipt"
+                        ],
+                        "type": "string",
+                        "description": "Deduction type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Input request for setting the deduction",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/admin.DeductionsUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully response with updated deduction details",
+                        "schema": {
+                            "$ref": "#/definitions/admin.DeductionsUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails or the amount is out of the limits",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found if the deduction type is unknown or not configured for the tax year",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error if there is a problem setting the deduction",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Sets the amount of a deduction of the tax year back to its default.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin/deductions"
+                ],
+                "summary": "Reset deduction",
+                "parameters": [
+                    {
+                        "enum": [
+                            "personal",
+                            "donation",
+                            "k-receipt"
+                        ],
+                        "type": "string",
+                        "description": "Deduction type",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tax year, defaults to the current year",
+                        "name": "taxYear",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully response with the reset deduction details",
+                        "schema": {
+                            "$ref": "#/definitions/admin.DeductionsUpdateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found if the deduction type is unknown or not configured for the tax year",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error if there is a problem resetting the deduction",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/exchange-rates": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Lists the yearly average exchange rates of the tax year in baht per unit, by currency, which foreign currency amounts are converted by.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin/exchange-rates"
+                ],
+                "summary": "List exchange rates",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax year of the exchange rates",
+                        "name": "taxYear",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully response with the exchange rates",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ExchangeRatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error if there is a problem getting the exchange rates",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Sets the Bank of Thailand yearly average exchange rates of the tax year in baht per unit, replacing the rate of every currency uploaded.\nCurrencies left out of the upload keep the rate they have. The rates are set together, or not at all.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin/exchange-rates"
+                ],
+                "summary": "Upload exchange rates",
+                "parameters": [
+                    {
+                        "description": "Input request for uploading the exchange rates",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/admin.ExchangeRatesUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully response with the uploaded exchange rates",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ExchangeRatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails or a currency is uploaded twice",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error if there is a problem setting the exchange rates",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/late-filing-rules": {
+            "get": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Gets the surcharge rate per month and the fixed penalty of paying the tax of the tax year late, from the latest tax year configured up to it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin/late-filing-rules"
+                ],
+                "summary": "Get late filing rules",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Tax year, defaults to the current year",
+                        "name": "taxYear",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully response with the late filing rules",
+                        "schema": {
+                            "$ref": "#/definitions/admin.LateFilingRulesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found if no late filing rules are configured up to the tax year",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error if there is a problem getting the late filing rules",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BasicAuth": []
+                    }
+                ],
+                "description": "Sets the surcharge rate per month, with up to two decimals, and the fixed penalty of paying the tax of the tax year late.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin/late-filing-rules"
+                ],
+                "summary": "Set late filing rules",
+                "parameters": [
+                    {
+                        "description": "Input request for setting the late filing rules",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/admin.LateFilingRulesUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully response with the late filing rules set",
+                        "schema": {
+                            "$ref": "#/definitions/admin.LateFilingRulesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails or a rule is out of range",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error if there is a problem setting the late filing rules",
+                        "schema": {
+                            "$ref": "#/definitions/admin.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax/calculations": {
+            "post": {
+                "description": "This endpoint calculates the tax and potentially applicable tax refund and tax levels based on the provided total income, withholding tax, and allowances.\nIncomes typed by Revenue Code section 40 have their standard expense deducted, which is broken down in the response.\nWhen the income other than salary is above 120,000, the tax is the higher of the progressive tax and 0.5% of that gross income, and the method used is returned with both amounts.\nThe net income, the tax of every level, the tax and the refund are rounded by the configured policy, which is returned with the result.\nSeverance and provident fund lump sums are deducted 7,000 for every year of service and half of what is left, and taxed by the brackets apart from the other income. Their tax is added to the tax and broken down in the response.\nDividends paid with the corporate rate of the paying company are grossed up by the dividend tax credit, which is credited like the withholding tax and returned on its own.\nInterest and dividends taxed at source at the final rate are worked out both included in the return and left out, and the result is the option with the lower tax.\nThe filing status decides which allowances apply, such as the spouse allowance. Filing jointly or separately takes the income of the spouse, and returns the tax of both options with the cheaper one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Calculate Tax",
+                "parameters": [
+                    {
+                        "description": "Input request for tax calculation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tax.CalculationsRequest"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the trace of every step of the calculation, including each cap that was hit",
+                        "name": "explain",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully calculated tax and returns the tax details",
+                        "schema": {
+                            "$ref": "#/definitions/tax.CalculationsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails or an allowance does not apply to the filing status",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found if the tax year is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error if the tax calculations service fails",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax/calculations/compare": {
+            "post": {
+                "description": "Calculates the base and every named scenario against the same snapshot of allowance settings and tax brackets, and returns the change of each scenario from the base.\nThe incomes, withholding tax and allowances of a scenario are added to those of the base, after its raise is applied to the incomes of the base.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Compare tax scenarios",
+                "parameters": [
+                    {
+                        "description": "Input request for the comparison",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tax.CalculationsCompareRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully calculated the base and every scenario",
+                        "schema": {
+                            "$ref": "#/definitions/tax.CalculationsCompareResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails or scenario names are not unique",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found if the tax year is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error if the tax calculations service fails",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax/calculations/gross-up": {
+            "post": {
+                "description": "Solves for the lowest income whose calculation reaches the target, with the same allowances and withholding tax applied to it.\nThe target is the tax payable after the withholding tax, the income left after the tax, or the refund of the withholding tax.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Gross up income",
+                "parameters": [
+                    {
+                        "description": "Input request for the gross-up",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tax.CalculationsGrossUpRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully found the income and returns its calculation",
+                        "schema": {
+                            "$ref": "#/definitions/tax.CalculationsGrossUpResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails or no income reaches the target",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found if the tax year is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error if the gross-up service fails",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax/calculations/installments": {
+            "post": {
+                "description": "Calculates the tax and splits it into three monthly installments from the deadline on when it is at least 3,000 baht.\nThe installments are in whole satang, the last one making up the tax. A tax below the threshold is a single payment due by the deadline.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Plan tax installments",
+                "parameters": [
+                    {
+                        "description": "Input request for the calculation and the deadline",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tax.CalculationsInstallmentsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully returns the installments of the tax",
+                        "schema": {
+                            "$ref": "#/definitions/tax.CalculationsInstallmentsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found if the tax year is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error if the tax calculations service fails",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax/calculations/optimize": {
+            "post": {
+                "description": "Works out how much more donation, k-receipt, RMF and SSF spending on top of the current claims would still lower the tax, and by how much.\nEach allowance type is worked out on its own, within the configured caps and down to the net income where the brackets charge no more tax.\nNon-residents are only recommended the donation, as the other types are restricted to residents.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Optimize deductions",
+                "parameters": [
+                    {
+                        "description": "Input request with the current claims",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tax.CalculationsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully returns the recommendation of every allowance type",
+                        "schema": {
+                            "$ref": "#/definitions/tax.CalculationsOptimizeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found if the tax year is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error if the tax calculations service fails",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax/calculations/upload-csv": {
+            "post": {
+                "description": "Uploads a CSV file and parses it to JSON.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Upload CSV file",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Upload CSV tax file",
+                        "name": "taxFile",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tax year of every record in the file, defaults to the current year",
+                        "name": "taxYear",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully parsed tax data",
+                        "schema": {
+                            "$ref": "#/definitions/tax.UploadCSVResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Unable to process the file, error in file retrieval or content",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found if the tax year is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error, failed to read CSV header or records",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax/late-filing": {
+            "post": {
+                "description": "Charges the configured surcharge rate of the tax due for every month or part of a month from the deadline to the payment date, capped at the tax due.\nThe configured fixed penalty is added whenever the payment is late. The rate and the penalty are configured by the admin as the late filing rules.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Calculate late filing surcharge",
+                "parameters": [
+                    {
+                        "description": "Input request for the late filing surcharge",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tax.LateFilingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully calculated the late filing surcharge",
+                        "schema": {
+                            "$ref": "#/definitions/tax.LateFilingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found if the tax year is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error if the tax calculations service fails",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax/payroll/reconciliation": {
+            "post": {
+                "description": "Projects the annual tax from the income and withholding of every month paid to date and the monthly income expected for the rest of the tax year.\nReturns how much more or less than the projected tax was withheld to date, and the adjusted withholding of every month left. A negative balance is refunded when filing.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Reconcile payroll withholding",
+                "parameters": [
+                    {
+                        "description": "Input request for the reconciliation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tax.PayrollReconciliationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully reconciled the withholding to date",
+                        "schema": {
+                            "$ref": "#/definitions/tax.PayrollReconciliationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails or a month is paid twice",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found if the tax year is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error if the tax calculations service fails",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/tax/payroll/withholding": {
+            "post": {
+                "description": "Works out the tax withheld from a monthly salary on form PND1: the salary is annualised over the months it is paid, its expense and the allowances are deducted, and the annual tax is divided by those months.\nThe extra tax due to a bonus is withheld in full in the month the bonus is paid. The schedule covers the 12 months of the tax year and adds up to the annual tax.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tax"
+                ],
+                "summary": "Calculate payroll withholding",
+                "parameters": [
+                    {
+                        "description": "Input request for the payroll withholding",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/tax.PayrollWithholdingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully calculated the withholding of every month",
+                        "schema": {
+                            "$ref": "#/definitions/tax.PayrollWithholdingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request if the input validation fails or the bonus is paid before the start month",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found if the tax year is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error if the tax calculations service fails",
+                        "schema": {
+                            "$ref": "#/definitions/tax.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "admin.DeductionsGetResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 60000
+                },
+                "default": {
+                    "type": "number",
+                    "example": 60000
+                },
+                "maximum": {
+                    "type": "number",
+                    "example": 100000
+                },
+                "minimum": {
+                    "type": "number",
+                    "example": 10000
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "example": 2024
+                },
+                "type": {
+                    "type": "string",
+                    "example": "personal"
+                },
+                "unit": {
+                    "type": "string",
+                    "enum": [
+                        "amount",
+                        "percent"
+                    ],
+                    "example": "amount"
+                }
+            }
+        },
+        "admin.DeductionsHistoryChange": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "set"
+                },
+                "changedAt": {
+                    "type": "string",
+                    "example": "2024-03-01T10:00:00Z"
+                },
+                "changedBy": {
+                    "type": "string",
+                    "example": "adminTax"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "newAmount": {
+                    "type": "number",
+                    "example": 70000
+                },
+                "oldAmount": {
+                    "type": "number",
+                    "example": 60000
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "example": 2024
+                },
+                "type": {
+                    "type": "string",
+                    "example": "personal"
+                }
+            }
+        },
+        "admin.DeductionsHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/admin.DeductionsHistoryChange"
+                    }
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "pageSize": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "admin.DeductionsKReceiptRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount is checked against the limits of the deduction by the service.",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 50000
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1900,
+                    "example": 2024
+                }
+            }
+        },
+        "admin.DeductionsKReceiptResponse": {
+            "type": "object",
+            "properties": {
+                "kReceipt": {
+                    "type": "number"
+                }
+            }
+        },
+        "admin.DeductionsListResponse": {
+            "type": "object",
+            "properties": {
+                "deductions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/admin.DeductionsGetResponse"
+                    }
+                }
+            }
+        },
+        "admin.DeductionsPersonalRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount is checked against the limits of the deduction by the service.",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 60000
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1900,
+                    "example": 2024
+                }
+            }
+        },
+        "admin.DeductionsPersonalResponse": {
+            "type": "object",
+            "properties": {
+                "personalDeduction": {
+                    "type": "number"
+                }
+            }
+        },
+        "admin.DeductionsScheduleRequest": {
+            "type": "object",
+            "required": [
+                "effectiveFrom",
+                "type"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 70000
+                },
+                "effectiveFrom": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "taxYear": {
+                    "description": "TaxYear is the year of effectiveFrom when it is not set.",
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1900,
+                    "example": 2025
+                },
+                "type": {
+                    "type": "string",
+                    "example": "personal"
+                }
+            }
+        },
+        "admin.DeductionsScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 70000
+                },
+                "effectiveFrom": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "example": 2025
+                },
+                "type": {
+                    "type": "string",
+                    "example": "personal"
+                }
+            }
+        },
+        "admin.DeductionsSchedulesListResponse": {
+            "type": "object",
+            "properties": {
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/admin.DeductionsScheduleResponse"
+                    }
+                }
+            }
+        },
+        "admin.DeductionsUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 60000
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1900,
+                    "example": 2024
+                }
+            }
+        },
+        "admin.DeductionsUpdateResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 60000
+                },
+                "type": {
+                    "type": "string",
+                    "example": "personal"
+                }
+            }
+        },
+        "admin.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "admin.ExchangeRateDetail": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "rate": {
+                    "description": "Rate is in baht per unit of the currency.",
+                    "type": "number",
+                    "example": 35.2859
+                }
+            }
+        },
+        "admin.ExchangeRateUpload": {
+            "type": "object",
+            "required": [
+                "currency"
+            ],
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "rate": {
+                    "description": "Rate is in baht per unit of the currency.",
+                    "type": "number",
+                    "example": 35.2859
+                }
+            }
+        },
+        "admin.ExchangeRatesResponse": {
+            "type": "object",
+            "properties": {
+                "rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/admin.ExchangeRateDetail"
+                    }
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "example": 2024
+                }
+            }
+        },
+        "admin.ExchangeRatesUpdateRequest": {
+            "type": "object",
+            "required": [
+                "rates"
+            ],
+            "properties": {
+                "rates": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/admin.ExchangeRateUpload"
+                    }
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1900,
+                    "example": 2024
+                }
+            }
+        },
+        "admin.LateFilingRulesResponse": {
+            "type": "object",
+            "properties": {
+                "penalty": {
+                    "type": "number",
+                    "example": 200
+                },
+                "surchargeRate": {
+                    "description": "SurchargeRate is the percentage of the tax due charged for every month or part of a month it is paid late.",
+                    "type": "number",
+                    "example": 1.5
+                },
+                "taxYear": {
+                    "description": "TaxYear is the tax year the rules were set for, the latest one up to the tax year asked for.",
+                    "type": "integer",
+                    "example": 2024
+                }
+            }
+        },
+        "admin.LateFilingRulesUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "penalty": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 200
+                },
+                "surchargeRate": {
+                    "description": "SurchargeRate is the percentage of the tax due charged for every month or part of a month it is paid late.",
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 1.5
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1900,
+                    "example": 2024
+                }
+            }
+        },
+        "github_com_ztrixack_assessment-tax_internal_handlers_tax.Allowance": {
+            "type": "object",
+            "required": [
+                "allowanceType"
+            ],
+            "properties": {
+                "allowanceType": {
+                    "type": "string",
+                    "example": "donation"
+                },
+                "amount": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0
+                }
+            }
+        },
+        "github_com_ztrixack_assessment-tax_internal_handlers_tax.Income": {
+            "type": "object",
+            "required": [
+                "incomeType"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 600000
+                },
+                "corporateRate": {
+                    "description": "CorporateRate is the corporate income tax rate of the company paying a dividend, which the dividend tax credit is worked out by.",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0.2
+                },
+                "currency": {
+                    "description": "Currency is the currency of the amount and the foreign tax, THB when it is not set.",
+                    "type": "string",
+                    "example": "THB"
+                },
+                "finalWithholding": {
+                    "description": "FinalWithholding marks interest (40(4)(a)) and dividends (40(4)(b)) taxed at source at the final rate.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "foreign": {
+                    "description": "Foreign marks income sourced outside Thailand, of which the amount is the part remitted to Thailand.",
+                    "type": "boolean",
+                    "example": false
+                },
+                "foreignTax": {
+                    "description": "ForeignTax is the tax paid abroad on foreign-sourced income, credited up to the tax on that income.",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "incomeType": {
+                    "type": "string",
+                    "example": "40(1)"
+                }
+            }
+        },
+        "tax.AllowanceRecommendation": {
+            "type": "object",
+            "properties": {
+                "allowanceType": {
+                    "type": "string",
+                    "example": "k-receipt"
+                },
+                "claimed": {
+                    "type": "number",
+                    "example": 20000
+                },
+                "headroom": {
+                    "description": "Headroom is the most that claiming more of the type still lowers the tax by.",
+                    "type": "number",
+                    "example": 30000
+                },
+                "limit": {
+                    "type": "string",
+                    "enum": [
+                        "per-claim",
+                        "maximum",
+                        "configured",
+                        "income-rate",
+                        "group"
+                    ],
+                    "example": "configured"
+                },
+                "taxSaved": {
+                    "type": "number",
+                    "example": 3000
+                }
+            }
+        },
+        "tax.AppliedAllowance": {
+            "type": "object",
+            "properties": {
+                "allowanceType": {
+                    "type": "string",
+                    "example": "k-receipt"
+                },
+                "claimed": {
+                    "type": "number",
+                    "example": 80000
+                },
+                "deducted": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "group": {
+                    "type": "string",
+                    "example": "insurance"
+                },
+                "limit": {
+                    "type": "string",
+                    "enum": [
+                        "per-claim",
+                        "maximum",
+                        "configured",
+                        "income-rate",
+                        "group"
+                    ],
+                    "example": "configured"
+                }
+            }
+        },
+        "tax.CalculationExplanation": {
+            "type": "object",
+            "properties": {
+                "allowances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tax.AppliedAllowance"
+                    }
+                },
+                "dividendCredit": {
+                    "type": "number",
+                    "example": 0
+                },
+                "effectiveRate": {
+                    "type": "number",
+                    "example": 0.04
+                },
+                "foreignTaxCredit": {
+                    "type": "number",
+                    "example": 0
+                },
+                "income": {
+                    "type": "number",
+                    "example": 600000
+                },
+                "netIncome": {
+                    "type": "number",
+                    "example": 390000
+                },
+                "taxBeforeWht": {
+                    "type": "number",
+                    "example": 24000
+                },
+                "totalAllowances": {
+                    "type": "number",
+                    "example": 110000
+                },
+                "totalExpenses": {
+                    "type": "number",
+                    "example": 100000
+                },
+                "wht": {
+                    "type": "number",
+                    "example": 25000
+                }
+            }
+        },
+        "tax.CalculationScenario": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "allowances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ztrixack_assessment-tax_internal_handlers_tax.Allowance"
+                    }
+                },
+                "incomes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ztrixack_assessment-tax_internal_handlers_tax.Income"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "+k-receipt 50k"
+                },
+                "raise": {
+                    "description": "Raise changes every income of the base by a share, such as 0.1 for 10% more.",
+                    "type": "number",
+                    "example": 0.1
+                },
+                "totalIncome": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "wht": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0
+                }
+            }
+        },
+        "tax.CalculationsCompareRequest": {
+            "type": "object",
+            "required": [
+                "scenarios"
+            ],
+            "properties": {
+                "base": {
+                    "$ref": "#/definitions/tax.CalculationsRequest"
+                },
+                "scenarios": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/tax.CalculationScenario"
+                    }
+                }
+            }
+        },
+        "tax.CalculationsCompareResponse": {
+            "type": "object",
+            "properties": {
+                "base": {
+                    "$ref": "#/definitions/tax.CalculationsResponse"
+                },
+                "scenarios": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tax.ComparedScenario"
+                    }
+                }
+            }
+        },
+        "tax.CalculationsGrossUpRequest": {
+            "type": "object",
+            "required": [
+                "target"
+            ],
+            "properties": {
+                "allowances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ztrixack_assessment-tax_internal_handlers_tax.Allowance"
+                    }
+                },
+                "amount": {
+                    "description": "Amount is the tax payable, the income left after the tax or the refund to solve the income for.",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 471000
+                },
+                "incomeType": {
+                    "description": "IncomeType is the type of the income solved for, whose standard expense is deducted. The income is untyped when it is empty.",
+                    "type": "string",
+                    "example": "40(1)"
+                },
+                "target": {
+                    "type": "string",
+                    "enum": [
+                        "tax",
+                        "take-home",
+                        "refund"
+                    ],
+                    "example": "take-home"
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1900,
+                    "example": 2024
+                },
+                "wht": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0
+                }
+            }
+        },
+        "tax.CalculationsGrossUpResponse": {
+            "type": "object",
+            "properties": {
+                "calculation": {
+                    "$ref": "#/definitions/tax.CalculationsResponse"
+                },
+                "income": {
+                    "type": "number",
+                    "example": 500000
+                },
+                "takeHome": {
+                    "type": "number",
+                    "example": 471000
+                }
+            }
+        },
+        "tax.CalculationsInstallmentsRequest": {
+            "type": "object",
+            "required": [
+                "deadline"
             ],
             "properties": {
-                "allowanceType": {
+                "allowances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ztrixack_assessment-tax_internal_handlers_tax.Allowance"
+                    }
+                },
+                "deadline": {
+                    "description": "Deadline is the due date of the first installment.",
+                    "type": "string",
+                    "example": "2025-04-08T00:00:00Z"
+                },
+                "filingStatus": {
+                    "description": "FilingStatus is single when it is not set.",
+                    "type": "string",
+                    "enum": [
+                        "single",
+                        "spouse-no-income",
+                        "joint",
+                        "separate"
+                    ],
+                    "example": "single"
+                },
+                "incomes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ztrixack_assessment-tax_internal_handlers_tax.Income"
+                    }
+                },
+                "lumpSums": {
+                    "description": "LumpSums are taxed apart from the other income.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tax.LumpSumPayment"
+                    }
+                },
+                "residency": {
+                    "description": "Residency is resident when it is not set.",
+                    "type": "string",
+                    "enum": [
+                        "resident",
+                        "non-resident"
+                    ],
+                    "example": "resident"
+                },
+                "spouse": {
+                    "description": "Spouse is required to file jointly or separately.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/tax.Spouse"
+                        }
+                    ]
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1900,
+                    "example": 2024
+                },
+                "totalIncome": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 500000
+                },
+                "wht": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "withholdings": {
+                    "description": "Withholdings are withholding tax amounts in their currency, added to wht once converted to baht.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tax.WithholdingItem"
+                    }
+                }
+            }
+        },
+        "tax.CalculationsInstallmentsResponse": {
+            "type": "object",
+            "properties": {
+                "eligible": {
+                    "description": "Eligible is false when the tax is below the threshold and paid in full by the deadline.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "installments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tax.TaxInstallment"
+                    }
+                },
+                "tax": {
+                    "type": "number",
+                    "example": 29000
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "example": 2024
+                }
+            }
+        },
+        "tax.CalculationsOptimizeResponse": {
+            "type": "object",
+            "properties": {
+                "recommendations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tax.AllowanceRecommendation"
+                    }
+                },
+                "tax": {
+                    "type": "number",
+                    "example": 29000
+                },
+                "taxRefund": {
+                    "type": "number"
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "example": 2024
+                }
+            }
+        },
+        "tax.CalculationsRequest": {
+            "type": "object",
+            "properties": {
+                "allowances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ztrixack_assessment-tax_internal_handlers_tax.Allowance"
+                    }
+                },
+                "filingStatus": {
+                    "description": "FilingStatus is single when it is not set.",
+                    "type": "string",
+                    "enum": [
+                        "single",
+                        "spouse-no-income",
+                        "joint",
+                        "separate"
+                    ],
+                    "example": "single"
+                },
+                "incomes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ztrixack_assessment-tax_internal_handlers_tax.Income"
+                    }
+                },
+                "lumpSums": {
+                    "description": "LumpSums are taxed apart from the other income.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tax.LumpSumPayment"
+                    }
+                },
+                "residency": {
+                    "description": "Residency is resident when it is not set.",
+                    "type": "string",
+                    "enum": [
+                        "resident",
+                        "non-resident"
+                    ],
+                    "example": "resident"
+                },
+                "spouse": {
+                    "description": "Spouse is required to file jointly or separately.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/tax.Spouse"
+                        }
+                    ]
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1900,
+                    "example": 2024
+                },
+                "totalIncome": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 500000
+                },
+                "wht": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "withholdings": {
+                    "description": "Withholdings are withholding tax amounts in their currency, added to wht once converted to baht.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tax.WithholdingItem"
+                    }
+                }
+            }
+        },
+        "tax.CalculationsResponse": {
+            "type": "object",
+            "properties": {
+                "conversions": {
+                    "description": "Conversions are only returned when an amount is in a foreign currency.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tax.CurrencyConversion"
+                    }
+                },
+                "dividendCredit": {
+                    "type": "number",
+                    "example": 0
+                },
+                "exemptForeignIncome": {
+                    "description": "ExemptForeignIncome is the foreign-sourced income left out of the return of a non-resident.",
+                    "type": "number",
+                    "example": 0
+                },
+                "expenses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tax.Expense"
+                    }
+                },
+                "explanation": {
+                    "description": "Explanation is only returned when asked for with the explain query parameter.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/tax.CalculationExplanation"
+                        }
+                    ]
+                },
+                "filing": {
+                    "description": "Filing is only returned when filing jointly or separately.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/tax.FilingOptions"
+                        }
+                    ]
+                },
+                "filingStatus": {
+                    "type": "string",
+                    "enum": [
+                        "single",
+                        "spouse-no-income",
+                        "joint",
+                        "separate"
+                    ],
+                    "example": "single"
+                },
+                "finalWithholding": {
+                    "description": "FinalWithholding is only returned when an income is taxed at source at the final rate.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/tax.FinalWithholdingOptions"
+                        }
+                    ]
+                },
+                "foreignTaxCredit": {
+                    "description": "ForeignTaxCredit is the foreign tax paid credited against the tax.",
+                    "type": "number",
+                    "example": 0
+                },
+                "grossIncomeTax": {
+                    "type": "number",
+                    "example": 0
+                },
+                "lumpSum": {
+                    "description": "LumpSum is only returned when there are lump sums.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/tax.LumpSumAssessment"
+                        }
+                    ]
+                },
+                "progressiveTax": {
+                    "type": "number",
+                    "example": 29000
+                },
+                "residency": {
+                    "type": "string",
+                    "enum": [
+                        "resident",
+                        "non-resident"
+                    ],
+                    "example": "resident"
+                },
+                "rounding": {
+                    "$ref": "#/definitions/tax.Rounding"
+                },
+                "tax": {
+                    "type": "number"
+                },
+                "taxLevel": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tax.TaxLevel"
+                    }
+                },
+                "taxMethod": {
+                    "type": "string",
+                    "enum": [
+                        "progressive",
+                        "gross-income"
+                    ],
+                    "example": "progressive"
+                },
+                "taxRefund": {
+                    "type": "number"
+                },
+                "taxYear": {
+                    "type": "integer"
+                }
+            }
+        },
+        "tax.ComparedScenario": {
+            "type": "object",
+            "properties": {
+                "calculation": {
+                    "$ref": "#/definitions/tax.CalculationsResponse"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "+k-receipt 50k"
+                },
+                "refundDelta": {
+                    "type": "number",
+                    "example": 0
+                },
+                "taxDelta": {
+                    "type": "number",
+                    "example": -5000
+                },
+                "totalTaxDelta": {
+                    "description": "TotalTaxDelta is the change of the tax before the withholding tax is credited.",
+                    "type": "number",
+                    "example": -5000
+                }
+            }
+        },
+        "tax.CurrencyConversion": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 20000
+                },
+                "converted": {
+                    "type": "number",
+                    "example": 710000
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "USD"
+                },
+                "incomeType": {
+                    "description": "IncomeType is the type of the income converted, or the income the foreign tax was paid on.",
+                    "type": "string",
+                    "example": "40(1)"
+                },
+                "item": {
+                    "type": "string",
+                    "enum": [
+                        "income",
+                        "foreign-tax",
+                        "wht"
+                    ],
+                    "example": "income"
+                },
+                "rate": {
+                    "type": "number",
+                    "example": 35.5
+                },
+                "spouse": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "tax.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "tax.Expense": {
+            "type": "object",
+            "properties": {
+                "expense": {
+                    "type": "number",
+                    "example": 100000
+                },
+                "income": {
+                    "type": "number",
+                    "example": 600000
+                },
+                "incomeType": {
+                    "type": "string",
+                    "example": "40(1)"
+                }
+            }
+        },
+        "tax.FilingOptions": {
+            "type": "object",
+            "properties": {
+                "cheaper": {
+                    "type": "string",
+                    "enum": [
+                        "joint",
+                        "separate"
+                    ],
+                    "example": "separate"
+                },
+                "joint": {
+                    "$ref": "#/definitions/tax.FilingTax"
+                },
+                "separate": {
+                    "$ref": "#/definitions/tax.FilingTax"
+                }
+            }
+        },
+        "tax.FilingTax": {
+            "type": "object",
+            "properties": {
+                "filingStatus": {
                     "type": "string",
                     "enum": [
-                        "donation",
-                        "k-receipt"
+                        "joint",
+                        "separate"
                     ],
-                    "example": "donation"
+                    "example": "separate"
+                },
+                "tax": {
+                    "type": "number",
+                    "example": 38000
+                },
+                "taxRefund": {
+                    "type": "number"
+                },
+                "totalTax": {
+                    "type": "number",
+                    "example": 38000
+                }
+            }
+        },
+        "tax.FinalWithholdingOptions": {
+            "type": "object",
+            "properties": {
+                "excluded": {
+                    "$ref": "#/definitions/tax.FinalWithholdingTax"
+                },
+                "include": {
+                    "description": "Include recommends including the income in the return, which the result is worked out by.",
+                    "type": "boolean",
+                    "example": true
+                },
+                "included": {
+                    "$ref": "#/definitions/tax.FinalWithholdingTax"
+                },
+                "income": {
+                    "type": "number",
+                    "example": 100000
+                },
+                "withheld": {
+                    "type": "number",
+                    "example": 10000
+                }
+            }
+        },
+        "tax.FinalWithholdingTax": {
+            "type": "object",
+            "properties": {
+                "tax": {
+                    "type": "number",
+                    "example": 0
+                },
+                "taxRefund": {
+                    "type": "number"
+                },
+                "totalTax": {
+                    "description": "TotalTax is the tax before the withholding tax is credited, with the tax withheld at source when the income is left out.",
+                    "type": "number",
+                    "example": 9000
+                }
+            }
+        },
+        "tax.LateFilingRequest": {
+            "type": "object",
+            "required": [
+                "deadline",
+                "paidAt"
+            ],
+            "properties": {
+                "deadline": {
+                    "type": "string",
+                    "example": "2025-04-08T00:00:00Z"
+                },
+                "paidAt": {
+                    "type": "string",
+                    "example": "2025-05-09T00:00:00Z"
+                },
+                "tax": {
+                    "description": "Tax is the tax due, such as the tax of a calculation.",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 100000
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1900,
+                    "example": 2024
+                }
+            }
+        },
+        "tax.LateFilingResponse": {
+            "type": "object",
+            "properties": {
+                "monthsLate": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "penalty": {
+                    "type": "number",
+                    "example": 200
+                },
+                "rate": {
+                    "description": "Rate is the percentage of the tax charged for every month or part of a month late.",
+                    "type": "number",
+                    "example": 1.5
+                },
+                "surcharge": {
+                    "type": "number",
+                    "example": 3000
+                },
+                "tax": {
+                    "type": "number",
+                    "example": 100000
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "example": 2024
                 },
+                "total": {
+                    "type": "number",
+                    "example": 103200
+                }
+            }
+        },
+        "tax.LumpSumAssessment": {
+            "type": "object",
+            "properties": {
+                "deduction": {
+                    "description": "Deduction is 7,000 for every year of service, and half of what is left.",
+                    "type": "number",
+                    "example": 535000
+                },
+                "income": {
+                    "type": "number",
+                    "example": 1000000
+                },
+                "netIncome": {
+                    "type": "number",
+                    "example": 465000
+                },
+                "tax": {
+                    "type": "number",
+                    "example": 31500
+                },
+                "taxLevel": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tax.TaxLevel"
+                    }
+                }
+            }
+        },
+        "tax.LumpSumPayment": {
+            "type": "object",
+            "required": [
+                "lumpSumType",
+                "yearsOfService"
+            ],
+            "properties": {
                 "amount": {
                     "type": "number",
                     "minimum": 0,
+                    "example": 1000000
+                },
+                "lumpSumType": {
+                    "type": "string",
+                    "enum": [
+                        "severance",
+                        "provident-fund"
+                    ],
+                    "example": "severance"
+                },
+                "yearsOfService": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 10
+                }
+            }
+        },
+        "tax.PaidPayrollMonth": {
+            "type": "object",
+            "required": [
+                "month"
+            ],
+            "properties": {
+                "income": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 40000
+                },
+                "month": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1,
+                    "example": 1
+                },
+                "withholding": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 1000
+                }
+            }
+        },
+        "tax.PayrollReconciliationRequest": {
+            "type": "object",
+            "required": [
+                "months"
+            ],
+            "properties": {
+                "allowances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ztrixack_assessment-tax_internal_handlers_tax.Allowance"
+                    }
+                },
+                "monthlyIncome": {
+                    "description": "MonthlyIncome is the salary expected in every month left, the income of the last paid month when it is not set.",
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 50000
+                },
+                "months": {
+                    "type": "array",
+                    "maxItems": 12,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/tax.PaidPayrollMonth"
+                    }
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1900,
+                    "example": 2024
+                }
+            }
+        },
+        "tax.PayrollReconciliationResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "description": "Balance is the tax left to pay when filing, negative for a refund.",
+                    "type": "number",
                     "example": 0
+                },
+                "expectedToDate": {
+                    "type": "number",
+                    "example": 5473.68
+                },
+                "incomeToDate": {
+                    "type": "number",
+                    "example": 120000
+                },
+                "overWithheld": {
+                    "type": "number",
+                    "example": -2473.68
+                },
+                "projectedIncome": {
+                    "type": "number",
+                    "example": 570000
+                },
+                "projectedTax": {
+                    "type": "number",
+                    "example": 26000
+                },
+                "remaining": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tax.WithholdingMonth"
+                    }
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "example": 2024
+                },
+                "withheldToDate": {
+                    "type": "number",
+                    "example": 3000
                 }
             }
         },
-        "tax.CalculationsRequest": {
+        "tax.PayrollWithholdingRequest": {
             "type": "object",
             "properties": {
                 "allowances": {
@@ -315,40 +2779,131 @@ const docTemplate = `{
                         "$ref": "#/definitions/github_com_ztrixack_assessment-tax_internal_handlers_tax.Allowance"
                     }
                 },
-                "totalIncome": {
+                "bonus": {
                     "type": "number",
                     "minimum": 0,
-                    "example": 500000
+                    "example": 100000
                 },
-                "wht": {
+                "bonusMonth": {
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 1,
+                    "example": 3
+                },
+                "monthlySalary": {
                     "type": "number",
                     "minimum": 0,
-                    "example": 0
+                    "example": 50000
+                },
+                "startMonth": {
+                    "description": "StartMonth is the first month the salary is paid in the tax year, January when it is not set.",
+                    "type": "integer",
+                    "maximum": 12,
+                    "minimum": 0,
+                    "example": 1
+                },
+                "taxYear": {
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 1900,
+                    "example": 2024
                 }
             }
         },
-        "tax.CalculationsResponse": {
+        "tax.PayrollWithholdingResponse": {
             "type": "object",
             "properties": {
-                "tax": {
-                    "type": "number"
+                "annualIncome": {
+                    "type": "number",
+                    "example": 700000
                 },
-                "taxLevel": {
+                "annualTax": {
+                    "type": "number",
+                    "example": 41000
+                },
+                "bonusTax": {
+                    "type": "number",
+                    "example": 12000
+                },
+                "schedule": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/tax.TaxLevel"
+                        "$ref": "#/definitions/tax.WithholdingMonth"
                     }
                 },
-                "taxRefund": {
-                    "type": "number"
+                "taxYear": {
+                    "type": "integer",
+                    "example": 2024
                 }
             }
         },
-        "tax.ErrorResponse": {
+        "tax.Rounding": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string"
+                "netIncome": {
+                    "$ref": "#/definitions/tax.RoundingRule"
+                },
+                "policy": {
+                    "type": "string",
+                    "enum": [
+                        "satang",
+                        "revenue-department"
+                    ],
+                    "example": "satang"
+                },
+                "refund": {
+                    "$ref": "#/definitions/tax.RoundingRule"
+                },
+                "tax": {
+                    "$ref": "#/definitions/tax.RoundingRule"
+                },
+                "taxLevel": {
+                    "$ref": "#/definitions/tax.RoundingRule"
+                }
+            }
+        },
+        "tax.RoundingRule": {
+            "type": "object",
+            "properties": {
+                "mode": {
+                    "type": "string",
+                    "enum": [
+                        "half-up",
+                        "down",
+                        "up"
+                    ],
+                    "example": "half-up"
+                },
+                "unit": {
+                    "type": "number",
+                    "example": 0.01
+                }
+            }
+        },
+        "tax.Spouse": {
+            "type": "object",
+            "properties": {
+                "allowances": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ztrixack_assessment-tax_internal_handlers_tax.Allowance"
+                    }
+                },
+                "incomes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_ztrixack_assessment-tax_internal_handlers_tax.Income"
+                    }
+                },
+                "totalIncome": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "wht": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 0
                 }
             }
         },
@@ -366,6 +2921,23 @@ const docTemplate = `{
                 }
             }
         },
+        "tax.TaxInstallment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 9666.66
+                },
+                "dueDate": {
+                    "type": "string",
+                    "example": "2025-04-08T00:00:00Z"
+                },
+                "number": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
         "tax.TaxLevel": {
             "type": "object",
             "properties": {
@@ -387,6 +2959,42 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "tax.WithholdingItem": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 1000
+                },
+                "currency": {
+                    "description": "Currency is THB when it is not set.",
+                    "type": "string",
+                    "example": "USD"
+                }
+            }
+        },
+        "tax.WithholdingMonth": {
+            "type": "object",
+            "properties": {
+                "bonus": {
+                    "type": "number",
+                    "example": 100000
+                },
+                "month": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "salary": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "withholding": {
+                    "type": "number",
+                    "example": 14416.67
+                }
+            }
         }
     },
     "securityDefinitions": {
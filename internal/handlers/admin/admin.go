@@ -21,4 +21,16 @@ func New(log logger.Logger, e api.API, admin admin.Servicer) *handler {
 func (h handler) setupRoutes(r api.Router) {
 	r.POST("/admin/deductions/personal", h.DeductionsPersonal, middlewares.BasicAuth(h.log))
 	r.POST("/admin/deductions/k-receipt", h.DeductionsKReceipt, middlewares.BasicAuth(h.log))
+	r.GET("/admin/deductions", h.DeductionsList, middlewares.BasicAuth(h.log))
+	r.GET("/admin/deductions/history", h.DeductionsHistory, middlewares.BasicAuth(h.log))
+	r.GET("/admin/deductions/schedules", h.DeductionsSchedulesList, middlewares.BasicAuth(h.log))
+	r.POST("/admin/deductions/schedules", h.DeductionsSchedule, middlewares.BasicAuth(h.log))
+	r.DELETE("/admin/deductions/schedules/:id", h.DeductionsScheduleCancel, middlewares.BasicAuth(h.log))
+	r.GET("/admin/deductions/:type", h.DeductionsGet, middlewares.BasicAuth(h.log))
+	r.PUT("/admin/deductions/:type", h.DeductionsUpdate, middlewares.BasicAuth(h.log))
+	r.DELETE("/admin/deductions/:type", h.DeductionsReset, middlewares.BasicAuth(h.log))
+	r.GET("/admin/exchange-rates", h.ExchangeRatesList, middlewares.BasicAuth(h.log))
+	r.PUT("/admin/exchange-rates", h.ExchangeRatesUpdate, middlewares.BasicAuth(h.log))
+	r.GET("/admin/late-filing-rules", h.LateFilingRulesGet, middlewares.BasicAuth(h.log))
+	r.PUT("/admin/late-filing-rules", h.LateFilingRulesUpdate, middlewares.BasicAuth(h.log))
 }
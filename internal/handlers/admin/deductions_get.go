@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+type DeductionsGetRequest struct {
+	Type    string `param:"type" validate:"required" example:"personal"`
+	TaxYear int    `query:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2024"`
+}
+
+type DeductionsGetResponse struct {
+	TaxYear int         `json:"taxYear" example:"2024"`
+	Type    string      `json:"type" example:"personal"`
+	Unit    string      `json:"unit" example:"amount" enums:"amount,percent"`
+	Amount  money.Money `json:"amount" example:"60000.0"`
+	Minimum money.Money `json:"minimum" example:"10000.0"`
+	Maximum money.Money `json:"maximum" example:"100000.0"`
+	Default money.Money `json:"default" example:"60000.0"`
+}
+
+// DeductionsGet gets a deduction by its type.
+//
+//	@summary		Get deduction
+//	@description	Gets the amount, limits and default of a deduction of the tax year.
+//	@description	The donation deduction is a percentage of the income left after the other deductions.
+//	@tags			admin/deductions
+//	@produce		json
+//	@param			type	path	string	true	"Deduction type"	Enums(personal, donation, k-receipt)
+//	@param			taxYear	query	int		false	"Tax year, defaults to the current year"
+//	@security		BasicAuth
+//	@success		200	{object}	DeductionsGetResponse	"Successfully response with the deduction"
+//	@failure		400	{object}	ErrorResponse			"Bad request if the input validation fails"
+//	@failure		401	{object}	ErrorResponse			"Unauthorized"
+//	@failure		404	{object}	ErrorResponse			"Not found if the deduction type is unknown or not configured for the tax year"
+//	@failure		500	{object}	ErrorResponse			"Internal Server Error if there is a problem getting the deduction"
+//	@router			/admin/deductions/{type} [get]
+func (h handler) DeductionsGet(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var req DeductionsGetRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.admin.GetDeduction(ctx, req.toServiceRequest())
+	if err != nil {
+		h.log.Err(err).E("Failed to get %s deduction", req.Type)
+		return c.JSON(toServiceErrorResponse(err, ErrGetDeduction))
+	}
+
+	return c.JSON(http.StatusOK, toDeductionsGetResponse(*res))
+}
+
+func (r *DeductionsGetRequest) toServiceRequest() admin.DeductionRequest {
+	return admin.DeductionRequest{
+		TaxYear: r.TaxYear,
+		Type:    admin.DeductionType(r.Type),
+	}
+}
+
+func toDeductionsGetResponse(d admin.Deduction) DeductionsGetResponse {
+	return DeductionsGetResponse{
+		TaxYear: d.TaxYear,
+		Type:    string(d.Type),
+		Unit:    string(d.Unit),
+		Amount:  d.Amount,
+		Minimum: d.Minimum,
+		Maximum: d.Maximum,
+		Default: d.Default,
+	}
+}
@@ -0,0 +1,97 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestDeductionsGet(t *testing.T) {
+	donation := admin.DeductionRule{Type: admin.Donation, Unit: admin.UnitPercent, Minimum: 0, Maximum: 100 * money.Baht, Default: 10 * money.Baht}
+
+	tests := []struct {
+		name         string
+		mockBehavior func(*admin.MockService)
+		dtype        string
+		query        string
+		expected     DeductionsGetResponse
+		expectedCode int
+	}{
+		{
+			name: "Normal case",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("GetDeduction", mock.Anything, admin.DeductionRequest{TaxYear: 2024, Type: admin.Donation}).
+					Return(&admin.Deduction{DeductionRule: donation, TaxYear: 2024, Amount: 8 * money.Baht}, nil)
+			},
+			dtype: "donation",
+			query: "?taxYear=2024",
+			expected: DeductionsGetResponse{
+				TaxYear: 2024, Type: "donation", Unit: "percent", Amount: 8 * money.Baht, Minimum: 0, Maximum: 100 * money.Baht, Default: 10 * money.Baht,
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Request parameters are invalid on Validate",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			dtype:        "",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Unknown deduction type",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("GetDeduction", mock.Anything, mock.Anything).Return(nil, admin.ErrInvalidDeductionType)
+			},
+			dtype:        "unknown",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Service is broken",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("GetDeduction", mock.Anything, mock.Anything).Return(nil, admin.ErrQueryDatabase)
+			},
+			dtype:        "personal",
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/deductions/"+tt.dtype+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+			c.SetParamNames("type")
+			c.SetParamValues(tt.dtype)
+
+			log := logger.NewMockLogger()
+			ms := new(admin.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err := h.DeductionsGet(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result DeductionsGetResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+type DeductionsHistoryRequest struct {
+	TaxYear   int       `query:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2024"`
+	Type      string    `query:"type" example:"personal"`
+	ChangedBy string    `query:"changedBy" example:"adminTax"`
+	From      time.Time `query:"from" example:"2024-01-01T00:00:00Z"`
+	To        time.Time `query:"to" example:"2025-01-01T00:00:00Z"`
+	Page      int       `query:"page" validate:"omitempty,min=1,max=100000" example:"1"`
+	PageSize  int       `query:"pageSize" validate:"omitempty,min=1,max=100" example:"20"`
+}
+
+type DeductionsHistoryResponse struct {
+	Changes  []DeductionsHistoryChange `json:"changes"`
+	Total    int                       `json:"total" example:"1"`
+	Page     int                       `json:"page" example:"1"`
+	PageSize int                       `json:"pageSize" example:"20"`
+}
+
+type DeductionsHistoryChange struct {
+	ID        int64       `json:"id" example:"1"`
+	TaxYear   int         `json:"taxYear" example:"2024"`
+	Type      string      `json:"type" example:"personal"`
+	Action    string      `json:"action" example:"set"`
+	OldAmount money.Money `json:"oldAmount" example:"60000.0"`
+	NewAmount money.Money `json:"newAmount" example:"70000.0"`
+	ChangedBy string      `json:"changedBy" example:"adminTax"`
+	ChangedAt time.Time   `json:"changedAt" example:"2024-03-01T10:00:00Z"`
+}
+
+// DeductionsHistory lists the audit trail of deduction changes.
+//
+//	@summary		List deduction history
+//	@description	Lists who changed which deduction, when, and from what amount to what amount, newest first.
+//	@tags			admin/deductions
+//	@produce		json
+//	@param			taxYear		query	int		false	"Tax year of the changed deduction"
+//	@param			type		query	string	false	"Deduction type"	Enums(personal, donation, k-receipt)
+//	@param			changedBy	query	string	false	"Username of the admin who made the change"
+//	@param			from		query	string	false	"Only changes made at or after this time (RFC 3339)"
+//	@param			to			query	string	false	"Only changes made before this time (RFC 3339)"
+//	@param			page		query	int		false	"Page number, starting at 1, at most 100000"
+//	@param			pageSize	query	int		false	"Number of changes per page, at most 100"
+//	@security		BasicAuth
+//	@success		200	{object}	DeductionsHistoryResponse	"Successfully response with the deduction changes"
+//	@failure		400	{object}	ErrorResponse				"Bad request if the input validation fails"
+//	@failure		401	{object}	ErrorResponse				"Unauthorized"
+//	@failure		500	{object}	ErrorResponse				"Internal Server Error if there is a problem getting the history"
+//	@router			/admin/deductions/history [get]
+func (h handler) DeductionsHistory(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var req DeductionsHistoryRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.admin.ListDeductionHistory(ctx, req.toServiceRequest())
+	if err != nil {
+		h.log.Err(err).E("Failed to list deduction history")
+		return c.JSON(toServiceErrorResponse(err, ErrListDeductionHistory))
+	}
+
+	changes := make([]DeductionsHistoryChange, len(res.Changes))
+	for i, change := range res.Changes {
+		changes[i] = toDeductionsHistoryChange(change)
+	}
+
+	return c.JSON(http.StatusOK, DeductionsHistoryResponse{
+		Changes:  changes,
+		Total:    res.Total,
+		Page:     res.Page,
+		PageSize: res.PageSize,
+	})
+}
+
+func (r *DeductionsHistoryRequest) toServiceRequest() admin.ListDeductionHistoryRequest {
+	return admin.ListDeductionHistoryRequest{
+		TaxYear:   r.TaxYear,
+		Type:      admin.DeductionType(r.Type),
+		ChangedBy: r.ChangedBy,
+		From:      r.From,
+		To:        r.To,
+		Page:      r.Page,
+		PageSize:  r.PageSize,
+	}
+}
+
+func toDeductionsHistoryChange(change admin.DeductionChange) DeductionsHistoryChange {
+	return DeductionsHistoryChange{
+		ID:        change.ID,
+		TaxYear:   change.TaxYear,
+		Type:      string(change.Type),
+		Action:    string(change.Action),
+		OldAmount: change.OldAmount,
+		NewAmount: change.NewAmount,
+		ChangedBy: change.ChangedBy,
+		ChangedAt: change.ChangedAt,
+	}
+}
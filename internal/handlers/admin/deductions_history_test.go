@@ -0,0 +1,130 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestDeductionsHistory(t *testing.T) {
+	changedAt := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		mockBehavior func(*admin.MockService)
+		query        string
+		expected     DeductionsHistoryResponse
+		expectedCode int
+	}{
+		{
+			name: "Normal case",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ListDeductionHistory", mock.Anything, admin.ListDeductionHistoryRequest{}).Return(&admin.DeductionHistory{
+					Changes: []admin.DeductionChange{
+						{ID: 2, TaxYear: 2024, Type: admin.Personal, Action: admin.ActionReset, OldAmount: 70000 * money.Baht, NewAmount: 60000 * money.Baht, ChangedBy: "adminTax", ChangedAt: changedAt.Add(time.Hour)},
+						{ID: 1, TaxYear: 2024, Type: admin.Personal, Action: admin.ActionSet, OldAmount: 60000 * money.Baht, NewAmount: 70000 * money.Baht, ChangedBy: "adminTax", ChangedAt: changedAt},
+					},
+					Total:    2,
+					Page:     1,
+					PageSize: 20,
+				}, nil)
+			},
+			expected: DeductionsHistoryResponse{
+				Changes: []DeductionsHistoryChange{
+					{ID: 2, TaxYear: 2024, Type: "personal", Action: "reset", OldAmount: 70000 * money.Baht, NewAmount: 60000 * money.Baht, ChangedBy: "adminTax", ChangedAt: changedAt.Add(time.Hour)},
+					{ID: 1, TaxYear: 2024, Type: "personal", Action: "set", OldAmount: 60000 * money.Baht, NewAmount: 70000 * money.Baht, ChangedBy: "adminTax", ChangedAt: changedAt},
+				},
+				Total:    2,
+				Page:     1,
+				PageSize: 20,
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "With filters",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ListDeductionHistory", mock.Anything, admin.ListDeductionHistoryRequest{
+					TaxYear:   2024,
+					Type:      admin.KReceipt,
+					ChangedBy: "adminTax",
+					From:      changedAt,
+					To:        changedAt.AddDate(0, 1, 0),
+					Page:      2,
+					PageSize:  10,
+				}).Return(&admin.DeductionHistory{Changes: []admin.DeductionChange{}, Total: 10, Page: 2, PageSize: 10}, nil)
+			},
+			query:        "?taxYear=2024&type=k-receipt&changedBy=adminTax&from=2024-03-01T10:00:00Z&to=2024-04-01T10:00:00Z&page=2&pageSize=10",
+			expected:     DeductionsHistoryResponse{Changes: []DeductionsHistoryChange{}, Total: 10, Page: 2, PageSize: 10},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Request parameters are invalid on Bind",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			query:        "?from=yesterday",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Request parameters are invalid on Validate",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			query:        "?pageSize=1000",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Page is too far to be listed",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			query:        "?page=4611686018427387904",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Service error",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ListDeductionHistory", mock.Anything, mock.Anything).Return(nil, admin.ErrQueryDatabase)
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/deductions/history"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+
+			log := logger.NewMockLogger()
+			ms := new(admin.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err := h.DeductionsHistory(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result DeductionsHistoryResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
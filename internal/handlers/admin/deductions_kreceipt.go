@@ -6,16 +6,20 @@ import (
 	"time"
 
 	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/api/middlewares"
 	"github.com/ztrixack/assessment-tax/internal/modules/logger"
 	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
 type DeductionsKReceiptRequest struct {
-	Amount float64 `json:"amount" validate:"required,min=1,max=100000" example:"50000.0"`
+	TaxYear int `json:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2024"`
+	// Amount is checked against the limits of the deduction by the service.
+	Amount money.Money `json:"amount" validate:"min=0" example:"50000.0"`
 }
 
 type DeductionsKReceiptResponse struct {
-	KReceipt float64 `json:"kReceipt"`
+	KReceipt money.Money `json:"kReceipt"`
 }
 
 // DeductionsKReceipt sets a k-receipt deduction by admin.
@@ -28,7 +32,7 @@ type DeductionsKReceiptResponse struct {
 //	@param			request	body	DeductionsKReceiptRequest	true	"Input request for setting k-receipt deduction"
 //	@security		BasicAuth
 //	@success		200			{object}		DeductionsKReceiptResponse	"Successfully response with updated deduction details"
-//	@failure		{object}	ErrorResponse	400							"Bad request if the input validation fails"
+//	@failure		{object}	ErrorResponse	400							"Bad request if the input validation fails or the amount is out of the limits"
 //	@failure		{object}	ErrorResponse	401							"Unauthorized"
 //	@failure		{object}	ErrorResponse	500							"Internal Server Error if there is a problem setting the deduction"
 //	@router			/admin/deductions/k-receipt [post]
@@ -51,18 +55,20 @@ func (h handler) DeductionsKReceipt(c api.Context) error {
 		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
 	}
 
-	res, err := h.admin.SetDeduction(ctx, req.toServiceRequest())
+	res, err := h.admin.SetDeduction(ctx, req.toServiceRequest(middlewares.Username(c)))
 	if err != nil {
 		h.log.Err(err).E("Failed to set KReceipt deduction")
-		return c.JSON(http.StatusInternalServerError, toErrorResponse(ErrDeductKReceipt))
+		return c.JSON(toServiceErrorResponse(err, ErrDeductKReceipt))
 	}
 
 	return c.JSON(http.StatusOK, DeductionsKReceiptResponse{KReceipt: res})
 }
 
-func (r *DeductionsKReceiptRequest) toServiceRequest() admin.SetDeductionRequest {
+func (r *DeductionsKReceiptRequest) toServiceRequest(changedBy string) admin.SetDeductionRequest {
 	return admin.SetDeductionRequest{
-		Type:   admin.KReceipt,
-		Amount: r.Amount,
+		TaxYear:   r.TaxYear,
+		Type:      admin.KReceipt,
+		Amount:    r.Amount,
+		ChangedBy: changedBy,
 	}
 }
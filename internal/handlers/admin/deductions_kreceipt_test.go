@@ -14,6 +14,7 @@ import (
 	"github.com/ztrixack/assessment-tax/internal/modules/logger"
 	"github.com/ztrixack/assessment-tax/internal/services/admin"
 	"github.com/ztrixack/assessment-tax/internal/utils/constants"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
 func TestDeductionsKReceiptRequest(t *testing.T) {
@@ -28,28 +29,28 @@ func TestDeductionsKReceiptRequest(t *testing.T) {
 		{
 			name: "Story: EXP08",
 			mockBehavior: func(ms *admin.MockService) {
-				ms.On("SetDeduction", mock.Anything, mock.Anything).Return(70000.0, nil)
+				ms.On("SetDeduction", mock.Anything, mock.Anything).Return(70000*money.Baht, nil)
 			},
 			contentType: constants.APPLICATION_JSON,
 			request: DeductionsKReceiptRequest{
-				Amount: 70000.0,
+				Amount: 70000 * money.Baht,
 			},
 			expected: DeductionsKReceiptResponse{
-				KReceipt: 70000.0,
+				KReceipt: 70000 * money.Baht,
 			},
 			expectedCode: http.StatusOK,
 		},
 		{
 			name: "Normal case",
 			mockBehavior: func(ms *admin.MockService) {
-				ms.On("SetDeduction", mock.Anything, mock.Anything).Return(50000.0, nil)
+				ms.On("SetDeduction", mock.Anything, mock.Anything).Return(50000*money.Baht, nil)
 			},
 			contentType: constants.APPLICATION_JSON,
 			request: DeductionsKReceiptRequest{
-				Amount: 50000.0,
+				Amount: 50000 * money.Baht,
 			},
 			expected: DeductionsKReceiptResponse{
-				KReceipt: 50000.0,
+				KReceipt: 50000 * money.Baht,
 			},
 			expectedCode: http.StatusOK,
 		},
@@ -68,7 +69,18 @@ func TestDeductionsKReceiptRequest(t *testing.T) {
 			},
 			contentType: constants.APPLICATION_JSON,
 			request: DeductionsKReceiptRequest{
-				Amount: -70000.0,
+				Amount: -70000 * money.Baht,
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Amount is out of the deduction limits",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("SetDeduction", mock.Anything, mock.Anything).Return(money.Money(0), admin.ErrMoreThanLimit(admin.KReceipt, 100000*money.Baht))
+			},
+			contentType: constants.APPLICATION_JSON,
+			request: DeductionsKReceiptRequest{
+				Amount: 100001 * money.Baht,
 			},
 			expectedCode: http.StatusBadRequest,
 		},
@@ -120,35 +132,21 @@ func TestDeductionsKReceiptRequestValidation(t *testing.T) {
 		{
 			name: "Valid request",
 			request: DeductionsKReceiptRequest{
-				Amount: 70000.0,
-			},
-			wantErr: false,
-		},
-		{
-			name: "Amount on the lower end",
-			request: DeductionsKReceiptRequest{
-				Amount: 1.0,
+				Amount: 70000 * money.Baht,
 			},
 			wantErr: false,
 		},
 		{
-			name: "Amount on the upper end",
+			name: "Amount above the upper end is left to the service",
 			request: DeductionsKReceiptRequest{
-				Amount: 100000.0,
+				Amount: 100001 * money.Baht,
 			},
 			wantErr: false,
 		},
 		{
-			name: "Amount on the below lower end",
-			request: DeductionsKReceiptRequest{
-				Amount: 0.0,
-			},
-			wantErr: true,
-		},
-		{
-			name: "Amount on the above upper end",
+			name: "Negative amount",
 			request: DeductionsKReceiptRequest{
-				Amount: 100001.0,
+				Amount: -1 * money.Baht,
 			},
 			wantErr: true,
 		},
@@ -176,18 +174,30 @@ func TestDeductionsKReceiptRequestToServiceRequest(t *testing.T) {
 		{
 			name: "valid request",
 			request: DeductionsKReceiptRequest{
-				Amount: 50000.0,
+				Amount: 50000 * money.Baht,
 			},
 			expected: admin.SetDeductionRequest{
 				Type:   "k-receipt",
-				Amount: 50000.0,
+				Amount: 50000 * money.Baht,
+			},
+		},
+		{
+			name: "with tax year",
+			request: DeductionsKReceiptRequest{
+				TaxYear: 2023,
+				Amount:  50000 * money.Baht,
+			},
+			expected: admin.SetDeductionRequest{
+				TaxYear: 2023,
+				Type:    "k-receipt",
+				Amount:  50000 * money.Baht,
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := tt.request.toServiceRequest()
+			result := tt.request.toServiceRequest("")
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+)
+
+type DeductionsListRequest struct {
+	TaxYear int `query:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2024"`
+}
+
+type DeductionsListResponse struct {
+	Deductions []DeductionsGetResponse `json:"deductions"`
+}
+
+// DeductionsList lists every deduction an admin can configure.
+//
+//	@summary		List deductions
+//	@description	Lists every configurable deduction of the tax year with its amount, limits and default.
+//	@tags			admin/deductions
+//	@produce		json
+//	@param			taxYear	query	int	false	"Tax year, defaults to the current year"
+//	@security		BasicAuth
+//	@success		200	{object}	DeductionsListResponse	"Successfully response with the deductions"
+//	@failure		400	{object}	ErrorResponse			"Bad request if the input validation fails"
+//	@failure		401	{object}	ErrorResponse			"Unauthorized"
+//	@failure		404	{object}	ErrorResponse			"Not found if no deductions are configured for the tax year"
+//	@failure		500	{object}	ErrorResponse			"Internal Server Error if there is a problem getting the deductions"
+//	@router			/admin/deductions [get]
+func (h handler) DeductionsList(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var req DeductionsListRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.admin.ListDeductions(ctx, req.toServiceRequest())
+	if err != nil {
+		h.log.Err(err).E("Failed to list deductions")
+		return c.JSON(toServiceErrorResponse(err, ErrListDeductions))
+	}
+
+	deductions := make([]DeductionsGetResponse, len(res))
+	for i, d := range res {
+		deductions[i] = toDeductionsGetResponse(d)
+	}
+
+	return c.JSON(http.StatusOK, DeductionsListResponse{Deductions: deductions})
+}
+
+func (r *DeductionsListRequest) toServiceRequest() admin.ListDeductionsRequest {
+	return admin.ListDeductionsRequest{
+		TaxYear: r.TaxYear,
+	}
+}
@@ -0,0 +1,114 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestDeductionsList(t *testing.T) {
+	personal := admin.DeductionRule{Type: admin.Personal, Unit: admin.UnitAmount, Minimum: 10000 * money.Baht, Maximum: 100000 * money.Baht, Default: 60000 * money.Baht}
+	kreceipt := admin.DeductionRule{Type: admin.KReceipt, Unit: admin.UnitAmount, Minimum: 0, Maximum: 100000 * money.Baht, Default: 50000 * money.Baht}
+
+	tests := []struct {
+		name         string
+		mockBehavior func(*admin.MockService)
+		query        string
+		expected     DeductionsListResponse
+		expectedCode int
+	}{
+		{
+			name: "Normal case",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ListDeductions", mock.Anything, admin.ListDeductionsRequest{TaxYear: 2024}).Return([]admin.Deduction{
+					{DeductionRule: personal, TaxYear: 2024, Amount: 70000 * money.Baht},
+					{DeductionRule: kreceipt, TaxYear: 2024, Amount: 50000 * money.Baht},
+				}, nil)
+			},
+			query: "?taxYear=2024",
+			expected: DeductionsListResponse{
+				Deductions: []DeductionsGetResponse{
+					{TaxYear: 2024, Type: "personal", Unit: "amount", Amount: 70000 * money.Baht, Minimum: 10000 * money.Baht, Maximum: 100000 * money.Baht, Default: 60000 * money.Baht},
+					{TaxYear: 2024, Type: "k-receipt", Unit: "amount", Amount: 50000 * money.Baht, Minimum: 0, Maximum: 100000 * money.Baht, Default: 50000 * money.Baht},
+				},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Without tax year",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ListDeductions", mock.Anything, admin.ListDeductionsRequest{}).Return([]admin.Deduction{}, nil)
+			},
+			expected:     DeductionsListResponse{Deductions: []DeductionsGetResponse{}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Request parameters are invalid on Bind",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			query:        "?taxYear=unknown",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Request parameters are invalid on Validate",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			query:        "?taxYear=2567",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Tax year is not configured",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ListDeductions", mock.Anything, mock.Anything).Return(nil, admin.ErrTaxYearNotFound(2000))
+			},
+			query:        "?taxYear=2000",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Service is broken",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ListDeductions", mock.Anything, mock.Anything).Return(nil, admin.ErrQueryDatabase)
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/deductions"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+
+			log := logger.NewMockLogger()
+			ms := new(admin.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err := h.DeductionsList(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result DeductionsListResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
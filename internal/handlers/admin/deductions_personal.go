@@ -6,16 +6,20 @@ import (
 	"time"
 
 	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/api/middlewares"
 	"github.com/ztrixack/assessment-tax/internal/modules/logger"
 	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
 type DeductionsPersonalRequest struct {
-	Amount float64 `json:"amount" validate:"min=10000,max=100000" example:"60000.0"`
+	TaxYear int `json:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2024"`
+	// Amount is checked against the limits of the deduction by the service.
+	Amount money.Money `json:"amount" validate:"min=0" example:"60000.0"`
 }
 
 type DeductionsPersonalResponse struct {
-	PersonalDeduction float64 `json:"personalDeduction"`
+	PersonalDeduction money.Money `json:"personalDeduction"`
 }
 
 // DeductionsPersonal sets a personal deduction by admin.
@@ -28,7 +32,7 @@ type DeductionsPersonalResponse struct {
 //	@param			request	body	DeductionsPersonalRequest	true	"Input request for setting personal deduction"
 //	@security		BasicAuth
 //	@success		200	{object}	DeductionsPersonalResponse	"Successfully response with updated deduction details"
-//	@failure		400	{object}	ErrorResponse				"Bad request if the input validation fails"
+//	@failure		400	{object}	ErrorResponse				"Bad request if the input validation fails or the amount is out of the limits"
 //	@failure		401	{object}	ErrorResponse				"Unauthorized"
 //	@failure		500	{object}	ErrorResponse				"Internal Server Error if there is a problem setting the deduction"
 //	@router			/admin/deductions/personal [post]
@@ -51,18 +55,20 @@ func (h handler) DeductionsPersonal(c api.Context) error {
 		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
 	}
 
-	res, err := h.admin.SetDeduction(ctx, req.toServiceRequest())
+	res, err := h.admin.SetDeduction(ctx, req.toServiceRequest(middlewares.Username(c)))
 	if err != nil {
 		h.log.Err(err).E("Failed to set personal deduction")
-		return c.JSON(http.StatusInternalServerError, toErrorResponse(ErrDeductPersonal))
+		return c.JSON(toServiceErrorResponse(err, ErrDeductPersonal))
 	}
 
 	return c.JSON(http.StatusOK, DeductionsPersonalResponse{PersonalDeduction: res})
 }
 
-func (r *DeductionsPersonalRequest) toServiceRequest() admin.SetDeductionRequest {
+func (r *DeductionsPersonalRequest) toServiceRequest(changedBy string) admin.SetDeductionRequest {
 	return admin.SetDeductionRequest{
-		Type:   admin.Personal,
-		Amount: r.Amount,
+		TaxYear:   r.TaxYear,
+		Type:      admin.Personal,
+		Amount:    r.Amount,
+		ChangedBy: changedBy,
 	}
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/ztrixack/assessment-tax/internal/modules/logger"
 	"github.com/ztrixack/assessment-tax/internal/services/admin"
 	"github.com/ztrixack/assessment-tax/internal/utils/constants"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
 func TestDeductionsPersonalRequest(t *testing.T) {
@@ -28,28 +29,28 @@ func TestDeductionsPersonalRequest(t *testing.T) {
 		{
 			name: "Story: EXP05",
 			mockBehavior: func(ms *admin.MockService) {
-				ms.On("SetDeduction", mock.Anything, mock.Anything).Return(70000.0, nil)
+				ms.On("SetDeduction", mock.Anything, mock.Anything).Return(70000*money.Baht, nil)
 			},
 			contentType: constants.APPLICATION_JSON,
 			request: DeductionsPersonalRequest{
-				Amount: 70000.0,
+				Amount: 70000 * money.Baht,
 			},
 			expected: DeductionsPersonalResponse{
-				PersonalDeduction: 70000.0,
+				PersonalDeduction: 70000 * money.Baht,
 			},
 			expectedCode: http.StatusOK,
 		},
 		{
 			name: "Normal case",
 			mockBehavior: func(ms *admin.MockService) {
-				ms.On("SetDeduction", mock.Anything, mock.Anything).Return(50000.0, nil)
+				ms.On("SetDeduction", mock.Anything, mock.Anything).Return(50000*money.Baht, nil)
 			},
 			contentType: constants.APPLICATION_JSON,
 			request: DeductionsPersonalRequest{
-				Amount: 50000.0,
+				Amount: 50000 * money.Baht,
 			},
 			expected: DeductionsPersonalResponse{
-				PersonalDeduction: 50000.0,
+				PersonalDeduction: 50000 * money.Baht,
 			},
 			expectedCode: http.StatusOK,
 		},
@@ -68,7 +69,18 @@ func TestDeductionsPersonalRequest(t *testing.T) {
 			},
 			contentType: constants.APPLICATION_JSON,
 			request: DeductionsPersonalRequest{
-				Amount: -70000.0,
+				Amount: -70000 * money.Baht,
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Amount is out of the deduction limits",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("SetDeduction", mock.Anything, mock.Anything).Return(money.Money(0), admin.ErrLessThanLimit(admin.Personal, 10000*money.Baht))
+			},
+			contentType: constants.APPLICATION_JSON,
+			request: DeductionsPersonalRequest{
+				Amount: 5000 * money.Baht,
 			},
 			expectedCode: http.StatusBadRequest,
 		},
@@ -120,35 +132,21 @@ func TestDeductionsPersonalRequestValidation(t *testing.T) {
 		{
 			name: "Valid request",
 			request: DeductionsPersonalRequest{
-				Amount: 70000.0,
+				Amount: 70000 * money.Baht,
 			},
 			wantErr: false,
 		},
 		{
-			name: "Amount on the lower end",
+			name: "Amount above the upper end is left to the service",
 			request: DeductionsPersonalRequest{
-				Amount: 10000.0,
+				Amount: 100001 * money.Baht,
 			},
 			wantErr: false,
 		},
 		{
-			name: "Amount on the upper end",
-			request: DeductionsPersonalRequest{
-				Amount: 100000.0,
-			},
-			wantErr: false,
-		},
-		{
-			name: "Amount on the below lower end",
-			request: DeductionsPersonalRequest{
-				Amount: 9999.0,
-			},
-			wantErr: true,
-		},
-		{
-			name: "Amount on the above upper end",
+			name: "Negative amount",
 			request: DeductionsPersonalRequest{
-				Amount: 100001.0,
+				Amount: -1 * money.Baht,
 			},
 			wantErr: true,
 		},
@@ -169,25 +167,50 @@ func TestDeductionsPersonalRequestValidation(t *testing.T) {
 
 func TestDeductionsPersonalRequestToServiceRequest(t *testing.T) {
 	tests := []struct {
-		name     string
-		request  DeductionsPersonalRequest
-		expected admin.SetDeductionRequest
+		name      string
+		request   DeductionsPersonalRequest
+		changedBy string
+		expected  admin.SetDeductionRequest
 	}{
 		{
 			name: "valid request",
 			request: DeductionsPersonalRequest{
-				Amount: 50000.0,
+				Amount: 50000 * money.Baht,
 			},
 			expected: admin.SetDeductionRequest{
 				Type:   "personal",
-				Amount: 50000.0,
+				Amount: 50000 * money.Baht,
+			},
+		},
+		{
+			name: "with tax year",
+			request: DeductionsPersonalRequest{
+				TaxYear: 2023,
+				Amount:  50000 * money.Baht,
+			},
+			expected: admin.SetDeductionRequest{
+				TaxYear: 2023,
+				Type:    "personal",
+				Amount:  50000 * money.Baht,
+			},
+		},
+		{
+			name: "with changed by",
+			request: DeductionsPersonalRequest{
+				Amount: 50000 * money.Baht,
+			},
+			changedBy: "adminTax",
+			expected: admin.SetDeductionRequest{
+				Type:      "personal",
+				Amount:    50000 * money.Baht,
+				ChangedBy: "adminTax",
 			},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := tt.request.toServiceRequest()
+			result := tt.request.toServiceRequest(tt.changedBy)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
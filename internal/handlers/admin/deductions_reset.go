@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/api/middlewares"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+)
+
+type DeductionsResetRequest struct {
+	Type    string `param:"type" validate:"required" example:"personal"`
+	TaxYear int    `query:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2024"`
+}
+
+// DeductionsReset resets a deduction to its default amount.
+//
+//	@summary		Reset deduction
+//	@description	Sets the amount of a deduction of the tax year back to its default.
+//	@tags			admin/deductions
+//	@produce		json
+//	@param			type	path	string	true	"Deduction type"	Enums(personal, donation, k-receipt)
+//	@param			taxYear	query	int		false	"Tax year, defaults to the current year"
+//	@security		BasicAuth
+//	@success		200	{object}	DeductionsUpdateResponse	"Successfully response with the reset deduction details"
+//	@failure		400	{object}	ErrorResponse				"Bad request if the input validation fails"
+//	@failure		401	{object}	ErrorResponse				"Unauthorized"
+//	@failure		404	{object}	ErrorResponse				"Not found if the deduction type is unknown or not configured for the tax year"
+//	@failure		500	{object}	ErrorResponse				"Internal Server Error if there is a problem resetting the deduction"
+//	@router			/admin/deductions/{type} [delete]
+func (h handler) DeductionsReset(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var req DeductionsResetRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.admin.ResetDeduction(ctx, req.toServiceRequest(middlewares.Username(c)))
+	if err != nil {
+		h.log.Err(err).E("Failed to reset %s deduction", req.Type)
+		return c.JSON(toServiceErrorResponse(err, ErrResetDeduction))
+	}
+
+	return c.JSON(http.StatusOK, DeductionsUpdateResponse{Type: req.Type, Amount: res})
+}
+
+func (r *DeductionsResetRequest) toServiceRequest(changedBy string) admin.DeductionRequest {
+	return admin.DeductionRequest{
+		TaxYear:   r.TaxYear,
+		Type:      admin.DeductionType(r.Type),
+		ChangedBy: changedBy,
+	}
+}
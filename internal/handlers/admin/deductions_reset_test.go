@@ -0,0 +1,94 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestDeductionsReset(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockBehavior func(*admin.MockService)
+		dtype        string
+		query        string
+		expected     DeductionsUpdateResponse
+		expectedCode int
+	}{
+		{
+			name: "Normal case",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ResetDeduction", mock.Anything, admin.DeductionRequest{TaxYear: 2024, Type: admin.KReceipt}).Return(50000*money.Baht, nil)
+			},
+			dtype:        "k-receipt",
+			query:        "?taxYear=2024",
+			expected:     DeductionsUpdateResponse{Type: "k-receipt", Amount: 50000 * money.Baht},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Request parameters are invalid on Validate",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			dtype:        "k-receipt",
+			query:        "?taxYear=1000",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Unknown deduction type",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ResetDeduction", mock.Anything, mock.Anything).Return(money.Money(0), admin.ErrInvalidDeductionType)
+			},
+			dtype:        "unknown",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Service is broken",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ResetDeduction", mock.Anything, mock.Anything).Return(money.Money(0), fmt.Errorf("some error"))
+			},
+			dtype:        "personal",
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			req := httptest.NewRequest(http.MethodDelete, "/admin/deductions/"+tt.dtype+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+			c.SetParamNames("type")
+			c.SetParamValues(tt.dtype)
+
+			log := logger.NewMockLogger()
+			ms := new(admin.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err := h.DeductionsReset(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result DeductionsUpdateResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
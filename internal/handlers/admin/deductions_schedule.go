@@ -0,0 +1,93 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/api/middlewares"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+type DeductionsScheduleRequest struct {
+	Type string `json:"type" validate:"required" example:"personal"`
+	// TaxYear is the year of effectiveFrom when it is not set.
+	TaxYear       int         `json:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2025"`
+	Amount        money.Money `json:"amount" validate:"min=0" example:"70000.0"`
+	EffectiveFrom time.Time   `json:"effectiveFrom" validate:"required" example:"2025-01-01T00:00:00Z"`
+}
+
+type DeductionsScheduleResponse struct {
+	ID            int64       `json:"id" example:"1"`
+	TaxYear       int         `json:"taxYear" example:"2025"`
+	Type          string      `json:"type" example:"personal"`
+	Amount        money.Money `json:"amount" example:"70000.0"`
+	EffectiveFrom time.Time   `json:"effectiveFrom" example:"2025-01-01T00:00:00Z"`
+}
+
+// DeductionsSchedule schedules a deduction change.
+//
+//	@summary		Schedule deduction
+//	@description	Sets the amount of a deduction of the tax year from a future date on, within the limits of the deduction.
+//	@description	Scheduling the same deduction at the same date again replaces the pending amount.
+//	@tags			admin/deductions
+//	@accept			json
+//	@produce		json
+//	@param			request	body	DeductionsScheduleRequest	true	"Input request for scheduling the deduction"
+//	@security		BasicAuth
+//	@success		201	{object}	DeductionsScheduleResponse	"Successfully response with the scheduled deduction"
+//	@failure		400	{object}	ErrorResponse				"Bad request if the input validation fails, the amount is out of the limits or the date is not in the future"
+//	@failure		401	{object}	ErrorResponse				"Unauthorized"
+//	@failure		404	{object}	ErrorResponse				"Not found if the deduction type is unknown or not configured for the tax year"
+//	@failure		500	{object}	ErrorResponse				"Internal Server Error if there is a problem scheduling the deduction"
+//	@router			/admin/deductions/schedules [post]
+func (h handler) DeductionsSchedule(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if c.Request().Body == http.NoBody {
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	var req DeductionsScheduleRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.admin.ScheduleDeduction(ctx, req.toServiceRequest(middlewares.Username(c)))
+	if err != nil {
+		h.log.Err(err).E("Failed to schedule %s deduction", req.Type)
+		return c.JSON(toServiceErrorResponse(err, ErrScheduleDeduction))
+	}
+
+	return c.JSON(http.StatusCreated, toDeductionsScheduleResponse(*res))
+}
+
+func (r *DeductionsScheduleRequest) toServiceRequest(changedBy string) admin.ScheduleDeductionRequest {
+	return admin.ScheduleDeductionRequest{
+		TaxYear:       r.TaxYear,
+		Type:          admin.DeductionType(r.Type),
+		Amount:        r.Amount,
+		EffectiveFrom: r.EffectiveFrom,
+		ChangedBy:     changedBy,
+	}
+}
+
+func toDeductionsScheduleResponse(scheduled admin.ScheduledDeduction) DeductionsScheduleResponse {
+	return DeductionsScheduleResponse{
+		ID:            scheduled.ID,
+		TaxYear:       scheduled.TaxYear,
+		Type:          string(scheduled.Type),
+		Amount:        scheduled.Amount,
+		EffectiveFrom: scheduled.EffectiveFrom,
+	}
+}
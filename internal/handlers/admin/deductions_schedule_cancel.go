@@ -0,0 +1,61 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/api/middlewares"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+)
+
+type DeductionsScheduleCancelRequest struct {
+	ID int64 `param:"id" validate:"required,min=1" example:"1"`
+}
+
+// DeductionsScheduleCancel cancels a deduction change that has not taken effect yet.
+//
+//	@summary		Cancel scheduled deduction
+//	@description	Withdraws a scheduled deduction change before it takes effect.
+//	@tags			admin/deductions
+//	@produce		json
+//	@param			id	path	int	true	"Scheduled deduction ID"
+//	@security		BasicAuth
+//	@success		200	{object}	DeductionsScheduleResponse	"Successfully response with the cancelled deduction"
+//	@failure		400	{object}	ErrorResponse				"Bad request if the input validation fails"
+//	@failure		401	{object}	ErrorResponse				"Unauthorized"
+//	@failure		404	{object}	ErrorResponse				"Not found if there is no pending scheduled deduction with the ID"
+//	@failure		500	{object}	ErrorResponse				"Internal Server Error if there is a problem cancelling the scheduled deduction"
+//	@router			/admin/deductions/schedules/{id} [delete]
+func (h handler) DeductionsScheduleCancel(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var req DeductionsScheduleCancelRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.admin.CancelScheduledDeduction(ctx, req.toServiceRequest(middlewares.Username(c)))
+	if err != nil {
+		h.log.Err(err).E("Failed to cancel scheduled deduction %d", req.ID)
+		return c.JSON(toServiceErrorResponse(err, ErrCancelScheduledDeduction))
+	}
+
+	return c.JSON(http.StatusOK, toDeductionsScheduleResponse(*res))
+}
+
+func (r *DeductionsScheduleCancelRequest) toServiceRequest(changedBy string) admin.CancelScheduledDeductionRequest {
+	return admin.CancelScheduledDeductionRequest{
+		ID:        r.ID,
+		ChangedBy: changedBy,
+	}
+}
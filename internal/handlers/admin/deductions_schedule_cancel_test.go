@@ -0,0 +1,104 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/api/middlewares"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestDeductionsScheduleCancel(t *testing.T) {
+	effectiveFrom := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		mockBehavior func(*admin.MockService)
+		id           string
+		expected     DeductionsScheduleResponse
+		expectedCode int
+	}{
+		{
+			name: "Normal case",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("CancelScheduledDeduction", mock.Anything, admin.CancelScheduledDeductionRequest{ID: 1, ChangedBy: "adminTax"}).
+					Return(&admin.ScheduledDeduction{ID: 1, TaxYear: 2030, Type: admin.Personal, Amount: 70000 * money.Baht, EffectiveFrom: effectiveFrom}, nil)
+			},
+			id:           "1",
+			expected:     DeductionsScheduleResponse{ID: 1, TaxYear: 2030, Type: "personal", Amount: 70000 * money.Baht, EffectiveFrom: effectiveFrom},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Request parameters are invalid on Bind",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			id:           "one",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Request parameters are invalid on Validate",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			id:           "0",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Not pending",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("CancelScheduledDeduction", mock.Anything, mock.Anything).Return(nil, admin.ErrScheduleNotFound)
+			},
+			id:           "2",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Service error",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("CancelScheduledDeduction", mock.Anything, mock.Anything).Return(nil, admin.ErrCancelDatabase)
+			},
+			id:           "1",
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			req := httptest.NewRequest(http.MethodDelete, "/admin/deductions/schedules/"+tt.id, nil)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+			c.Set(middlewares.UsernameKey, "adminTax")
+
+			log := logger.NewMockLogger()
+			ms := new(admin.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err := h.DeductionsScheduleCancel(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result DeductionsScheduleResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
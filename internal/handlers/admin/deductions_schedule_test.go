@@ -0,0 +1,120 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/api/middlewares"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/constants"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestDeductionsSchedule(t *testing.T) {
+	effectiveFrom := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		mockBehavior func(*admin.MockService)
+		contentType  string
+		body         string
+		expected     DeductionsScheduleResponse
+		expectedCode int
+	}{
+		{
+			name: "Normal case",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ScheduleDeduction", mock.Anything, admin.ScheduleDeductionRequest{TaxYear: 2030, Type: admin.Personal, Amount: 70000 * money.Baht, EffectiveFrom: effectiveFrom, ChangedBy: "adminTax"}).
+					Return(&admin.ScheduledDeduction{ID: 1, TaxYear: 2030, Type: admin.Personal, Amount: 70000 * money.Baht, EffectiveFrom: effectiveFrom}, nil)
+			},
+			contentType:  constants.APPLICATION_JSON,
+			body:         `{"type": "personal", "taxYear": 2030, "amount": 70000, "effectiveFrom": "2030-01-01T00:00:00Z"}`,
+			expected:     DeductionsScheduleResponse{ID: 1, TaxYear: 2030, Type: "personal", Amount: 70000 * money.Baht, EffectiveFrom: effectiveFrom},
+			expectedCode: http.StatusCreated,
+		},
+		{
+			name: "Request parameters are invalid on Bind",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			contentType:  constants.APPLICATION_JSON,
+			body:         `{"type": "personal", "amount": 70000, "effectiveFrom": "next year"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Request parameters are invalid on Validate",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			contentType:  constants.APPLICATION_JSON,
+			body:         `{"type": "personal", "amount": 70000}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Effective date is not in the future",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ScheduleDeduction", mock.Anything, mock.Anything).Return(nil, admin.ErrNotInFuture)
+			},
+			contentType:  constants.APPLICATION_JSON,
+			body:         `{"type": "personal", "amount": 70000, "effectiveFrom": "2000-01-01T00:00:00Z"}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Unknown deduction type",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ScheduleDeduction", mock.Anything, mock.Anything).Return(nil, admin.ErrInvalidDeductionType)
+			},
+			contentType:  constants.APPLICATION_JSON,
+			body:         `{"type": "unknown", "amount": 70000, "effectiveFrom": "2030-01-01T00:00:00Z"}`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Service error",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ScheduleDeduction", mock.Anything, mock.Anything).Return(nil, admin.ErrUpdateDatabase(admin.Personal))
+			},
+			contentType:  constants.APPLICATION_JSON,
+			body:         `{"type": "personal", "amount": 70000, "effectiveFrom": "2030-01-01T00:00:00Z"}`,
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/deductions/schedules", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+			c.Set(middlewares.UsernameKey, "adminTax")
+
+			log := logger.NewMockLogger()
+			ms := new(admin.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err := h.DeductionsSchedule(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusCreated {
+				var result DeductionsScheduleResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+)
+
+type DeductionsSchedulesListRequest struct {
+	TaxYear int    `query:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2025"`
+	Type    string `query:"type" example:"personal"`
+}
+
+type DeductionsSchedulesListResponse struct {
+	Schedules []DeductionsScheduleResponse `json:"schedules"`
+}
+
+// DeductionsSchedulesList lists the deduction changes that have not taken effect yet.
+//
+//	@summary		List scheduled deductions
+//	@description	Lists the scheduled deduction changes that have not taken effect yet, soonest first.
+//	@tags			admin/deductions
+//	@produce		json
+//	@param			taxYear	query	int		false	"Tax year of the scheduled deduction"
+//	@param			type	query	string	false	"Deduction type"	Enums(personal, donation, k-receipt)
+//	@security		BasicAuth
+//	@success		200	{object}	DeductionsSchedulesListResponse	"Successfully response with the scheduled deductions"
+//	@failure		400	{object}	ErrorResponse					"Bad request if the input validation fails"
+//	@failure		401	{object}	ErrorResponse					"Unauthorized"
+//	@failure		500	{object}	ErrorResponse					"Internal Server Error if there is a problem getting the scheduled deductions"
+//	@router			/admin/deductions/schedules [get]
+func (h handler) DeductionsSchedulesList(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var req DeductionsSchedulesListRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.admin.ListScheduledDeductions(ctx, req.toServiceRequest())
+	if err != nil {
+		h.log.Err(err).E("Failed to list scheduled deductions")
+		return c.JSON(toServiceErrorResponse(err, ErrListScheduledDeductions))
+	}
+
+	schedules := make([]DeductionsScheduleResponse, len(res))
+	for i, scheduled := range res {
+		schedules[i] = toDeductionsScheduleResponse(scheduled)
+	}
+
+	return c.JSON(http.StatusOK, DeductionsSchedulesListResponse{Schedules: schedules})
+}
+
+func (r *DeductionsSchedulesListRequest) toServiceRequest() admin.ListScheduledDeductionsRequest {
+	return admin.ListScheduledDeductionsRequest{
+		TaxYear: r.TaxYear,
+		Type:    admin.DeductionType(r.Type),
+	}
+}
@@ -0,0 +1,96 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestDeductionsSchedulesList(t *testing.T) {
+	effectiveFrom := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		mockBehavior func(*admin.MockService)
+		query        string
+		expected     DeductionsSchedulesListResponse
+		expectedCode int
+	}{
+		{
+			name: "Normal case",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ListScheduledDeductions", mock.Anything, admin.ListScheduledDeductionsRequest{TaxYear: 2030, Type: admin.Personal}).Return([]admin.ScheduledDeduction{
+					{ID: 1, TaxYear: 2030, Type: admin.Personal, Amount: 70000 * money.Baht, EffectiveFrom: effectiveFrom},
+				}, nil)
+			},
+			query: "?taxYear=2030&type=personal",
+			expected: DeductionsSchedulesListResponse{
+				Schedules: []DeductionsScheduleResponse{
+					{ID: 1, TaxYear: 2030, Type: "personal", Amount: 70000 * money.Baht, EffectiveFrom: effectiveFrom},
+				},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Request parameters are invalid on Bind",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			query:        "?taxYear=next",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Request parameters are invalid on Validate",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			query:        "?taxYear=1000",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Service error",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ListScheduledDeductions", mock.Anything, mock.Anything).Return(nil, admin.ErrQueryDatabase)
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/deductions/schedules"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+
+			log := logger.NewMockLogger()
+			ms := new(admin.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err := h.DeductionsSchedulesList(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result DeductionsSchedulesListResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/api/middlewares"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+type DeductionsUpdateRequest struct {
+	Type    string      `param:"type" json:"-" validate:"required" swaggerignore:"true"`
+	TaxYear int         `json:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2024"`
+	Amount  money.Money `json:"amount" validate:"min=0" example:"60000.0"`
+}
+
+type DeductionsUpdateResponse struct {
+	Type   string      `json:"type" example:"personal"`
+	Amount money.Money `json:"amount" example:"60000.0"`
+}
+
+// DeductionsUpdate sets a deduction by its type.
+//
+//	@summary		Update deduction
+//	@description	Sets the amount of a deduction of the tax year, within the limits of the deduction.
+//	@description	The donation deduction is set as a percentage of the income left after the other deductions.
+//	@tags			admin/deductions
+//	@accept			json
+//	@produce		json
+//	@param			type	path	string					true	"Deduction type"	Enums(personal, donation, k-receipt)
+//	@param			request	body	DeductionsUpdateRequest	true	"Input request for setting the deduction"
+//	@security		BasicAuth
+//	@success		200	{object}	DeductionsUpdateResponse	"Successfully response with updated deduction details"
+//	@failure		400	{object}	ErrorResponse				"Bad request if the input validation fails or the amount is out of the limits"
+//	@failure		401	{object}	ErrorResponse				"Unauthorized"
+//	@failure		404	{object}	ErrorResponse				"Not found if the deduction type is unknown or not configured for the tax year"
+//	@failure		500	{object}	ErrorResponse				"Internal Server Error if there is a problem setting the deduction"
+//	@router			/admin/deductions/{type} [put]
+func (h handler) DeductionsUpdate(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if c.Request().Body == http.NoBody {
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	var req DeductionsUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.admin.SetDeduction(ctx, req.toServiceRequest(middlewares.Username(c)))
+	if err != nil {
+		h.log.Err(err).E("Failed to set %s deduction", req.Type)
+		return c.JSON(toServiceErrorResponse(err, ErrSetDeduction))
+	}
+
+	return c.JSON(http.StatusOK, DeductionsUpdateResponse{Type: req.Type, Amount: res})
+}
+
+func (r *DeductionsUpdateRequest) toServiceRequest(changedBy string) admin.SetDeductionRequest {
+	return admin.SetDeductionRequest{
+		TaxYear:   r.TaxYear,
+		Type:      admin.DeductionType(r.Type),
+		Amount:    r.Amount,
+		ChangedBy: changedBy,
+	}
+}
@@ -0,0 +1,141 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/api/middlewares"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/constants"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestDeductionsUpdate(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockBehavior func(*admin.MockService)
+		contentType  string
+		dtype        string
+		username     string
+		body         string
+		expected     DeductionsUpdateResponse
+		expectedCode int
+	}{
+		{
+			name: "Normal case",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("SetDeduction", mock.Anything, admin.SetDeductionRequest{TaxYear: 2024, Type: admin.Donation, Amount: 15 * money.Baht}).Return(15*money.Baht, nil)
+			},
+			contentType:  constants.APPLICATION_JSON,
+			dtype:        "donation",
+			body:         `{"taxYear": 2024, "amount": 15}`,
+			expected:     DeductionsUpdateResponse{Type: "donation", Amount: 15 * money.Baht},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Records the authenticated admin",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("SetDeduction", mock.Anything, admin.SetDeductionRequest{TaxYear: 2024, Type: admin.Donation, Amount: 15 * money.Baht, ChangedBy: "adminTax"}).Return(15*money.Baht, nil)
+			},
+			contentType:  constants.APPLICATION_JSON,
+			dtype:        "donation",
+			username:     "adminTax",
+			body:         `{"taxYear": 2024, "amount": 15}`,
+			expected:     DeductionsUpdateResponse{Type: "donation", Amount: 15 * money.Baht},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Request parameters are invalid on Bind",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			contentType:  constants.TEXT_PLAIN,
+			dtype:        "donation",
+			body:         `{"amount": 15}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Request parameters are invalid on Validate",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			contentType:  constants.APPLICATION_JSON,
+			dtype:        "donation",
+			body:         `{"amount": -1}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Amount is out of the deduction limits",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("SetDeduction", mock.Anything, mock.Anything).Return(money.Money(0), admin.ErrLessThanLimit(admin.Personal, 10000*money.Baht))
+			},
+			contentType:  constants.APPLICATION_JSON,
+			dtype:        "personal",
+			body:         `{"amount": 5000}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Unknown deduction type",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("SetDeduction", mock.Anything, mock.Anything).Return(money.Money(0), admin.ErrInvalidDeductionType)
+			},
+			contentType:  constants.APPLICATION_JSON,
+			dtype:        "unknown",
+			body:         `{"amount": 5000}`,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Service is broken",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("SetDeduction", mock.Anything, mock.Anything).Return(money.Money(0), fmt.Errorf("some error"))
+			},
+			contentType:  constants.APPLICATION_JSON,
+			dtype:        "k-receipt",
+			body:         `{"amount": 5000}`,
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/deductions/"+tt.dtype, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+			c.SetParamNames("type")
+			c.SetParamValues(tt.dtype)
+			if tt.username != "" {
+				c.Set(middlewares.UsernameKey, tt.username)
+			}
+
+			log := logger.NewMockLogger()
+			ms := new(admin.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err := h.DeductionsUpdate(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result DeductionsUpdateResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+)
+
+type ExchangeRatesListRequest struct {
+	TaxYear int `query:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2024"`
+}
+
+type ExchangeRatesResponse struct {
+	TaxYear int                  `json:"taxYear" example:"2024"`
+	Rates   []ExchangeRateDetail `json:"rates"`
+}
+
+// ExchangeRateDetail is the Bank of Thailand average rate of a currency over the tax year.
+type ExchangeRateDetail struct {
+	Currency string `json:"currency" example:"USD"`
+	// Rate is in baht per unit of the currency.
+	Rate float64 `json:"rate" example:"35.2859"`
+}
+
+// ExchangeRatesList lists the exchange rates of a tax year.
+//
+//	@summary		List exchange rates
+//	@description	Lists the yearly average exchange rates of the tax year in baht per unit, by currency, which foreign currency amounts are converted by.
+//	@tags			admin/exchange-rates
+//	@produce		json
+//	@param			taxYear	query	int	false	"Tax year of the exchange rates"
+//	@security		BasicAuth
+//	@success		200	{object}	ExchangeRatesResponse	"Successfully response with the exchange rates"
+//	@failure		400	{object}	ErrorResponse			"Bad request if the input validation fails"
+//	@failure		401	{object}	ErrorResponse			"Unauthorized"
+//	@failure		500	{object}	ErrorResponse			"Internal Server Error if there is a problem getting the exchange rates"
+//	@router			/admin/exchange-rates [get]
+func (h handler) ExchangeRatesList(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var req ExchangeRatesListRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.admin.ListExchangeRates(ctx, admin.ListExchangeRatesRequest{TaxYear: req.TaxYear})
+	if err != nil {
+		h.log.Err(err).E("Failed to list exchange rates")
+		return c.JSON(toServiceErrorResponse(err, ErrListExchangeRates))
+	}
+
+	return c.JSON(http.StatusOK, toExchangeRatesResponse(req.TaxYear, res))
+}
+
+// toExchangeRatesResponse returns the rates with the tax year they were resolved to, or the requested one
+// when there are none.
+func toExchangeRatesResponse(taxYear int, rates []admin.ExchangeRate) ExchangeRatesResponse {
+	res := ExchangeRatesResponse{TaxYear: taxYear, Rates: make([]ExchangeRateDetail, len(rates))}
+	for i, rate := range rates {
+		res.TaxYear = rate.TaxYear
+		res.Rates[i] = ExchangeRateDetail{Currency: rate.Currency, Rate: rate.Rate}
+	}
+
+	return res
+}
@@ -0,0 +1,101 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+)
+
+func TestExchangeRatesList(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockBehavior func(*admin.MockService)
+		query        string
+		expected     ExchangeRatesResponse
+		expectedCode int
+	}{
+		{
+			name: "Normal case",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ListExchangeRates", mock.Anything, admin.ListExchangeRatesRequest{TaxYear: 2024}).Return([]admin.ExchangeRate{
+					{TaxYear: 2024, Currency: "JPY", Rate: 0.2333},
+					{TaxYear: 2024, Currency: "USD", Rate: 35.2859},
+				}, nil)
+			},
+			query: "?taxYear=2024",
+			expected: ExchangeRatesResponse{
+				TaxYear: 2024,
+				Rates:   []ExchangeRateDetail{{Currency: "JPY", Rate: 0.2333}, {Currency: "USD", Rate: 35.2859}},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "No rates for the tax year",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ListExchangeRates", mock.Anything, admin.ListExchangeRatesRequest{TaxYear: 2000}).Return([]admin.ExchangeRate{}, nil)
+			},
+			query:        "?taxYear=2000",
+			expected:     ExchangeRatesResponse{TaxYear: 2000, Rates: []ExchangeRateDetail{}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Request parameters are invalid on Bind",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			query:        "?taxYear=last",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Request parameters are invalid on Validate",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			query:        "?taxYear=1000",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Service error",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("ListExchangeRates", mock.Anything, mock.Anything).Return(nil, admin.ErrQueryDatabase)
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/exchange-rates"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+
+			log := logger.NewMockLogger()
+			ms := new(admin.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err := h.ExchangeRatesList(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result ExchangeRatesResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
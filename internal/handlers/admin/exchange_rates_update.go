@@ -0,0 +1,74 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+)
+
+type ExchangeRatesUpdateRequest struct {
+	TaxYear int                  `json:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2024"`
+	Rates   []ExchangeRateUpload `json:"rates" validate:"required,min=1,dive"`
+}
+
+type ExchangeRateUpload struct {
+	Currency string `json:"currency" validate:"required,len=3,uppercase" example:"USD"`
+	// Rate is in baht per unit of the currency.
+	Rate float64 `json:"rate" validate:"gt=0" example:"35.2859"`
+}
+
+// ExchangeRatesUpdate uploads the exchange rates of a tax year.
+//
+//	@summary		Upload exchange rates
+//	@description	Sets the Bank of Thailand yearly average exchange rates of the tax year in baht per unit, replacing the rate of every currency uploaded.
+//	@description	Currencies left out of the upload keep the rate they have. The rates are set together, or not at all.
+//	@tags			admin/exchange-rates
+//	@accept			json
+//	@produce		json
+//	@param			request	body	ExchangeRatesUpdateRequest	true	"Input request for uploading the exchange rates"
+//	@security		BasicAuth
+//	@success		200	{object}	ExchangeRatesResponse	"Successfully response with the uploaded exchange rates"
+//	@failure		400	{object}	ErrorResponse			"Bad request if the input validation fails or a currency is uploaded twice"
+//	@failure		401	{object}	ErrorResponse			"Unauthorized"
+//	@failure		500	{object}	ErrorResponse			"Internal Server Error if there is a problem setting the exchange rates"
+//	@router			/admin/exchange-rates [put]
+func (h handler) ExchangeRatesUpdate(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if c.Request().Body == http.NoBody {
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	var req ExchangeRatesUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.admin.SetExchangeRates(ctx, req.toServiceRequest())
+	if err != nil {
+		h.log.Err(err).E("Failed to set exchange rates")
+		return c.JSON(toServiceErrorResponse(err, ErrSetExchangeRates))
+	}
+
+	return c.JSON(http.StatusOK, toExchangeRatesResponse(req.TaxYear, res))
+}
+
+func (r *ExchangeRatesUpdateRequest) toServiceRequest() admin.SetExchangeRatesRequest {
+	rates := make([]admin.CurrencyRate, len(r.Rates))
+	for i, rate := range r.Rates {
+		rates[i] = admin.CurrencyRate{Currency: rate.Currency, Rate: rate.Rate}
+	}
+
+	return admin.SetExchangeRatesRequest{TaxYear: r.TaxYear, Rates: rates}
+}
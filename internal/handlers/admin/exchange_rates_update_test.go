@@ -0,0 +1,132 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/constants"
+)
+
+func TestExchangeRatesUpdate(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockBehavior func(*admin.MockService)
+		contentType  string
+		body         string
+		expected     ExchangeRatesResponse
+		expectedCode int
+	}{
+		{
+			name: "Normal case",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("SetExchangeRates", mock.Anything, admin.SetExchangeRatesRequest{
+					TaxYear: 2024,
+					Rates:   []admin.CurrencyRate{{Currency: "USD", Rate: 35.2859}, {Currency: "JPY", Rate: 0.2333}},
+				}).Return([]admin.ExchangeRate{
+					{TaxYear: 2024, Currency: "USD", Rate: 35.2859},
+					{TaxYear: 2024, Currency: "JPY", Rate: 0.2333},
+				}, nil)
+			},
+			contentType: constants.APPLICATION_JSON,
+			body:        `{"taxYear": 2024, "rates": [{"currency": "USD", "rate": 35.2859}, {"currency": "JPY", "rate": 0.2333}]}`,
+			expected: ExchangeRatesResponse{
+				TaxYear: 2024,
+				Rates:   []ExchangeRateDetail{{Currency: "USD", Rate: 35.2859}, {Currency: "JPY", Rate: 0.2333}},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Request parameters are invalid on Bind",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			contentType:  constants.TEXT_PLAIN,
+			body:         `{"rates": [{"currency": "USD", "rate": 35.2859}]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "No rates",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			contentType:  constants.APPLICATION_JSON,
+			body:         `{"taxYear": 2024, "rates": []}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Currency is not a currency code",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			contentType:  constants.APPLICATION_JSON,
+			body:         `{"rates": [{"currency": "usd", "rate": 35.2859}]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Rate is not above zero",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			contentType:  constants.APPLICATION_JSON,
+			body:         `{"rates": [{"currency": "USD", "rate": 0}]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Currency uploaded twice",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("SetExchangeRates", mock.Anything, mock.Anything).Return(nil, admin.ErrDuplicateCurrency)
+			},
+			contentType:  constants.APPLICATION_JSON,
+			body:         `{"rates": [{"currency": "USD", "rate": 35.2859}, {"currency": "USD", "rate": 36}]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Service is broken",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("SetExchangeRates", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("some error"))
+			},
+			contentType:  constants.APPLICATION_JSON,
+			body:         `{"rates": [{"currency": "USD", "rate": 35.2859}]}`,
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/exchange-rates", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+
+			log := logger.NewMockLogger()
+			ms := new(admin.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err := h.ExchangeRatesUpdate(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result ExchangeRatesResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
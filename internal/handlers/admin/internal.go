@@ -1,13 +1,30 @@
 package admin
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
 )
 
 var (
-	ErrInvalidRequest = fmt.Errorf("invalid request")
-	ErrDeductPersonal = fmt.Errorf("unable to set personal deduction")
-	ErrDeductKReceipt = fmt.Errorf("unable to set k-receipt deduction")
+	ErrInvalidRequest           = fmt.Errorf("invalid request")
+	ErrDeductPersonal           = fmt.Errorf("unable to set personal deduction")
+	ErrDeductKReceipt           = fmt.Errorf("unable to set k-receipt deduction")
+	ErrDeductionNotFound        = fmt.Errorf("deduction not found")
+	ErrListDeductions           = fmt.Errorf("unable to list deductions")
+	ErrGetDeduction             = fmt.Errorf("unable to get deduction")
+	ErrSetDeduction             = fmt.Errorf("unable to set deduction")
+	ErrResetDeduction           = fmt.Errorf("unable to reset deduction")
+	ErrListDeductionHistory     = fmt.Errorf("unable to list deduction history")
+	ErrScheduleDeduction        = fmt.Errorf("unable to schedule deduction")
+	ErrListScheduledDeductions  = fmt.Errorf("unable to list scheduled deductions")
+	ErrCancelScheduledDeduction = fmt.Errorf("unable to cancel scheduled deduction")
+	ErrListExchangeRates        = fmt.Errorf("unable to list exchange rates")
+	ErrSetExchangeRates         = fmt.Errorf("unable to set exchange rates")
+	ErrGetLateFilingRules       = fmt.Errorf("unable to get late filing rules")
+	ErrSetLateFilingRules       = fmt.Errorf("unable to set late filing rules")
 )
 
 type ErrorResponse struct {
@@ -19,3 +36,24 @@ func toErrorResponse(err error) ErrorResponse {
 		Error: err.Error(),
 	}
 }
+
+// toServiceErrorResponse maps an admin service error to its status code,
+// falling back to a generic error so database failures are not exposed.
+func toServiceErrorResponse(err error, fallback error) (int, ErrorResponse) {
+	switch {
+	case errors.Is(err, admin.ErrInvalidDeductionType):
+		return http.StatusNotFound, toErrorResponse(ErrDeductionNotFound)
+
+	case errors.Is(err, admin.ErrNoAllowances), errors.Is(err, admin.ErrScheduleNotFound),
+		errors.Is(err, admin.ErrNoLateFilingRules):
+		return http.StatusNotFound, toErrorResponse(err)
+
+	case errors.Is(err, admin.ErrOutOfLimit), errors.Is(err, admin.ErrNotInFuture),
+		errors.Is(err, admin.ErrNoExchangeRates), errors.Is(err, admin.ErrInvalidCurrency),
+		errors.Is(err, admin.ErrDuplicateCurrency), errors.Is(err, admin.ErrInvalidExchangeRate),
+		errors.Is(err, admin.ErrInvalidSurchargeRate), errors.Is(err, admin.ErrInvalidLateFilingPenalty):
+		return http.StatusBadRequest, toErrorResponse(err)
+	}
+
+	return http.StatusInternalServerError, toErrorResponse(fallback)
+}
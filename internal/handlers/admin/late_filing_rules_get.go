@@ -0,0 +1,70 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+type LateFilingRulesGetRequest struct {
+	TaxYear int `query:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2024"`
+}
+
+type LateFilingRulesResponse struct {
+	// TaxYear is the tax year the rules were set for, the latest one up to the tax year asked for.
+	TaxYear int `json:"taxYear" example:"2024"`
+	// SurchargeRate is the percentage of the tax due charged for every month or part of a month it is paid late.
+	SurchargeRate float64     `json:"surchargeRate" example:"1.5"`
+	Penalty       money.Money `json:"penalty" example:"200.0"`
+}
+
+// LateFilingRulesGet gets the late filing rules of a tax year.
+//
+//	@summary		Get late filing rules
+//	@description	Gets the surcharge rate per month and the fixed penalty of paying the tax of the tax year late, from the latest tax year configured up to it.
+//	@tags			admin/late-filing-rules
+//	@produce		json
+//	@param			taxYear	query	int	false	"Tax year, defaults to the current year"
+//	@security		BasicAuth
+//	@success		200	{object}	LateFilingRulesResponse	"Successfully response with the late filing rules"
+//	@failure		400	{object}	ErrorResponse			"Bad request if the input validation fails"
+//	@failure		401	{object}	ErrorResponse			"Unauthorized"
+//	@failure		404	{object}	ErrorResponse			"Not found if no late filing rules are configured up to the tax year"
+//	@failure		500	{object}	ErrorResponse			"Internal Server Error if there is a problem getting the late filing rules"
+//	@router			/admin/late-filing-rules [get]
+func (h handler) LateFilingRulesGet(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	var req LateFilingRulesGetRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.admin.GetLateFilingRules(ctx, admin.LateFilingRulesRequest{TaxYear: req.TaxYear})
+	if err != nil {
+		h.log.Err(err).E("Failed to get late filing rules")
+		return c.JSON(toServiceErrorResponse(err, ErrGetLateFilingRules))
+	}
+
+	return c.JSON(http.StatusOK, toLateFilingRulesResponse(*res))
+}
+
+func toLateFilingRulesResponse(rules admin.LateFilingRules) LateFilingRulesResponse {
+	return LateFilingRulesResponse{
+		TaxYear:       rules.TaxYear,
+		SurchargeRate: rules.SurchargeRate,
+		Penalty:       rules.Penalty,
+	}
+}
@@ -0,0 +1,97 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestLateFilingRulesGet(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockBehavior func(*admin.MockService)
+		query        string
+		expected     LateFilingRulesResponse
+		expectedCode int
+	}{
+		{
+			name: "Normal case",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("GetLateFilingRules", mock.Anything, admin.LateFilingRulesRequest{TaxYear: 2024}).Return(&admin.LateFilingRules{
+					TaxYear: 2024, SurchargeRate: 1.5, Penalty: money.FromBaht(200),
+				}, nil)
+			},
+			query:        "?taxYear=2024",
+			expected:     LateFilingRulesResponse{TaxYear: 2024, SurchargeRate: 1.5, Penalty: money.FromBaht(200)},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Request parameters are invalid on Bind",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			query:        "?taxYear=last",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Request parameters are invalid on Validate",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			query:        "?taxYear=1000",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "No rules up to the tax year",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("GetLateFilingRules", mock.Anything, mock.Anything).Return(nil, admin.ErrLateFilingRulesNotFound(2000))
+			},
+			query:        "?taxYear=2000",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Service error",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("GetLateFilingRules", mock.Anything, mock.Anything).Return(nil, admin.ErrQueryLateFilingRules)
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			req := httptest.NewRequest(http.MethodGet, "/admin/late-filing-rules"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+
+			log := logger.NewMockLogger()
+			ms := new(admin.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err := h.LateFilingRulesGet(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result LateFilingRulesResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+type LateFilingRulesUpdateRequest struct {
+	TaxYear int `json:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2024"`
+	// SurchargeRate is the percentage of the tax due charged for every month or part of a month it is paid late.
+	SurchargeRate float64     `json:"surchargeRate" validate:"min=0,max=100" example:"1.5"`
+	Penalty       money.Money `json:"penalty" validate:"min=0" example:"200.0"`
+}
+
+// LateFilingRulesUpdate sets the late filing rules of a tax year.
+//
+//	@summary		Set late filing rules
+//	@description	Sets the surcharge rate per month, with up to two decimals, and the fixed penalty of paying the tax of the tax year late.
+//	@tags			admin/late-filing-rules
+//	@accept			json
+//	@produce		json
+//	@param			request	body	LateFilingRulesUpdateRequest	true	"Input request for setting the late filing rules"
+//	@security		BasicAuth
+//	@success		200	{object}	LateFilingRulesResponse	"Successfully response with the late filing rules set"
+//	@failure		400	{object}	ErrorResponse			"Bad request if the input validation fails or a rule is out of range"
+//	@failure		401	{object}	ErrorResponse			"Unauthorized"
+//	@failure		500	{object}	ErrorResponse			"Internal Server Error if there is a problem setting the late filing rules"
+//	@router			/admin/late-filing-rules [put]
+func (h handler) LateFilingRulesUpdate(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if c.Request().Body == http.NoBody {
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	var req LateFilingRulesUpdateRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.admin.SetLateFilingRules(ctx, admin.SetLateFilingRulesRequest{
+		TaxYear:       req.TaxYear,
+		SurchargeRate: req.SurchargeRate,
+		Penalty:       req.Penalty,
+	})
+	if err != nil {
+		h.log.Err(err).E("Failed to set late filing rules")
+		return c.JSON(toServiceErrorResponse(err, ErrSetLateFilingRules))
+	}
+
+	return c.JSON(http.StatusOK, toLateFilingRulesResponse(*res))
+}
@@ -0,0 +1,117 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/admin"
+	"github.com/ztrixack/assessment-tax/internal/utils/constants"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestLateFilingRulesUpdate(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockBehavior func(*admin.MockService)
+		contentType  string
+		body         string
+		expected     LateFilingRulesResponse
+		expectedCode int
+	}{
+		{
+			name: "Normal case",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("SetLateFilingRules", mock.Anything, admin.SetLateFilingRulesRequest{
+					TaxYear: 2024, SurchargeRate: 1.25, Penalty: money.FromBaht(500),
+				}).Return(&admin.LateFilingRules{TaxYear: 2024, SurchargeRate: 1.25, Penalty: money.FromBaht(500)}, nil)
+			},
+			contentType:  constants.APPLICATION_JSON,
+			body:         `{"taxYear": 2024, "surchargeRate": 1.25, "penalty": 500.0}`,
+			expected:     LateFilingRulesResponse{TaxYear: 2024, SurchargeRate: 1.25, Penalty: money.FromBaht(500)},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Request parameters are invalid on Bind",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			contentType:  constants.TEXT_PLAIN,
+			body:         `{"surchargeRate": 1.5, "penalty": 200.0}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Surcharge rate is above 100 percent",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			contentType:  constants.APPLICATION_JSON,
+			body:         `{"surchargeRate": 150, "penalty": 200.0}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Penalty is negative",
+			mockBehavior: func(ms *admin.MockService) {
+				// Do nothing
+			},
+			contentType:  constants.APPLICATION_JSON,
+			body:         `{"surchargeRate": 1.5, "penalty": -1.0}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Surcharge rate has more than two decimals",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("SetLateFilingRules", mock.Anything, mock.Anything).Return(nil, admin.ErrInvalidSurchargeRate)
+			},
+			contentType:  constants.APPLICATION_JSON,
+			body:         `{"surchargeRate": 1.125, "penalty": 200.0}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Service is broken",
+			mockBehavior: func(ms *admin.MockService) {
+				ms.On("SetLateFilingRules", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("some error"))
+			},
+			contentType:  constants.APPLICATION_JSON,
+			body:         `{"surchargeRate": 1.5, "penalty": 200.0}`,
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/late-filing-rules", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+
+			log := logger.NewMockLogger()
+			ms := new(admin.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err := h.LateFilingRulesUpdate(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result LateFilingRulesResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
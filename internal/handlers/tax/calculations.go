@@ -8,48 +8,236 @@ import (
 	"github.com/ztrixack/assessment-tax/internal/modules/api"
 	"github.com/ztrixack/assessment-tax/internal/modules/logger"
 	"github.com/ztrixack/assessment-tax/internal/services/tax"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
 type CalculationsRequest struct {
-	TotalIncome *float64    `json:"totalIncome" validate:"min=0" example:"500000.0"`
-	WHT         float64     `json:"wht" validate:"min=0" example:"0.0"`
-	Allowances  []Allowance `json:"allowances" validate:"dive"`
+	TaxYear     int          `json:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2024"`
+	TotalIncome *money.Money `json:"totalIncome" validate:"required_without=Incomes,omitempty,min=0" example:"500000.0"`
+	Incomes     []Income     `json:"incomes" validate:"dive"`
+	WHT         money.Money  `json:"wht" validate:"min=0" example:"0.0"`
+	// Withholdings are withholding tax amounts in their currency, added to wht once converted to baht.
+	Withholdings []WithholdingItem `json:"withholdings" validate:"dive"`
+	Allowances   []Allowance       `json:"allowances" validate:"dive"`
+	// FilingStatus is single when it is not set.
+	FilingStatus string `json:"filingStatus" validate:"omitempty,filing" example:"single" enums:"single,spouse-no-income,joint,separate"`
+	// Residency is resident when it is not set.
+	Residency string `json:"residency" validate:"omitempty,residency" example:"resident" enums:"resident,non-resident"`
+	// Spouse is required to file jointly or separately.
+	Spouse *Spouse `json:"spouse,omitempty"`
+	// LumpSums are taxed apart from the other income.
+	LumpSums []LumpSumPayment `json:"lumpSums" validate:"dive"`
 }
 
 func (r *CalculationsRequest) toServiceRequest() tax.CalculateRequest {
+	var income money.Money
+	if r.TotalIncome != nil {
+		income = *r.TotalIncome
+	}
+
 	return tax.CalculateRequest{
-		Income:     *r.TotalIncome,
-		WHT:        r.WHT,
-		Allowances: remapAllowances(r.Allowances),
+		TaxYear:      r.TaxYear,
+		Income:       income,
+		Incomes:      remapIncomes(r.Incomes),
+		WHT:          r.WHT,
+		Withholdings: remapWithholdings(r.Withholdings),
+		Allowances:   remapAllowances(r.Allowances),
+		FilingStatus: tax.FilingStatus(r.FilingStatus),
+		Residency:    tax.Residency(r.Residency),
+		Spouse:       remapSpouse(r.Spouse),
+		LumpSums:     remapLumpSums(r.LumpSums),
 	}
 }
 
+// LumpSumPayment is a payment made once on leaving employment.
+type LumpSumPayment struct {
+	LumpSumType    string      `json:"lumpSumType" validate:"required,lumpsum" example:"severance" enums:"severance,provident-fund"`
+	Amount         money.Money `json:"amount" validate:"min=0" example:"1000000.0"`
+	YearsOfService int         `json:"yearsOfService" validate:"required,min=1,max=100" example:"10"`
+}
+
+// Spouse is the income of a spouse with income, with the withholding tax and the allowances of their own.
+type Spouse struct {
+	TotalIncome money.Money `json:"totalIncome" validate:"min=0" example:"0.0"`
+	Incomes     []Income    `json:"incomes" validate:"dive"`
+	WHT         money.Money `json:"wht" validate:"min=0" example:"0.0"`
+	Allowances  []Allowance `json:"allowances" validate:"dive"`
+}
+
+type Income struct {
+	IncomeType string      `json:"incomeType" validate:"required,income" example:"40(1)"`
+	Amount     money.Money `json:"amount" validate:"min=0" example:"600000.0"`
+	// FinalWithholding marks interest (40(4)(a)) and dividends (40(4)(b)) taxed at source at the final rate.
+	FinalWithholding bool `json:"finalWithholding,omitempty" example:"false"`
+	// CorporateRate is the corporate income tax rate of the company paying a dividend, which the dividend tax credit is worked out by.
+	CorporateRate float64 `json:"corporateRate,omitempty" validate:"min=0,lt=1" example:"0.2"`
+	// Foreign marks income sourced outside Thailand, of which the amount is the part remitted to Thailand.
+	Foreign bool `json:"foreign,omitempty" example:"false"`
+	// ForeignTax is the tax paid abroad on foreign-sourced income, credited up to the tax on that income.
+	ForeignTax money.Money `json:"foreignTax,omitempty" validate:"min=0" example:"0.0"`
+	// Currency is the currency of the amount and the foreign tax, THB when it is not set.
+	Currency string `json:"currency,omitempty" validate:"omitempty,len=3,uppercase" example:"THB"`
+}
+
+// WithholdingItem is an amount of withholding tax in its currency.
+type WithholdingItem struct {
+	Amount money.Money `json:"amount" validate:"min=0" example:"1000.0"`
+	// Currency is THB when it is not set.
+	Currency string `json:"currency,omitempty" validate:"omitempty,len=3,uppercase" example:"USD"`
+}
+
 type Allowance struct {
-	AllowanceType string  `json:"allowanceType" validate:"required,oneof=donation k-receipt" example:"donation"`
-	Amount        float64 `json:"amount" validate:"min=0" example:"0.0"`
+	AllowanceType string      `json:"allowanceType" validate:"required,allowance" example:"donation"`
+	Amount        money.Money `json:"amount" validate:"min=0" example:"0.0"`
 }
 
 type CalculationsResponse struct {
-	Tax       float64    `json:"tax"`
-	TaxLevel  []TaxLevel `json:"taxLevel"`
-	TaxRefund *float64   `json:"taxRefund,omitempty"`
+	TaxYear        int          `json:"taxYear"`
+	FilingStatus   string       `json:"filingStatus" example:"single" enums:"single,spouse-no-income,joint,separate"`
+	Residency      string       `json:"residency" example:"resident" enums:"resident,non-resident"`
+	Tax            money.Money  `json:"tax"`
+	TaxLevel       []TaxLevel   `json:"taxLevel"`
+	TaxRefund      *money.Money `json:"taxRefund,omitempty"`
+	Expenses       []Expense    `json:"expenses,omitempty"`
+	TaxMethod      string       `json:"taxMethod" example:"progressive" enums:"progressive,gross-income"`
+	ProgressiveTax money.Money  `json:"progressiveTax" example:"29000.0"`
+	GrossIncomeTax money.Money  `json:"grossIncomeTax" example:"0.0"`
+	DividendCredit money.Money  `json:"dividendCredit" example:"0.0"`
+	// ForeignTaxCredit is the foreign tax paid credited against the tax.
+	ForeignTaxCredit money.Money `json:"foreignTaxCredit" example:"0.0"`
+	// ExemptForeignIncome is the foreign-sourced income left out of the return of a non-resident.
+	ExemptForeignIncome money.Money `json:"exemptForeignIncome" example:"0.0"`
+	Rounding            Rounding    `json:"rounding"`
+	// Filing is only returned when filing jointly or separately.
+	Filing *FilingOptions `json:"filing,omitempty"`
+	// LumpSum is only returned when there are lump sums.
+	LumpSum *LumpSumAssessment `json:"lumpSum,omitempty"`
+	// FinalWithholding is only returned when an income is taxed at source at the final rate.
+	FinalWithholding *FinalWithholdingOptions `json:"finalWithholding,omitempty"`
+	// Conversions are only returned when an amount is in a foreign currency.
+	Conversions []CurrencyConversion `json:"conversions,omitempty"`
+	// Explanation is only returned when asked for with the explain query parameter.
+	Explanation *CalculationExplanation `json:"explanation,omitempty"`
+}
+
+// CurrencyConversion is an amount in a foreign currency converted to baht by the exchange rate of the tax year.
+type CurrencyConversion struct {
+	Item string `json:"item" example:"income" enums:"income,foreign-tax,wht"`
+	// IncomeType is the type of the income converted, or the income the foreign tax was paid on.
+	IncomeType string      `json:"incomeType,omitempty" example:"40(1)"`
+	Spouse     bool        `json:"spouse,omitempty" example:"false"`
+	Currency   string      `json:"currency" example:"USD"`
+	Amount     money.Money `json:"amount" example:"20000.0"`
+	Rate       float64     `json:"rate" example:"35.5"`
+	Converted  money.Money `json:"converted" example:"710000.0"`
+}
+
+// FilingOptions is the tax of the income of both spouses filed jointly and separately.
+type FilingOptions struct {
+	Joint    FilingTax `json:"joint"`
+	Separate FilingTax `json:"separate"`
+	Cheaper  string    `json:"cheaper" example:"separate" enums:"joint,separate"`
+}
+
+type FilingTax struct {
+	FilingStatus string       `json:"filingStatus" example:"separate" enums:"joint,separate"`
+	Tax          money.Money  `json:"tax" example:"38000.0"`
+	TaxRefund    *money.Money `json:"taxRefund,omitempty"`
+	TotalTax     money.Money  `json:"totalTax" example:"38000.0"`
+}
+
+// LumpSumAssessment is the tax of the lump sums, worked out apart from the other income and included in the tax.
+type LumpSumAssessment struct {
+	Income money.Money `json:"income" example:"1000000.0"`
+	// Deduction is 7,000 for every year of service, and half of what is left.
+	Deduction money.Money `json:"deduction" example:"535000.0"`
+	NetIncome money.Money `json:"netIncome" example:"465000.0"`
+	Tax       money.Money `json:"tax" example:"31500.0"`
+	TaxLevel  []TaxLevel  `json:"taxLevel"`
+}
+
+// FinalWithholdingOptions is the tax of the return with the income taxed at source at the final rate included and left out.
+type FinalWithholdingOptions struct {
+	Income   money.Money         `json:"income" example:"100000.0"`
+	Withheld money.Money         `json:"withheld" example:"10000.0"`
+	Included FinalWithholdingTax `json:"included"`
+	Excluded FinalWithholdingTax `json:"excluded"`
+	// Include recommends including the income in the return, which the result is worked out by.
+	Include bool `json:"include" example:"true"`
+}
+
+type FinalWithholdingTax struct {
+	Tax       money.Money  `json:"tax" example:"0.0"`
+	TaxRefund *money.Money `json:"taxRefund,omitempty"`
+	// TotalTax is the tax before the withholding tax is credited, with the tax withheld at source when the income is left out.
+	TotalTax money.Money `json:"totalTax" example:"9000.0"`
+}
+
+type CalculationExplanation struct {
+	Income           money.Money        `json:"income" example:"600000.0"`
+	TotalExpenses    money.Money        `json:"totalExpenses" example:"100000.0"`
+	Allowances       []AppliedAllowance `json:"allowances"`
+	TotalAllowances  money.Money        `json:"totalAllowances" example:"110000.0"`
+	NetIncome        money.Money        `json:"netIncome" example:"390000.0"`
+	TaxBeforeWHT     money.Money        `json:"taxBeforeWht" example:"24000.0"`
+	WHT              money.Money        `json:"wht" example:"25000.0"`
+	DividendCredit   money.Money        `json:"dividendCredit" example:"0.0"`
+	ForeignTaxCredit money.Money        `json:"foreignTaxCredit" example:"0.0"`
+	EffectiveRate    float64            `json:"effectiveRate" example:"0.04"`
+}
+
+type AppliedAllowance struct {
+	AllowanceType string      `json:"allowanceType" example:"k-receipt"`
+	Claimed       money.Money `json:"claimed" example:"80000.0"`
+	Deducted      money.Money `json:"deducted" example:"50000.0"`
+	Limit         string      `json:"limit,omitempty" example:"configured" enums:"per-claim,maximum,configured,income-rate,group"`
+	Group         string      `json:"group,omitempty" example:"insurance"`
+}
+
+// Rounding is the policy the net income, the tax of every level, the tax and the refund were rounded by.
+type Rounding struct {
+	Policy    string       `json:"policy" example:"satang" enums:"satang,revenue-department"`
+	NetIncome RoundingRule `json:"netIncome"`
+	TaxLevel  RoundingRule `json:"taxLevel"`
+	Tax       RoundingRule `json:"tax"`
+	Refund    RoundingRule `json:"refund"`
+}
+
+type RoundingRule struct {
+	Unit money.Money `json:"unit" example:"0.01"`
+	Mode string      `json:"mode" example:"half-up" enums:"half-up,down,up"`
+}
+
+type Expense struct {
+	IncomeType string      `json:"incomeType" example:"40(1)"`
+	Income     money.Money `json:"income" example:"600000.0"`
+	Expense    money.Money `json:"expense" example:"100000.0"`
 }
 
 type TaxLevel struct {
-	Level string  `json:"level"`
-	Tax   float64 `json:"tax"`
+	Level string      `json:"level"`
+	Tax   money.Money `json:"tax"`
 }
 
 // Calculations calculates the tax based on total income, withholding tax (WHT), and allowances.
 //
 //	@summary		Calculate Tax
 //	@description	This endpoint calculates the tax and potentially applicable tax refund and tax levels based on the provided total income, withholding tax, and allowances.
+//	@description	Incomes typed by Revenue Code section 40 have their standard expense deducted, which is broken down in the response.
+//	@description	When the income other than salary is above 120,000, the tax is the higher of the progressive tax and 0.5% of that gross income, and the method used is returned with both amounts.
+//	@description	The net income, the tax of every level, the tax and the refund are rounded by the configured policy, which is returned with the result.
+//	@description	Severance and provident fund lump sums are deducted 7,000 for every year of service and half of what is left, and taxed by the brackets apart from the other income. Their tax is added to the tax and broken down in the response.
+//	@description	Dividends paid with the corporate rate of the paying company are grossed up by the dividend tax credit, which is credited like the withholding tax and returned on its own.
+//	@description	Interest and dividends taxed at source at the final rate are worked out both included in the return and left out, and the result is the option with the lower tax.
+//	@description	The filing status decides which allowances apply, such as the spouse allowance. Filing jointly or separately takes the income of the spouse, and returns the tax of both options with the cheaper one.
 //	@tags			tax
 //	@accept			json
 //	@produce		json
 //	@param			request	body		CalculationsRequest		true	"Input request for tax calculation"
+//	@param			explain	query		bool					false	"Return the trace of every step of the calculation, including each cap that was hit"
 //	@success		200		{object}	CalculationsResponse	"Successfully calculated tax and returns the tax details"
-//	@failure		400		{object}	ErrorResponse			"Bad request if the input validation fails"
+//	@failure		400		{object}	ErrorResponse			"Bad request if the input validation fails or an allowance does not apply to the filing status"
+//	@failure		404		{object}	ErrorResponse			"Not found if the tax year is not configured"
 //	@failure		500		{object}	ErrorResponse			"Internal server error if the tax calculations service fails"
 //	@router			/tax/calculations [post]
 func (h *handler) Calculations(c api.Context) error {
@@ -71,10 +259,18 @@ func (h *handler) Calculations(c api.Context) error {
 		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
 	}
 
-	res, err := h.tax.Calculate(ctx, req.toServiceRequest())
+	explain, err := getExplainFromRequest(c)
+	if err != nil {
+		h.log.Err(err).E("Failed to get explain from request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	sreq := req.toServiceRequest()
+	sreq.Explain = explain
+	res, err := h.tax.Calculate(ctx, sreq)
 	if err != nil {
 		h.log.Err(err).E("Failed to calculate tax")
-		return c.JSON(http.StatusInternalServerError, toErrorResponse(ErrCalculateTax))
+		return c.JSON(toServiceErrorResponse(err, ErrCalculateTax))
 	}
 
 	return c.JSON(http.StatusOK, toCalculationsResponse(*res))
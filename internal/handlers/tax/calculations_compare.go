@@ -0,0 +1,121 @@
+package tax
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/tax"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+type CalculationsCompareRequest struct {
+	Base      CalculationsRequest   `json:"base"`
+	Scenarios []CalculationScenario `json:"scenarios" validate:"required,min=1,max=20,dive"`
+}
+
+// CalculationScenario is a change to the base, whose incomes, withholding tax and allowances are added to those of the base.
+type CalculationScenario struct {
+	Name        string      `json:"name" validate:"required" example:"+k-receipt 50k"`
+	TotalIncome money.Money `json:"totalIncome" validate:"min=0" example:"0.0"`
+	Incomes     []Income    `json:"incomes" validate:"dive"`
+	WHT         money.Money `json:"wht" validate:"min=0" example:"0.0"`
+	Allowances  []Allowance `json:"allowances" validate:"dive"`
+	// Raise changes every income of the base by a share, such as 0.1 for 10% more.
+	Raise float64 `json:"raise" validate:"gt=-1" example:"0.1"`
+}
+
+type CalculationsCompareResponse struct {
+	Base      CalculationsResponse `json:"base"`
+	Scenarios []ComparedScenario   `json:"scenarios"`
+}
+
+type ComparedScenario struct {
+	Name        string               `json:"name" example:"+k-receipt 50k"`
+	Calculation CalculationsResponse `json:"calculation"`
+	TaxDelta    money.Money          `json:"taxDelta" example:"-5000.0"`
+	RefundDelta money.Money          `json:"refundDelta" example:"0.0"`
+	// TotalTaxDelta is the change of the tax before the withholding tax is credited.
+	TotalTaxDelta money.Money `json:"totalTaxDelta" example:"-5000.0"`
+}
+
+// CalculationsCompare calculates the tax of several what-if scenarios side by side with a base.
+//
+//	@summary		Compare tax scenarios
+//	@description	Calculates the base and every named scenario against the same snapshot of allowance settings and tax brackets, and returns the change of each scenario from the base.
+//	@description	The incomes, withholding tax and allowances of a scenario are added to those of the base, after its raise is applied to the incomes of the base.
+//	@tags			tax
+//	@accept			json
+//	@produce		json
+//	@param			request	body		CalculationsCompareRequest	true	"Input request for the comparison"
+//	@success		200		{object}	CalculationsCompareResponse	"Successfully calculated the base and every scenario"
+//	@failure		400		{object}	ErrorResponse				"Bad request if the input validation fails or scenario names are not unique"
+//	@failure		404		{object}	ErrorResponse				"Not found if the tax year is not configured"
+//	@failure		500		{object}	ErrorResponse				"Internal server error if the tax calculations service fails"
+//	@router			/tax/calculations/compare [post]
+func (h *handler) CalculationsCompare(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if c.Request().Body == http.NoBody {
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	var req CalculationsCompareRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.tax.Compare(ctx, req.toServiceRequest())
+	if err != nil {
+		h.log.Err(err).E("Failed to compare scenarios")
+		return c.JSON(toServiceErrorResponse(err, ErrCalculateTax))
+	}
+
+	return c.JSON(http.StatusOK, toCalculationsCompareResponse(*res))
+}
+
+func (r *CalculationsCompareRequest) toServiceRequest() tax.CompareRequest {
+	scenarios := make([]tax.Scenario, len(r.Scenarios))
+	for i, s := range r.Scenarios {
+		scenarios[i] = tax.Scenario{
+			Name:       s.Name,
+			Income:     s.TotalIncome,
+			Incomes:    remapIncomes(s.Incomes),
+			WHT:        s.WHT,
+			Allowances: remapAllowances(s.Allowances),
+			Raise:      s.Raise,
+		}
+	}
+
+	return tax.CompareRequest{
+		Base:      r.Base.toServiceRequest(),
+		Scenarios: scenarios,
+	}
+}
+
+func toCalculationsCompareResponse(r tax.CompareResponse) CalculationsCompareResponse {
+	scenarios := make([]ComparedScenario, len(r.Scenarios))
+	for i, s := range r.Scenarios {
+		scenarios[i] = ComparedScenario{
+			Name:          s.Name,
+			Calculation:   toCalculationsResponse(s.Calculation),
+			TaxDelta:      s.TaxDelta,
+			RefundDelta:   s.RefundDelta,
+			TotalTaxDelta: s.TotalTaxDelta,
+		}
+	}
+
+	return CalculationsCompareResponse{
+		Base:      toCalculationsResponse(r.Base),
+		Scenarios: scenarios,
+	}
+}
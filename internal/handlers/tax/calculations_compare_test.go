@@ -0,0 +1,137 @@
+package tax
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/tax"
+	"github.com/ztrixack/assessment-tax/internal/utils/constants"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestCalculationsCompare(t *testing.T) {
+	levels := []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 29000 * money.Baht}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}}
+	scenarioLevels := []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 24000 * money.Baht}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}}
+
+	tests := []struct {
+		name         string
+		mockBehavior func(*tax.MockService)
+		request      interface{}
+		expected     CalculationsCompareResponse
+		expectedCode int
+	}{
+		{
+			name: "Compare a scenario with the base",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("Compare", mock.Anything, tax.CompareRequest{
+					Base:      tax.CalculateRequest{Income: 500000 * money.Baht, Incomes: []tax.Income{}, Allowances: []tax.Allowance{}},
+					Scenarios: []tax.Scenario{{Name: "+k-receipt 50k", Incomes: []tax.Income{}, Allowances: []tax.Allowance{{Type: tax.KReceipt, Amount: 50000 * money.Baht}}}},
+				}).Return(&tax.CompareResponse{
+					Base: tax.CalculateResponse{Tax: 29000 * money.Baht, TaxLevel: toTaxLevels(0, 29000, 0, 0, 0)},
+					Scenarios: []tax.ScenarioResult{{
+						Name:          "+k-receipt 50k",
+						Calculation:   tax.CalculateResponse{Tax: 24000 * money.Baht, TaxLevel: toTaxLevels(0, 24000, 0, 0, 0)},
+						TaxDelta:      -5000 * money.Baht,
+						TotalTaxDelta: -5000 * money.Baht,
+					}},
+				}, nil)
+			},
+			request: CalculationsCompareRequest{
+				Base:      CalculationsRequest{TotalIncome: pointerTo(500000.0)},
+				Scenarios: []CalculationScenario{{Name: "+k-receipt 50k", Allowances: []Allowance{{AllowanceType: "k-receipt", Amount: 50000 * money.Baht}}}},
+			},
+			expected: CalculationsCompareResponse{
+				Base: CalculationsResponse{Tax: 29000 * money.Baht, TaxLevel: levels},
+				Scenarios: []ComparedScenario{{
+					Name:          "+k-receipt 50k",
+					Calculation:   CalculationsResponse{Tax: 24000 * money.Baht, TaxLevel: scenarioLevels},
+					TaxDelta:      -5000 * money.Baht,
+					TotalTaxDelta: -5000 * money.Baht,
+				}},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "No scenarios",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      CalculationsCompareRequest{Base: CalculationsRequest{TotalIncome: pointerTo(500000.0)}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Base without income",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      CalculationsCompareRequest{Scenarios: []CalculationScenario{{Name: "raise", Raise: 0.1}}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Unnamed scenario",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      CalculationsCompareRequest{Base: CalculationsRequest{TotalIncome: pointerTo(500000.0)}, Scenarios: []CalculationScenario{{Raise: 0.1}}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Raise removes the whole income",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      CalculationsCompareRequest{Base: CalculationsRequest{TotalIncome: pointerTo(500000.0)}, Scenarios: []CalculationScenario{{Name: "unpaid", Raise: -1}}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Duplicate scenario names",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("Compare", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: %q", tax.ErrDuplicateScenario, "raise"))
+			},
+			request:      CalculationsCompareRequest{Base: CalculationsRequest{TotalIncome: pointerTo(500000.0)}, Scenarios: []CalculationScenario{{Name: "raise", Raise: 0.1}, {Name: "raise", Raise: 0.2}}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Calculation service is broken",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("Compare", mock.Anything, mock.Anything).Return(nil, errors.New("some error"))
+			},
+			request:      CalculationsCompareRequest{Base: CalculationsRequest{TotalIncome: pointerTo(500000.0)}, Scenarios: []CalculationScenario{{Name: "raise", Raise: 0.1}}},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			reqb, err := json.Marshal(tt.request)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/tax/calculations/compare", bytes.NewBuffer(reqb))
+			req.Header.Set("Content-Type", constants.APPLICATION_JSON)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+
+			log := logger.NewMockLogger()
+			ms := new(tax.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err = h.CalculationsCompare(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result CalculationsCompareResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
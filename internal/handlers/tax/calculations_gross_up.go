@@ -0,0 +1,90 @@
+package tax
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/tax"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+type CalculationsGrossUpRequest struct {
+	TaxYear int    `json:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2024"`
+	Target  string `json:"target" validate:"required,oneof=tax take-home refund" example:"take-home" enums:"tax,take-home,refund"`
+	// Amount is the tax payable, the income left after the tax or the refund to solve the income for.
+	Amount money.Money `json:"amount" validate:"min=0" example:"471000.0"`
+	// IncomeType is the type of the income solved for, whose standard expense is deducted. The income is untyped when it is empty.
+	IncomeType string      `json:"incomeType" validate:"omitempty,income" example:"40(1)"`
+	WHT        money.Money `json:"wht" validate:"min=0" example:"0.0"`
+	Allowances []Allowance `json:"allowances" validate:"dive"`
+}
+
+type CalculationsGrossUpResponse struct {
+	Income      money.Money          `json:"income" example:"500000.0"`
+	TakeHome    money.Money          `json:"takeHome" example:"471000.0"`
+	Calculation CalculationsResponse `json:"calculation"`
+}
+
+// CalculationsGrossUp finds the income that reaches a target tax, take-home pay or refund.
+//
+//	@summary		Gross up income
+//	@description	Solves for the lowest income whose calculation reaches the target, with the same allowances and withholding tax applied to it.
+//	@description	The target is the tax payable after the withholding tax, the income left after the tax, or the refund of the withholding tax.
+//	@tags			tax
+//	@accept			json
+//	@produce		json
+//	@param			request	body		CalculationsGrossUpRequest	true	"Input request for the gross-up"
+//	@success		200		{object}	CalculationsGrossUpResponse	"Successfully found the income and returns its calculation"
+//	@failure		400		{object}	ErrorResponse				"Bad request if the input validation fails or no income reaches the target"
+//	@failure		404		{object}	ErrorResponse				"Not found if the tax year is not configured"
+//	@failure		500		{object}	ErrorResponse				"Internal server error if the gross-up service fails"
+//	@router			/tax/calculations/gross-up [post]
+func (h *handler) CalculationsGrossUp(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if c.Request().Body == http.NoBody {
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	var req CalculationsGrossUpRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.tax.GrossUp(ctx, req.toServiceRequest())
+	if err != nil {
+		h.log.Err(err).E("Failed to gross up income")
+		return c.JSON(toServiceErrorResponse(err, ErrGrossUp))
+	}
+
+	return c.JSON(http.StatusOK, toCalculationsGrossUpResponse(*res))
+}
+
+func (r *CalculationsGrossUpRequest) toServiceRequest() tax.GrossUpRequest {
+	return tax.GrossUpRequest{
+		TaxYear:    r.TaxYear,
+		Target:     tax.GrossUpTarget(r.Target),
+		Amount:     r.Amount,
+		IncomeType: tax.IncomeType(r.IncomeType),
+		WHT:        r.WHT,
+		Allowances: remapAllowances(r.Allowances),
+	}
+}
+
+func toCalculationsGrossUpResponse(r tax.GrossUpResponse) CalculationsGrossUpResponse {
+	return CalculationsGrossUpResponse{
+		Income:      r.Income,
+		TakeHome:    r.TakeHome,
+		Calculation: toCalculationsResponse(r.Calculation),
+	}
+}
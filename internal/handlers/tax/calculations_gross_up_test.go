@@ -0,0 +1,127 @@
+package tax
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/tax"
+	"github.com/ztrixack/assessment-tax/internal/utils/constants"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestCalculationsGrossUp(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockBehavior func(*tax.MockService)
+		request      interface{}
+		expected     CalculationsGrossUpResponse
+		expectedCode int
+	}{
+		{
+			name: "Gross up a take-home salary",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("GrossUp", mock.Anything, tax.GrossUpRequest{
+					Target:     tax.TargetTakeHome,
+					Amount:     571000 * money.Baht,
+					IncomeType: tax.Salary,
+					Allowances: []tax.Allowance{},
+				}).Return(&tax.GrossUpResponse{
+					Income:      600000 * money.Baht,
+					TakeHome:    571000 * money.Baht,
+					Calculation: tax.CalculateResponse{Tax: 29000 * money.Baht, TaxLevel: toTaxLevels(0.0, 29000.0, 0.0, 0.0, 0.0)},
+				}, nil)
+			},
+			request: CalculationsGrossUpRequest{Target: "take-home", Amount: 571000 * money.Baht, IncomeType: "40(1)", Allowances: []Allowance{}},
+			expected: CalculationsGrossUpResponse{
+				Income:   600000 * money.Baht,
+				TakeHome: 571000 * money.Baht,
+				Calculation: CalculationsResponse{
+					Tax:      29000 * money.Baht,
+					TaxLevel: []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 29000 * money.Baht}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
+				},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Unknown target",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      CalculationsGrossUpRequest{Target: "net-worth", Amount: 1000 * money.Baht},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Negative amount",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      CalculationsGrossUpRequest{Target: "tax", Amount: -1 * money.Baht},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Unknown income type",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      CalculationsGrossUpRequest{Target: "tax", Amount: 1000 * money.Baht, IncomeType: "40(9)"},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Invalid request",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      "invalid",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "No income reaches the target",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("GrossUp", mock.Anything, mock.Anything).Return(nil, tax.ErrGrossUpUnreachable)
+			},
+			request:      CalculationsGrossUpRequest{Target: "tax", Amount: 1000000000000 * money.Baht},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Gross-up service is broken",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("GrossUp", mock.Anything, mock.Anything).Return(nil, errors.New("some error"))
+			},
+			request:      CalculationsGrossUpRequest{Target: "tax", Amount: 1000 * money.Baht},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			reqb, err := json.Marshal(tt.request)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/tax/calculations/gross-up", bytes.NewBuffer(reqb))
+			req.Header.Set("Content-Type", constants.APPLICATION_JSON)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+
+			log := logger.NewMockLogger()
+			ms := new(tax.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err = h.CalculationsGrossUp(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result CalculationsGrossUpResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
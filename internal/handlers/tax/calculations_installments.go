@@ -0,0 +1,95 @@
+package tax
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/tax"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+type CalculationsInstallmentsRequest struct {
+	CalculationsRequest
+	// Deadline is the due date of the first installment.
+	Deadline time.Time `json:"deadline" validate:"required" example:"2025-04-08T00:00:00Z"`
+}
+
+type CalculationsInstallmentsResponse struct {
+	TaxYear int         `json:"taxYear" example:"2024"`
+	Tax     money.Money `json:"tax" example:"29000.0"`
+	// Eligible is false when the tax is below the threshold and paid in full by the deadline.
+	Eligible     bool             `json:"eligible" example:"true"`
+	Installments []TaxInstallment `json:"installments"`
+}
+
+type TaxInstallment struct {
+	Number  int         `json:"number" example:"1"`
+	DueDate time.Time   `json:"dueDate" example:"2025-04-08T00:00:00Z"`
+	Amount  money.Money `json:"amount" example:"9666.66"`
+}
+
+// CalculationsInstallments plans the installments of the tax due.
+//
+//	@summary		Plan tax installments
+//	@description	Calculates the tax and splits it into three monthly installments from the deadline on when it is at least 3,000 baht.
+//	@description	The installments are in whole satang, the last one making up the tax. A tax below the threshold is a single payment due by the deadline.
+//	@tags			tax
+//	@accept			json
+//	@produce		json
+//	@param			request	body		CalculationsInstallmentsRequest		true	"Input request for the calculation and the deadline"
+//	@success		200		{object}	CalculationsInstallmentsResponse	"Successfully returns the installments of the tax"
+//	@failure		400		{object}	ErrorResponse						"Bad request if the input validation fails"
+//	@failure		404		{object}	ErrorResponse						"Not found if the tax year is not configured"
+//	@failure		500		{object}	ErrorResponse						"Internal server error if the tax calculations service fails"
+//	@router			/tax/calculations/installments [post]
+func (h *handler) CalculationsInstallments(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if c.Request().Body == http.NoBody {
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	var req CalculationsInstallmentsRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.tax.PlanInstallments(ctx, tax.InstallmentRequest{
+		CalculateRequest: req.toServiceRequest(),
+		Deadline:         req.Deadline,
+	})
+	if err != nil {
+		h.log.Err(err).E("Failed to plan tax installments")
+		return c.JSON(toServiceErrorResponse(err, ErrCalculateTax))
+	}
+
+	return c.JSON(http.StatusOK, toCalculationsInstallmentsResponse(*res))
+}
+
+func toCalculationsInstallmentsResponse(r tax.InstallmentResponse) CalculationsInstallmentsResponse {
+	installments := make([]TaxInstallment, len(r.Installments))
+	for i, installment := range r.Installments {
+		installments[i] = TaxInstallment{
+			Number:  installment.Number,
+			DueDate: installment.DueDate,
+			Amount:  installment.Amount,
+		}
+	}
+
+	return CalculationsInstallmentsResponse{
+		TaxYear:      r.TaxYear,
+		Tax:          r.Tax,
+		Eligible:     r.Eligible,
+		Installments: installments,
+	}
+}
@@ -0,0 +1,131 @@
+package tax
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/tax"
+	"github.com/ztrixack/assessment-tax/internal/utils/constants"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestCalculationsInstallments(t *testing.T) {
+	deadline := time.Date(2025, time.April, 8, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		mockBehavior func(*tax.MockService)
+		request      interface{}
+		expected     CalculationsInstallmentsResponse
+		expectedCode int
+	}{
+		{
+			name: "Plan three installments",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("PlanInstallments", mock.Anything, tax.InstallmentRequest{
+					CalculateRequest: tax.CalculateRequest{
+						Income:     500000 * money.Baht,
+						Incomes:    []tax.Income{},
+						Allowances: []tax.Allowance{},
+					},
+					Deadline: deadline,
+				}).Return(&tax.InstallmentResponse{
+					TaxYear:  2024,
+					Tax:      29000 * money.Baht,
+					Eligible: true,
+					Installments: []tax.Installment{
+						{Number: 1, DueDate: deadline, Amount: money.FromBaht(9666.66)},
+						{Number: 2, DueDate: deadline.AddDate(0, 1, 0), Amount: money.FromBaht(9666.66)},
+						{Number: 3, DueDate: deadline.AddDate(0, 2, 0), Amount: money.FromBaht(9666.68)},
+					},
+				}, nil)
+			},
+			request: CalculationsInstallmentsRequest{
+				CalculationsRequest: CalculationsRequest{TotalIncome: pointerTo(500000.0)},
+				Deadline:            deadline,
+			},
+			expected: CalculationsInstallmentsResponse{
+				TaxYear:  2024,
+				Tax:      29000 * money.Baht,
+				Eligible: true,
+				Installments: []TaxInstallment{
+					{Number: 1, DueDate: deadline, Amount: money.FromBaht(9666.66)},
+					{Number: 2, DueDate: deadline.AddDate(0, 1, 0), Amount: money.FromBaht(9666.66)},
+					{Number: 3, DueDate: deadline.AddDate(0, 2, 0), Amount: money.FromBaht(9666.68)},
+				},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Missing deadline",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      CalculationsInstallmentsRequest{CalculationsRequest: CalculationsRequest{TotalIncome: pointerTo(500000.0)}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Missing income",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      CalculationsInstallmentsRequest{Deadline: deadline},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Invalid request",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      "invalid",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Calculation service is broken",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("PlanInstallments", mock.Anything, mock.Anything).Return(nil, errors.New("some error"))
+			},
+			request: CalculationsInstallmentsRequest{
+				CalculationsRequest: CalculationsRequest{TotalIncome: pointerTo(500000.0)},
+				Deadline:            deadline,
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			reqb, err := json.Marshal(tt.request)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/tax/calculations/installments", bytes.NewBuffer(reqb))
+			req.Header.Set("Content-Type", constants.APPLICATION_JSON)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+
+			log := logger.NewMockLogger()
+			ms := new(tax.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err = h.CalculationsInstallments(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result CalculationsInstallmentsResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
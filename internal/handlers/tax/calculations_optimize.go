@@ -0,0 +1,91 @@
+package tax
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/tax"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+type CalculationsOptimizeResponse struct {
+	TaxYear         int                       `json:"taxYear" example:"2024"`
+	Tax             money.Money               `json:"tax" example:"29000.0"`
+	TaxRefund       *money.Money              `json:"taxRefund,omitempty"`
+	Recommendations []AllowanceRecommendation `json:"recommendations"`
+}
+
+type AllowanceRecommendation struct {
+	AllowanceType string      `json:"allowanceType" example:"k-receipt"`
+	Claimed       money.Money `json:"claimed" example:"20000.0"`
+	// Headroom is the most that claiming more of the type still lowers the tax by.
+	Headroom money.Money `json:"headroom" example:"30000.0"`
+	TaxSaved money.Money `json:"taxSaved" example:"3000.0"`
+	Limit    string      `json:"limit,omitempty" example:"configured" enums:"per-claim,maximum,configured,income-rate,group"`
+}
+
+// CalculationsOptimize recommends how much more of each allowance is worth claiming.
+//
+//	@summary		Optimize deductions
+//	@description	Works out how much more donation, k-receipt, RMF and SSF spending on top of the current claims would still lower the tax, and by how much.
+//	@description	Each allowance type is worked out on its own, within the configured caps and down to the net income where the brackets charge no more tax.
+//	@description	Non-residents are only recommended the donation, as the other types are restricted to residents.
+//	@tags			tax
+//	@accept			json
+//	@produce		json
+//	@param			request	body		CalculationsRequest				true	"Input request with the current claims"
+//	@success		200		{object}	CalculationsOptimizeResponse	"Successfully returns the recommendation of every allowance type"
+//	@failure		400		{object}	ErrorResponse					"Bad request if the input validation fails"
+//	@failure		404		{object}	ErrorResponse					"Not found if the tax year is not configured"
+//	@failure		500		{object}	ErrorResponse					"Internal server error if the tax calculations service fails"
+//	@router			/tax/calculations/optimize [post]
+func (h *handler) CalculationsOptimize(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if c.Request().Body == http.NoBody {
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	var req CalculationsRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.tax.Optimize(ctx, tax.OptimizeRequest{CalculateRequest: req.toServiceRequest()})
+	if err != nil {
+		h.log.Err(err).E("Failed to optimize deductions")
+		return c.JSON(toServiceErrorResponse(err, ErrCalculateTax))
+	}
+
+	return c.JSON(http.StatusOK, toCalculationsOptimizeResponse(*res))
+}
+
+func toCalculationsOptimizeResponse(r tax.OptimizeResponse) CalculationsOptimizeResponse {
+	recommendations := make([]AllowanceRecommendation, len(r.Recommendations))
+	for i, rec := range r.Recommendations {
+		recommendations[i] = AllowanceRecommendation{
+			AllowanceType: string(rec.Type),
+			Claimed:       rec.Claimed,
+			Headroom:      rec.Headroom,
+			TaxSaved:      rec.TaxSaved,
+			Limit:         string(rec.Limit),
+		}
+	}
+
+	return CalculationsOptimizeResponse{
+		TaxYear:         r.TaxYear,
+		Tax:             r.Tax,
+		TaxRefund:       remapTaxRefund(r.Refund),
+		Recommendations: recommendations,
+	}
+}
@@ -0,0 +1,114 @@
+package tax
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/tax"
+	"github.com/ztrixack/assessment-tax/internal/utils/constants"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestCalculationsOptimize(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockBehavior func(*tax.MockService)
+		request      interface{}
+		expected     CalculationsOptimizeResponse
+		expectedCode int
+	}{
+		{
+			name: "Recommend the allowances left to claim",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("Optimize", mock.Anything, tax.OptimizeRequest{CalculateRequest: tax.CalculateRequest{
+					Income:     500000 * money.Baht,
+					WHT:        30000 * money.Baht,
+					Incomes:    []tax.Income{},
+					Allowances: []tax.Allowance{{Type: tax.KReceipt, Amount: 20000 * money.Baht}},
+				}}).Return(&tax.OptimizeResponse{
+					TaxYear: 2024,
+					Refund:  4000 * money.Baht,
+					Recommendations: []tax.Recommendation{
+						{Type: tax.Donation, Headroom: 42000 * money.Baht, TaxSaved: 4200 * money.Baht, Limit: tax.LimitGroup},
+						{Type: tax.KReceipt, Claimed: 20000 * money.Baht, Headroom: 30000 * money.Baht, TaxSaved: 3000 * money.Baht, Limit: tax.LimitConfigured},
+					},
+				}, nil)
+			},
+			request: CalculationsRequest{
+				TotalIncome: pointerTo(500000.0),
+				WHT:         30000 * money.Baht,
+				Allowances:  []Allowance{{AllowanceType: "k-receipt", Amount: 20000 * money.Baht}},
+			},
+			expected: CalculationsOptimizeResponse{
+				TaxYear:   2024,
+				TaxRefund: pointerTo(4000.0),
+				Recommendations: []AllowanceRecommendation{
+					{AllowanceType: "donation", Headroom: 42000 * money.Baht, TaxSaved: 4200 * money.Baht, Limit: "group"},
+					{AllowanceType: "k-receipt", Claimed: 20000 * money.Baht, Headroom: 30000 * money.Baht, TaxSaved: 3000 * money.Baht, Limit: "configured"},
+				},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Missing income",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      CalculationsRequest{WHT: 30000 * money.Baht},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Invalid request",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      "invalid",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Calculation service is broken",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("Optimize", mock.Anything, mock.Anything).Return(nil, errors.New("some error"))
+			},
+			request:      CalculationsRequest{TotalIncome: pointerTo(500000.0)},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			reqb, err := json.Marshal(tt.request)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/tax/calculations/optimize", bytes.NewBuffer(reqb))
+			req.Header.Set("Content-Type", constants.APPLICATION_JSON)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+
+			log := logger.NewMockLogger()
+			ms := new(tax.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err = h.CalculationsOptimize(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result CalculationsOptimizeResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
@@ -15,10 +15,12 @@ import (
 	"github.com/ztrixack/assessment-tax/internal/modules/logger"
 	"github.com/ztrixack/assessment-tax/internal/services/tax"
 	"github.com/ztrixack/assessment-tax/internal/utils/constants"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
-func pointerTo(value float64) *float64 {
-	return &value
+func pointerTo(value float64) *money.Money {
+	amount := money.FromBaht(value)
+	return &amount
 }
 
 func TestCalculations(t *testing.T) {
@@ -26,6 +28,7 @@ func TestCalculations(t *testing.T) {
 		name         string
 		mockBehavior func(*tax.MockService)
 		contentType  string
+		query        string
 		request      CalculationsRequest
 		expected     CalculationsResponse
 		expectedCode int
@@ -33,120 +36,143 @@ func TestCalculations(t *testing.T) {
 		{
 			name: "Story: EXP01",
 			mockBehavior: func(ms *tax.MockService) {
-				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 29000.0, Refund: 0.0, TaxLevel: []float64{0.0, 29000.0, 0.0, 0.0, 0.0}}, nil)
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 29000 * money.Baht, Refund: 0, TaxLevel: toTaxLevels(0.0, 29000.0, 0.0, 0.0, 0.0)}, nil)
 			},
 			contentType: constants.APPLICATION_JSON,
 			request: CalculationsRequest{
 				TotalIncome: pointerTo(500000.0),
-				WHT:         0.0,
-				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 0.0}},
+				WHT:         0,
+				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 0}},
+			},
+			expected: CalculationsResponse{
+				Tax:      29000 * money.Baht,
+				TaxLevel: []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 29000 * money.Baht}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Incomes with expenses",
+			mockBehavior: func(ms *tax.MockService) {
+				withIncomes := mock.MatchedBy(func(req tax.CalculateRequest) bool {
+					return len(req.Incomes) == 1 && req.Incomes[0].Type == tax.Salary
+				})
+				ms.On("Calculate", mock.Anything, withIncomes).Return(&tax.CalculateResponse{
+					Tax:      29000 * money.Baht,
+					TaxLevel: toTaxLevels(0.0, 29000.0, 0.0, 0.0, 0.0),
+					Expenses: []tax.IncomeExpense{{Income: tax.Income{Type: tax.Salary, Amount: 600000 * money.Baht}, Expense: 100000 * money.Baht}},
+				}, nil)
+			},
+			contentType: constants.APPLICATION_JSON,
+			request: CalculationsRequest{
+				Incomes: []Income{{IncomeType: "40(1)", Amount: 600000 * money.Baht}},
 			},
 			expected: CalculationsResponse{
-				Tax:      29000.0,
-				TaxLevel: []TaxLevel{{constants.T0_150k, 0.0}, {constants.T150k_500k, 29000.0}, {constants.T500k_1M, 0.0}, {constants.T1M_2M, 0.0}, {constants.T2M, 0.0}},
+				Tax:      29000 * money.Baht,
+				TaxLevel: []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 29000 * money.Baht}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
+				Expenses: []Expense{{IncomeType: "40(1)", Income: 600000 * money.Baht, Expense: 100000 * money.Baht}},
 			},
 			expectedCode: http.StatusOK,
 		},
 		{
 			name: "Story: EXP02",
 			mockBehavior: func(ms *tax.MockService) {
-				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 4000.0, Refund: 0.0, TaxLevel: []float64{0.0, 29000.0, 0.0, 0.0, 0.0}}, nil)
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 4000 * money.Baht, Refund: 0, TaxLevel: toTaxLevels(0.0, 29000.0, 0.0, 0.0, 0.0)}, nil)
 			},
 			contentType: constants.APPLICATION_JSON,
 			request: CalculationsRequest{
 				TotalIncome: pointerTo(500000.0),
-				WHT:         25000.0,
-				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 0.0}},
+				WHT:         25000 * money.Baht,
+				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 0}},
 			},
 			expected: CalculationsResponse{
-				Tax:      4000.0,
-				TaxLevel: []TaxLevel{{constants.T0_150k, 0.0}, {constants.T150k_500k, 29000.0}, {constants.T500k_1M, 0.0}, {constants.T1M_2M, 0.0}, {constants.T2M, 0.0}},
+				Tax:      4000 * money.Baht,
+				TaxLevel: []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 29000 * money.Baht}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
 			},
 			expectedCode: http.StatusOK,
 		},
 		{
 			name: "Story: EXP03",
 			mockBehavior: func(ms *tax.MockService) {
-				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 19000.0, Refund: 0.0, TaxLevel: []float64{0.0, 19000.0, 0.0, 0.0, 0.0}}, nil)
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 19000 * money.Baht, Refund: 0, TaxLevel: toTaxLevels(0.0, 19000.0, 0.0, 0.0, 0.0)}, nil)
 			},
 			contentType: constants.APPLICATION_JSON,
 			request: CalculationsRequest{
 				TotalIncome: pointerTo(500000.0),
-				WHT:         0.0,
-				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 200000.0}},
+				WHT:         0,
+				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 200000 * money.Baht}},
 			},
 			expected: CalculationsResponse{
-				Tax:      19000.0,
-				TaxLevel: []TaxLevel{{constants.T0_150k, 0.0}, {constants.T150k_500k, 19000.0}, {constants.T500k_1M, 0.0}, {constants.T1M_2M, 0.0}, {constants.T2M, 0.0}},
+				Tax:      19000 * money.Baht,
+				TaxLevel: []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 19000 * money.Baht}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
 			},
 			expectedCode: http.StatusOK,
 		},
 		{
 			name: "Story: EXP04",
 			mockBehavior: func(ms *tax.MockService) {
-				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 19000.0, Refund: 0.0, TaxLevel: []float64{0.0, 19000.0, 0.0, 0.0, 0.0}}, nil)
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 19000 * money.Baht, Refund: 0, TaxLevel: toTaxLevels(0.0, 19000.0, 0.0, 0.0, 0.0)}, nil)
 			},
 			contentType: constants.APPLICATION_JSON,
 			request: CalculationsRequest{
 				TotalIncome: pointerTo(500000.0),
-				WHT:         0.0,
-				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 200000.0}},
+				WHT:         0,
+				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 200000 * money.Baht}},
 			},
 			expected: CalculationsResponse{
-				Tax:      19000.0,
-				TaxLevel: []TaxLevel{{constants.T0_150k, 0.0}, {constants.T150k_500k, 19000.0}, {constants.T500k_1M, 0.0}, {constants.T1M_2M, 0.0}, {constants.T2M, 0.0}},
+				Tax:      19000 * money.Baht,
+				TaxLevel: []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 19000 * money.Baht}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
 			},
 			expectedCode: http.StatusOK,
 		},
 		{
 			name: "Story: EXP07",
 			mockBehavior: func(ms *tax.MockService) {
-				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 14000.0, Refund: 0.0, TaxLevel: []float64{0.0, 14000.0, 0.0, 0.0, 0.0}}, nil)
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 14000 * money.Baht, Refund: 0, TaxLevel: toTaxLevels(0.0, 14000.0, 0.0, 0.0, 0.0)}, nil)
 			},
 			contentType: constants.APPLICATION_JSON,
 			request: CalculationsRequest{
 				TotalIncome: pointerTo(500000.0),
-				WHT:         0.0,
-				Allowances:  []Allowance{{AllowanceType: "k-receipt", Amount: 200000.0}, {AllowanceType: "donation", Amount: 100000.0}},
+				WHT:         0,
+				Allowances:  []Allowance{{AllowanceType: "k-receipt", Amount: 200000 * money.Baht}, {AllowanceType: "donation", Amount: 100000 * money.Baht}},
 			},
 			expected: CalculationsResponse{
-				Tax:      14000.0,
-				TaxLevel: []TaxLevel{{constants.T0_150k, 0.0}, {constants.T150k_500k, 14000.0}, {constants.T500k_1M, 0.0}, {constants.T1M_2M, 0.0}, {constants.T2M, 0.0}},
+				Tax:      14000 * money.Baht,
+				TaxLevel: []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 14000 * money.Baht}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
 			},
 			expectedCode: http.StatusOK,
 		},
 		{
 			name: "Successful calculation",
 			mockBehavior: func(ms *tax.MockService) {
-				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 1360000.0, Refund: 0.0, TaxLevel: []float64{0.0, 35000.0, 75000.0, 200000.0, 1050000.0}}, nil)
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 1360000 * money.Baht, Refund: 0, TaxLevel: toTaxLevels(0.0, 35000.0, 75000.0, 200000.0, 1050000.0)}, nil)
 			},
 			contentType: constants.APPLICATION_JSON,
 			request: CalculationsRequest{
 				TotalIncome: pointerTo(5000000.0),
-				WHT:         0.0,
-				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 0.0}},
+				WHT:         0,
+				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 0}},
 			},
 			expected: CalculationsResponse{
-				Tax:      1360000.0,
-				TaxLevel: []TaxLevel{{constants.T0_150k, 0.0}, {constants.T150k_500k, 35000.0}, {constants.T500k_1M, 75000.0}, {constants.T1M_2M, 200000.0}, {constants.T2M, 1050000.0}},
+				Tax:      1360000 * money.Baht,
+				TaxLevel: []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 35000 * money.Baht}, {"500,000-1,000,000", 75000 * money.Baht}, {"1,000,000-2,000,000", 200000 * money.Baht}, {"2,000,001 ขึ้นไป", 1050000 * money.Baht}},
 			},
 			expectedCode: http.StatusOK,
 		},
 		{
 			name: "Successful with Refund",
 			mockBehavior: func(ms *tax.MockService) {
-				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 0.0, Refund: 21000.0, TaxLevel: []float64{0.0, 29000.0, 0.0, 0.0, 0.0}}, nil)
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 0, Refund: 21000 * money.Baht, TaxLevel: toTaxLevels(0.0, 29000.0, 0.0, 0.0, 0.0)}, nil)
 			},
 			contentType: constants.APPLICATION_JSON,
 			request: CalculationsRequest{
 				TotalIncome: pointerTo(500000.0),
-				WHT:         50000.0,
-				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 0.0}},
+				WHT:         50000 * money.Baht,
+				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 0}},
 			},
 			expected: CalculationsResponse{
-				Tax:       0.0,
+				Tax:       0,
 				TaxRefund: pointerTo(21000.0),
-				TaxLevel:  []TaxLevel{{constants.T0_150k, 0.0}, {constants.T150k_500k, 29000.0}, {constants.T500k_1M, 0.0}, {constants.T1M_2M, 0.0}, {constants.T2M, 0.0}},
+				TaxLevel:  []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 29000 * money.Baht}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
 			},
 			expectedCode: http.StatusOK,
 		},
@@ -169,6 +195,315 @@ func TestCalculations(t *testing.T) {
 			},
 			expectedCode: http.StatusBadRequest,
 		},
+		{
+			name: "Explain the calculation",
+			mockBehavior: func(ms *tax.MockService) {
+				explain := mock.MatchedBy(func(req tax.CalculateRequest) bool { return req.Explain })
+				ms.On("Calculate", mock.Anything, explain).Return(&tax.CalculateResponse{
+					Tax:      29000 * money.Baht,
+					TaxLevel: toTaxLevels(0.0, 29000.0, 0.0, 0.0, 0.0),
+					Explanation: &tax.Explanation{
+						Income:          500000 * money.Baht,
+						Allowances:      []tax.AllowanceDeduction{{Type: tax.Personal, Claimed: 60000 * money.Baht, Deducted: 60000 * money.Baht}, {Type: tax.KReceipt, Claimed: 80000 * money.Baht, Deducted: 50000 * money.Baht, Limit: tax.LimitConfigured}},
+						TotalAllowances: 110000 * money.Baht,
+						NetIncome:       390000 * money.Baht,
+						TaxBeforeWHT:    24000 * money.Baht,
+						EffectiveRate:   0.048,
+					},
+				}, nil)
+			},
+			contentType: constants.APPLICATION_JSON,
+			query:       "?explain=true",
+			request: CalculationsRequest{
+				TotalIncome: pointerTo(500000.0),
+				Allowances:  []Allowance{{AllowanceType: "k-receipt", Amount: 80000 * money.Baht}},
+			},
+			expected: CalculationsResponse{
+				Tax:      29000 * money.Baht,
+				TaxLevel: []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 29000 * money.Baht}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
+				Explanation: &CalculationExplanation{
+					Income:          500000 * money.Baht,
+					Allowances:      []AppliedAllowance{{AllowanceType: "personal", Claimed: 60000 * money.Baht, Deducted: 60000 * money.Baht}, {AllowanceType: "k-receipt", Claimed: 80000 * money.Baht, Deducted: 50000 * money.Baht, Limit: "configured"}},
+					TotalAllowances: 110000 * money.Baht,
+					NetIncome:       390000 * money.Baht,
+					TaxBeforeWHT:    24000 * money.Baht,
+					EffectiveRate:   0.048,
+				},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Explain is not a boolean",
+			mockBehavior: func(ms *tax.MockService) {
+				// Do nothing
+			},
+			contentType: constants.APPLICATION_JSON,
+			query:       "?explain=maybe",
+			request: CalculationsRequest{
+				TotalIncome: pointerTo(500000.0),
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Joint and separate filing",
+			mockBehavior: func(ms *tax.MockService) {
+				joint := mock.MatchedBy(func(req tax.CalculateRequest) bool {
+					return req.FilingStatus == tax.Joint && req.Spouse != nil && req.Spouse.Income == 400000*money.Baht
+				})
+				ms.On("Calculate", mock.Anything, joint).Return(&tax.CalculateResponse{
+					FilingStatus: tax.Joint,
+					Tax:          77000 * money.Baht,
+					TaxLevel:     toTaxLevels(0.0, 35000.0, 42000.0, 0.0, 0.0),
+					Filing: &tax.FilingComparison{
+						Joint:    tax.FilingOption{Status: tax.Joint, Tax: 77000 * money.Baht, TotalTax: 77000 * money.Baht},
+						Separate: tax.FilingOption{Status: tax.Separate, Tax: 38000 * money.Baht, Refund: 500 * money.Baht, TotalTax: 38500 * money.Baht},
+						Cheaper:  tax.Separate,
+					},
+				}, nil)
+			},
+			contentType: constants.APPLICATION_JSON,
+			request: CalculationsRequest{
+				TotalIncome:  pointerTo(600000.0),
+				FilingStatus: "joint",
+				Spouse:       &Spouse{TotalIncome: 400000 * money.Baht},
+			},
+			expected: CalculationsResponse{
+				FilingStatus: "joint",
+				Tax:          77000 * money.Baht,
+				TaxLevel:     []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 35000 * money.Baht}, {"500,000-1,000,000", 42000 * money.Baht}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
+				Filing: &FilingOptions{
+					Joint:    FilingTax{FilingStatus: "joint", Tax: 77000 * money.Baht, TotalTax: 77000 * money.Baht},
+					Separate: FilingTax{FilingStatus: "separate", Tax: 38000 * money.Baht, TaxRefund: pointerTo(500.0), TotalTax: 38500 * money.Baht},
+					Cheaper:  "separate",
+				},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Dividends taxed at source",
+			mockBehavior: func(ms *tax.MockService) {
+				withheld := mock.MatchedBy(func(req tax.CalculateRequest) bool {
+					return len(req.Incomes) == 1 && req.Incomes[0].Type == tax.Dividend && req.Incomes[0].FinalWithholding
+				})
+				ms.On("Calculate", mock.Anything, withheld).Return(&tax.CalculateResponse{
+					Refund:   1000 * money.Baht,
+					TaxLevel: toTaxLevels(0.0, 9000.0, 0.0, 0.0, 0.0),
+					FinalWithholding: &tax.FinalWithholdingComparison{
+						Income:   100000 * money.Baht,
+						Withheld: 10000 * money.Baht,
+						Included: tax.FinalWithholdingOption{Refund: 1000 * money.Baht, TotalTax: 9000 * money.Baht},
+						Excluded: tax.FinalWithholdingOption{TotalTax: 10000 * money.Baht},
+						Include:  true,
+					},
+				}, nil)
+			},
+			contentType: constants.APPLICATION_JSON,
+			request: CalculationsRequest{
+				TotalIncome: pointerTo(200000.0),
+				Incomes:     []Income{{IncomeType: "40(4)(b)", Amount: 100000 * money.Baht, FinalWithholding: true}},
+			},
+			expected: CalculationsResponse{
+				TaxRefund: pointerTo(1000.0),
+				TaxLevel:  []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 9000 * money.Baht}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
+				FinalWithholding: &FinalWithholdingOptions{
+					Income:   100000 * money.Baht,
+					Withheld: 10000 * money.Baht,
+					Included: FinalWithholdingTax{TaxRefund: pointerTo(1000.0), TotalTax: 9000 * money.Baht},
+					Excluded: FinalWithholdingTax{TotalTax: 10000 * money.Baht},
+					Include:  true,
+				},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Dividend tax credit",
+			mockBehavior: func(ms *tax.MockService) {
+				withRate := mock.MatchedBy(func(req tax.CalculateRequest) bool {
+					return len(req.Incomes) == 1 && req.Incomes[0].CorporateRate == 0.20
+				})
+				ms.On("Calculate", mock.Anything, withRate).Return(&tax.CalculateResponse{
+					Refund:         11000 * money.Baht,
+					TaxLevel:       toTaxLevels(0.0, 9000.0, 0.0, 0.0, 0.0),
+					Expenses:       []tax.IncomeExpense{{Income: tax.Income{Type: tax.Dividend, Amount: 100000 * money.Baht}}},
+					DividendCredit: 20000 * money.Baht,
+				}, nil)
+			},
+			contentType: constants.APPLICATION_JSON,
+			request: CalculationsRequest{
+				TotalIncome: pointerTo(200000.0),
+				Incomes:     []Income{{IncomeType: "40(4)(b)", Amount: 80000 * money.Baht, CorporateRate: 0.20}},
+			},
+			expected: CalculationsResponse{
+				TaxRefund:      pointerTo(11000.0),
+				TaxLevel:       []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 9000 * money.Baht}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
+				Expenses:       []Expense{{IncomeType: "40(4)(b)", Income: 100000 * money.Baht}},
+				DividendCredit: 20000 * money.Baht,
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Foreign tax credit of a resident",
+			mockBehavior: func(ms *tax.MockService) {
+				withForeignTax := mock.MatchedBy(func(req tax.CalculateRequest) bool {
+					return req.Residency == tax.Resident && len(req.Incomes) == 1 && req.Incomes[0].Foreign && req.Incomes[0].ForeignTax == 30000*money.Baht
+				})
+				ms.On("Calculate", mock.Anything, withForeignTax).Return(&tax.CalculateResponse{
+					Residency:        tax.Resident,
+					Tax:              money.FromBaht(19333.33),
+					TaxLevel:         toTaxLevels(0.0, 29000.0, 0.0, 0.0, 0.0),
+					ForeignTaxCredit: money.FromBaht(9666.67),
+				}, nil)
+			},
+			contentType: constants.APPLICATION_JSON,
+			request: CalculationsRequest{
+				Residency: "resident",
+				Incomes:   []Income{{IncomeType: "40(1)", Amount: 600000 * money.Baht, Foreign: true, ForeignTax: 30000 * money.Baht}},
+			},
+			expected: CalculationsResponse{
+				Residency:        "resident",
+				Tax:              money.FromBaht(19333.33),
+				TaxLevel:         []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 29000 * money.Baht}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
+				ForeignTaxCredit: money.FromBaht(9666.67),
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Non-resident claims k-receipt",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(nil, tax.ErrAllowanceNotForNonResident)
+			},
+			contentType: constants.APPLICATION_JSON,
+			request: CalculationsRequest{
+				TotalIncome: pointerTo(500000.0),
+				Residency:   "non-resident",
+				Allowances:  []Allowance{{AllowanceType: "k-receipt", Amount: 20000 * money.Baht}},
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Salary and withholding in dollars",
+			mockBehavior: func(ms *tax.MockService) {
+				inDollars := mock.MatchedBy(func(req tax.CalculateRequest) bool {
+					return len(req.Incomes) == 1 && req.Incomes[0].Currency == "USD" &&
+						len(req.Withholdings) == 1 && req.Withholdings[0] == tax.Withholding{Amount: 1000 * money.Baht, Currency: "USD"}
+				})
+				ms.On("Calculate", mock.Anything, inDollars).Return(&tax.CalculateResponse{
+					Tax:      7000 * money.Baht,
+					TaxLevel: toTaxLevels(0.0, 35000.0, 7500.0, 0.0, 0.0),
+					Conversions: []tax.Conversion{
+						{Item: tax.ConvertedIncome, IncomeType: tax.Salary, Currency: "USD", Amount: 20000 * money.Baht, Rate: 35.5, Converted: 710000 * money.Baht},
+						{Item: tax.ConvertedWHT, Currency: "USD", Amount: 1000 * money.Baht, Rate: 35.5, Converted: 35500 * money.Baht},
+					},
+				}, nil)
+			},
+			contentType: constants.APPLICATION_JSON,
+			request: CalculationsRequest{
+				Incomes:      []Income{{IncomeType: "40(1)", Amount: 20000 * money.Baht, Currency: "USD"}},
+				Withholdings: []WithholdingItem{{Amount: 1000 * money.Baht, Currency: "USD"}},
+			},
+			expected: CalculationsResponse{
+				Tax:      7000 * money.Baht,
+				TaxLevel: []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 35000 * money.Baht}, {"500,000-1,000,000", 7500 * money.Baht}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
+				Conversions: []CurrencyConversion{
+					{Item: "income", IncomeType: "40(1)", Currency: "USD", Amount: 20000 * money.Baht, Rate: 35.5, Converted: 710000 * money.Baht},
+					{Item: "wht", Currency: "USD", Amount: 1000 * money.Baht, Rate: 35.5, Converted: 35500 * money.Baht},
+				},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "No exchange rate for the currency",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(nil, tax.ErrNoExchangeRate)
+			},
+			contentType: constants.APPLICATION_JSON,
+			request: CalculationsRequest{
+				Incomes: []Income{{IncomeType: "40(1)", Amount: 20000 * money.Baht, Currency: "EUR"}},
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Severance taxed separately",
+			mockBehavior: func(ms *tax.MockService) {
+				withLumpSum := mock.MatchedBy(func(req tax.CalculateRequest) bool {
+					return len(req.LumpSums) == 1 && req.LumpSums[0] == tax.LumpSum{Type: tax.Severance, Amount: 1000000 * money.Baht, YearsOfService: 10}
+				})
+				ms.On("Calculate", mock.Anything, withLumpSum).Return(&tax.CalculateResponse{
+					Tax:      60500 * money.Baht,
+					TaxLevel: toTaxLevels(0.0, 29000.0, 0.0, 0.0, 0.0),
+					LumpSum: &tax.LumpSumTax{
+						Income:    1000000 * money.Baht,
+						Deduction: 535000 * money.Baht,
+						NetIncome: 465000 * money.Baht,
+						Tax:       31500 * money.Baht,
+						TaxLevel:  toTaxLevels(0.0, 31500.0, 0.0, 0.0, 0.0),
+					},
+				}, nil)
+			},
+			contentType: constants.APPLICATION_JSON,
+			request: CalculationsRequest{
+				TotalIncome: pointerTo(500000.0),
+				LumpSums:    []LumpSumPayment{{LumpSumType: "severance", Amount: 1000000 * money.Baht, YearsOfService: 10}},
+			},
+			expected: CalculationsResponse{
+				Tax:      60500 * money.Baht,
+				TaxLevel: []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 29000 * money.Baht}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
+				LumpSum: &LumpSumAssessment{
+					Income:    1000000 * money.Baht,
+					Deduction: 535000 * money.Baht,
+					NetIncome: 465000 * money.Baht,
+					Tax:       31500 * money.Baht,
+					TaxLevel:  []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 31500 * money.Baht}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
+				},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name: "Corporate rate of interest",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(nil, tax.ErrCorporateRateNotDividend)
+			},
+			contentType: constants.APPLICATION_JSON,
+			request: CalculationsRequest{
+				Incomes: []Income{{IncomeType: "40(4)(a)", Amount: 80000 * money.Baht, CorporateRate: 0.20}},
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Salary cannot be taxed at source at the final rate",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(nil, tax.ErrNotFinalWithholding)
+			},
+			contentType: constants.APPLICATION_JSON,
+			request: CalculationsRequest{
+				Incomes: []Income{{IncomeType: "40(1)", Amount: 100000 * money.Baht, FinalWithholding: true}},
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Allowance does not apply to the filing status",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(nil, tax.ErrAllowanceNotApplicable)
+			},
+			contentType: constants.APPLICATION_JSON,
+			request: CalculationsRequest{
+				TotalIncome: pointerTo(500000.0),
+				Allowances:  []Allowance{{AllowanceType: "spouse", Amount: 60000 * money.Baht}},
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Tax year not configured",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(nil, tax.ErrNoAllowances)
+			},
+			contentType: constants.APPLICATION_JSON,
+			request: CalculationsRequest{
+				TaxYear:     2023,
+				TotalIncome: pointerTo(500000.0),
+			},
+			expectedCode: http.StatusNotFound,
+		},
 		{
 			name: "Calculation service is broken",
 			mockBehavior: func(ms *tax.MockService) {
@@ -177,8 +512,8 @@ func TestCalculations(t *testing.T) {
 			contentType: constants.APPLICATION_JSON,
 			request: CalculationsRequest{
 				TotalIncome: pointerTo(1500000.0),
-				WHT:         0.0,
-				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 0.0}},
+				WHT:         0,
+				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 0}},
 			},
 			expectedCode: http.StatusInternalServerError,
 		},
@@ -191,7 +526,7 @@ func TestCalculations(t *testing.T) {
 			reqb, err := json.Marshal(tt.request)
 			assert.NoError(t, err)
 
-			req := httptest.NewRequest(http.MethodPost, "/tax/calculations", bytes.NewBuffer(reqb))
+			req := httptest.NewRequest(http.MethodPost, "/tax/calculations"+tt.query, bytes.NewBuffer(reqb))
 			req.Header.Set("Content-Type", tt.contentType)
 			req.Header.Set("Accept", tt.contentType)
 			rec := httptest.NewRecorder()
@@ -231,8 +566,8 @@ func TestCalculationRequestValidation(t *testing.T) {
 			name: "valid request",
 			request: CalculationsRequest{
 				TotalIncome: pointerTo(500000.0),
-				WHT:         50000.0,
-				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 200000.0}},
+				WHT:         50000 * money.Baht,
+				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 200000 * money.Baht}},
 			},
 			wantErr: false,
 		},
@@ -247,7 +582,7 @@ func TestCalculationRequestValidation(t *testing.T) {
 			name: "no allowances",
 			request: CalculationsRequest{
 				TotalIncome: pointerTo(500000.0),
-				WHT:         0.0,
+				WHT:         0,
 			},
 			wantErr: false,
 		},
@@ -255,17 +590,33 @@ func TestCalculationRequestValidation(t *testing.T) {
 			name: "invalid total income",
 			request: CalculationsRequest{
 				TotalIncome: pointerTo(-1),
-				WHT:         0.0,
+				WHT:         0,
 				Allowances:  []Allowance{{AllowanceType: "donation", Amount: 0}},
 			},
 			wantErr: true,
 		},
+		{
+			name: "allowance type from the allowance rules",
+			request: CalculationsRequest{
+				TotalIncome: pointerTo(500000.0),
+				Allowances:  []Allowance{{AllowanceType: "life-insurance", Amount: 10000 * money.Baht}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "personal allowance cannot be claimed",
+			request: CalculationsRequest{
+				TotalIncome: pointerTo(500000.0),
+				Allowances:  []Allowance{{AllowanceType: "personal", Amount: 10000 * money.Baht}},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid allowance type",
 			request: CalculationsRequest{
 				TotalIncome: pointerTo(500000.0),
-				WHT:         5000.0,
-				Allowances:  []Allowance{{AllowanceType: "unknown", Amount: 10000}},
+				WHT:         5000 * money.Baht,
+				Allowances:  []Allowance{{AllowanceType: "unknown", Amount: 10000 * money.Baht}},
 			},
 			wantErr: true,
 		},
@@ -273,8 +624,156 @@ func TestCalculationRequestValidation(t *testing.T) {
 			name: "invalid allowance amount",
 			request: CalculationsRequest{
 				TotalIncome: pointerTo(500000.0),
-				WHT:         5000.0,
-				Allowances:  []Allowance{{AllowanceType: "donation", Amount: -500}},
+				WHT:         5000 * money.Baht,
+				Allowances:  []Allowance{{AllowanceType: "donation", Amount: -500 * money.Baht}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid tax year",
+			request: CalculationsRequest{
+				TaxYear:     2023,
+				TotalIncome: pointerTo(500000.0),
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid tax year",
+			request: CalculationsRequest{
+				TaxYear:     2567,
+				TotalIncome: pointerTo(500000.0),
+			},
+			wantErr: true,
+		},
+		{
+			name: "incomes without total income",
+			request: CalculationsRequest{
+				Incomes: []Income{{IncomeType: "40(1)", Amount: 600000 * money.Baht}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid income type",
+			request: CalculationsRequest{
+				Incomes: []Income{{IncomeType: "40(9)", Amount: 600000 * money.Baht}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid income amount",
+			request: CalculationsRequest{
+				Incomes: []Income{{IncomeType: "40(1)", Amount: -1 * money.Baht}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "joint filing with the spouse income",
+			request: CalculationsRequest{
+				TotalIncome:  pointerTo(500000.0),
+				FilingStatus: "joint",
+				Spouse:       &Spouse{Incomes: []Income{{IncomeType: "40(1)", Amount: 400000 * money.Baht}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "corporate rate of the whole profit",
+			request: CalculationsRequest{
+				Incomes: []Income{{IncomeType: "40(4)(b)", Amount: 80000 * money.Baht, CorporateRate: 1}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "severance with years of service",
+			request: CalculationsRequest{
+				TotalIncome: pointerTo(500000.0),
+				LumpSums:    []LumpSumPayment{{LumpSumType: "severance", Amount: 1000000 * money.Baht, YearsOfService: 10}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "lump sum without years of service",
+			request: CalculationsRequest{
+				TotalIncome: pointerTo(500000.0),
+				LumpSums:    []LumpSumPayment{{LumpSumType: "severance", Amount: 1000000 * money.Baht}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "lump sum with too many years of service",
+			request: CalculationsRequest{
+				TotalIncome: pointerTo(500000.0),
+				LumpSums:    []LumpSumPayment{{LumpSumType: "severance", Amount: 1000000 * money.Baht, YearsOfService: 101}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown lump sum type",
+			request: CalculationsRequest{
+				TotalIncome: pointerTo(500000.0),
+				LumpSums:    []LumpSumPayment{{LumpSumType: "bonus", Amount: 1000000 * money.Baht, YearsOfService: 10}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "non-resident with remitted foreign income",
+			request: CalculationsRequest{
+				Residency: "non-resident",
+				Incomes:   []Income{{IncomeType: "40(1)", Amount: 200000 * money.Baht, Foreign: true, ForeignTax: 30000 * money.Baht}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown residency",
+			request: CalculationsRequest{
+				TotalIncome: pointerTo(500000.0),
+				Residency:   "tourist",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative foreign tax",
+			request: CalculationsRequest{
+				Incomes: []Income{{IncomeType: "40(1)", Amount: 200000 * money.Baht, Foreign: true, ForeignTax: -1 * money.Baht}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "income and withholding in dollars",
+			request: CalculationsRequest{
+				Incomes:      []Income{{IncomeType: "40(1)", Amount: 20000 * money.Baht, Currency: "USD"}},
+				Withholdings: []WithholdingItem{{Amount: 1000 * money.Baht, Currency: "USD"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "lower case currency",
+			request: CalculationsRequest{
+				Incomes: []Income{{IncomeType: "40(1)", Amount: 20000 * money.Baht, Currency: "usd"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative withholding in dollars",
+			request: CalculationsRequest{
+				TotalIncome:  pointerTo(500000.0),
+				Withholdings: []WithholdingItem{{Amount: -1 * money.Baht, Currency: "USD"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown filing status",
+			request: CalculationsRequest{
+				TotalIncome:  pointerTo(500000.0),
+				FilingStatus: "widowed",
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative spouse income",
+			request: CalculationsRequest{
+				TotalIncome:  pointerTo(500000.0),
+				FilingStatus: "separate",
+				Spouse:       &Spouse{TotalIncome: -1 * money.Baht},
 			},
 			wantErr: true,
 		},
@@ -288,6 +787,11 @@ func TestCalculationRequestValidation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			v := validator.New()
+			v.RegisterValidation("allowance", isClaimableAllowance)
+			v.RegisterValidation("income", isIncomeType)
+			v.RegisterValidation("filing", isFilingStatus)
+			v.RegisterValidation("lumpsum", isLumpSumType)
+			v.RegisterValidation("residency", isResidency)
 			err := v.Struct(tt.request)
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -308,16 +812,63 @@ func TestCalculationsRequestToServiceRequest(t *testing.T) {
 			name: "valid request",
 			request: CalculationsRequest{
 				TotalIncome: pointerTo(500000.0),
-				WHT:         5000.0,
+				WHT:         5000 * money.Baht,
 				Allowances: []Allowance{
-					{AllowanceType: "donation", Amount: 10000},
+					{AllowanceType: "donation", Amount: 10000 * money.Baht},
 				},
 			},
 			expected: tax.CalculateRequest{
-				Income: 500000.0,
-				WHT:    5000.0,
+				Income:  500000 * money.Baht,
+				Incomes: []tax.Income{},
+				WHT:     5000 * money.Baht,
 				Allowances: []tax.Allowance{
-					{Type: tax.AllowanceType("donation"), Amount: 10000},
+					{Type: tax.AllowanceType("donation"), Amount: 10000 * money.Baht},
+				},
+			},
+		},
+		{
+			name: "with tax year",
+			request: CalculationsRequest{
+				TaxYear:     2023,
+				TotalIncome: pointerTo(500000.0),
+			},
+			expected: tax.CalculateRequest{
+				TaxYear:    2023,
+				Income:     500000 * money.Baht,
+				Incomes:    []tax.Income{},
+				Allowances: []tax.Allowance{},
+			},
+		},
+		{
+			name: "with incomes only",
+			request: CalculationsRequest{
+				Incomes: []Income{{IncomeType: "40(1)", Amount: 600000 * money.Baht}},
+			},
+			expected: tax.CalculateRequest{
+				Incomes:    []tax.Income{{Type: tax.Salary, Amount: 600000 * money.Baht}},
+				Allowances: []tax.Allowance{},
+			},
+		},
+		{
+			name: "with the spouse filed separately",
+			request: CalculationsRequest{
+				TotalIncome:  pointerTo(500000.0),
+				FilingStatus: "separate",
+				Spouse: &Spouse{
+					Incomes:    []Income{{IncomeType: "40(1)", Amount: 400000 * money.Baht}},
+					WHT:        1000 * money.Baht,
+					Allowances: []Allowance{{AllowanceType: "k-receipt", Amount: 20000 * money.Baht}},
+				},
+			},
+			expected: tax.CalculateRequest{
+				Income:       500000 * money.Baht,
+				Incomes:      []tax.Income{},
+				Allowances:   []tax.Allowance{},
+				FilingStatus: tax.Separate,
+				Spouse: &tax.SpouseIncome{
+					Incomes:    []tax.Income{{Type: tax.Salary, Amount: 400000 * money.Baht}},
+					WHT:        1000 * money.Baht,
+					Allowances: []tax.Allowance{{Type: tax.KReceipt, Amount: 20000 * money.Baht}},
 				},
 			},
 		},
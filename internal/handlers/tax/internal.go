@@ -2,24 +2,29 @@ package tax
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/http"
 	"strconv"
+	"strings"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/ztrixack/assessment-tax/internal/modules/api"
 	"github.com/ztrixack/assessment-tax/internal/services/tax"
-	"github.com/ztrixack/assessment-tax/internal/utils/constants"
 	"github.com/ztrixack/assessment-tax/internal/utils/csv"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
 var (
-	ErrInvalidRequest = fmt.Errorf("invalid request")
-	ErrCalculateTax   = fmt.Errorf("failed to calculate tax")
-	ErrInvalidFile    = fmt.Errorf("invalid file")
-	ErrGetFileFailed  = fmt.Errorf("failed to get CSV file")
-
-	TaxLevelLabels = []string{constants.T0_150k, constants.T150k_500k, constants.T500k_1M, constants.T1M_2M, constants.T2M}
+	ErrInvalidRequest       = fmt.Errorf("invalid request")
+	ErrCalculateTax         = fmt.Errorf("failed to calculate tax")
+	ErrInvalidFile          = fmt.Errorf("invalid file")
+	ErrGetFileFailed        = fmt.Errorf("failed to get CSV file")
+	ErrInvalidTaxYear       = fmt.Errorf("invalid tax year")
+	ErrGrossUp              = fmt.Errorf("failed to gross up income")
+	ErrTaxYearNotConfigured = fmt.Errorf("tax year not configured")
 )
 
 type ErrorResponse struct {
@@ -28,9 +33,126 @@ type ErrorResponse struct {
 
 func toCalculationsResponse(r tax.CalculateResponse) CalculationsResponse {
 	return CalculationsResponse{
-		Tax:       r.Tax,
-		TaxLevel:  remapTaxLevel(TaxLevelLabels, r.TaxLevel),
-		TaxRefund: remapTaxRefund(r.Refund),
+		TaxYear:             r.TaxYear,
+		FilingStatus:        string(r.FilingStatus),
+		Residency:           string(r.Residency),
+		Tax:                 r.Tax,
+		TaxLevel:            remapTaxLevel(r.TaxLevel),
+		TaxRefund:           remapTaxRefund(r.Refund),
+		Expenses:            remapExpenses(r.Expenses),
+		TaxMethod:           string(r.Method),
+		ProgressiveTax:      r.ProgressiveTax,
+		GrossIncomeTax:      r.GrossIncomeTax,
+		DividendCredit:      r.DividendCredit,
+		ForeignTaxCredit:    r.ForeignTaxCredit,
+		ExemptForeignIncome: r.ExemptForeignIncome,
+		LumpSum:             remapLumpSum(r.LumpSum),
+		Rounding:            remapRounding(r.Rounding),
+		Filing:              remapFiling(r.Filing),
+		FinalWithholding:    remapFinalWithholding(r.FinalWithholding),
+		Conversions:         remapConversions(r.Conversions),
+		Explanation:         remapExplanation(r.Explanation),
+	}
+}
+
+func remapFiling(f *tax.FilingComparison) *FilingOptions {
+	if f == nil {
+		return nil
+	}
+
+	option := func(o tax.FilingOption) FilingTax {
+		return FilingTax{
+			FilingStatus: string(o.Status),
+			Tax:          o.Tax,
+			TaxRefund:    remapTaxRefund(o.Refund),
+			TotalTax:     o.TotalTax,
+		}
+	}
+
+	return &FilingOptions{
+		Joint:    option(f.Joint),
+		Separate: option(f.Separate),
+		Cheaper:  string(f.Cheaper),
+	}
+}
+
+func remapLumpSum(l *tax.LumpSumTax) *LumpSumAssessment {
+	if l == nil {
+		return nil
+	}
+
+	return &LumpSumAssessment{
+		Income:    l.Income,
+		Deduction: l.Deduction,
+		NetIncome: l.NetIncome,
+		Tax:       l.Tax,
+		TaxLevel:  remapTaxLevel(l.TaxLevel),
+	}
+}
+
+func remapFinalWithholding(f *tax.FinalWithholdingComparison) *FinalWithholdingOptions {
+	if f == nil {
+		return nil
+	}
+
+	option := func(o tax.FinalWithholdingOption) FinalWithholdingTax {
+		return FinalWithholdingTax{
+			Tax:       o.Tax,
+			TaxRefund: remapTaxRefund(o.Refund),
+			TotalTax:  o.TotalTax,
+		}
+	}
+
+	return &FinalWithholdingOptions{
+		Income:   f.Income,
+		Withheld: f.Withheld,
+		Included: option(f.Included),
+		Excluded: option(f.Excluded),
+		Include:  f.Include,
+	}
+}
+
+func remapRounding(policy tax.RoundingPolicy) Rounding {
+	rule := func(r money.Rounding) RoundingRule {
+		return RoundingRule{Unit: r.Unit, Mode: string(r.Mode)}
+	}
+
+	return Rounding{
+		Policy:    policy.Name,
+		NetIncome: rule(policy.NetIncome),
+		TaxLevel:  rule(policy.TaxLevel),
+		Tax:       rule(policy.Tax),
+		Refund:    rule(policy.Refund),
+	}
+}
+
+func remapExplanation(e *tax.Explanation) *CalculationExplanation {
+	if e == nil {
+		return nil
+	}
+
+	allowances := make([]AppliedAllowance, len(e.Allowances))
+	for i, a := range e.Allowances {
+		allowances[i] = AppliedAllowance{
+			AllowanceType: string(a.Type),
+			Claimed:       a.Claimed,
+			Deducted:      a.Deducted,
+			Limit:         string(a.Limit),
+			Group:         a.Group,
+		}
+	}
+
+	return &CalculationExplanation{
+		Income:           e.Income,
+		TotalExpenses:    e.TotalExpenses,
+		Allowances:       allowances,
+		TotalAllowances:  e.TotalAllowances,
+		NetIncome:        e.NetIncome,
+		TaxBeforeWHT:     e.TaxBeforeWHT,
+		WHT:              e.WHT,
+		DividendCredit:   e.DividendCredit,
+		ForeignTaxCredit: e.ForeignTaxCredit,
+		EffectiveRate:    e.EffectiveRate,
 	}
 }
 
@@ -40,8 +162,61 @@ func toErrorResponse(err error) ErrorResponse {
 	}
 }
 
-func remapTaxRefund(refund float64) *float64 {
-	if refund == 0.0 {
+// toServiceErrorResponse maps a tax service error to its status code,
+// falling back to a generic error so database failures are not exposed.
+func toServiceErrorResponse(err error, fallback error) (int, ErrorResponse) {
+	switch {
+	case errors.Is(err, tax.ErrNoAllowances), errors.Is(err, tax.ErrNoTaxBrackets), errors.Is(err, tax.ErrNoSurchargeRules):
+		return http.StatusNotFound, toErrorResponse(ErrTaxYearNotConfigured)
+
+	case errors.Is(err, tax.ErrGrossUpUnreachable),
+		errors.Is(err, tax.ErrUnsupportedGrossUpTarget),
+		errors.Is(err, tax.ErrNegativeGrossUpAmount),
+		errors.Is(err, tax.ErrUnsupportedIncomeType),
+		errors.Is(err, tax.ErrDuplicateScenario),
+		errors.Is(err, tax.ErrInvalidPayrollMonth),
+		errors.Is(err, tax.ErrDuplicatePaidMonth),
+		errors.Is(err, tax.ErrUnsupportedFilingStatus),
+		errors.Is(err, tax.ErrMissingSpouseIncome),
+		errors.Is(err, tax.ErrUnexpectedSpouseIncome),
+		errors.Is(err, tax.ErrAllowanceNotApplicable),
+		errors.Is(err, tax.ErrNotFinalWithholding),
+		errors.Is(err, tax.ErrCorporateRateNotDividend),
+		errors.Is(err, tax.ErrInvalidCorporateRate),
+		errors.Is(err, tax.ErrUnsupportedLumpSumType),
+		errors.Is(err, tax.ErrInvalidYearsOfService),
+		errors.Is(err, tax.ErrNegativeTaxDue),
+		errors.Is(err, tax.ErrMissingDeadline),
+		errors.Is(err, tax.ErrUnsupportedResidency),
+		errors.Is(err, tax.ErrAllowanceNotForNonResident),
+		errors.Is(err, tax.ErrNegativeForeignTax),
+		errors.Is(err, tax.ErrForeignTaxNotForeignIncome),
+		errors.Is(err, tax.ErrForeignIncomeTaxedAtSource),
+		errors.Is(err, tax.ErrInvalidCurrency),
+		errors.Is(err, tax.ErrNoExchangeRate):
+		return http.StatusBadRequest, toErrorResponse(err)
+	}
+
+	return http.StatusInternalServerError, toErrorResponse(fallback)
+}
+
+func remapWithholdingMonths(months []tax.PayrollMonth) []WithholdingMonth {
+	result := make([]WithholdingMonth, len(months))
+
+	for i, month := range months {
+		result[i] = WithholdingMonth{
+			Month:       month.Month,
+			Salary:      month.Salary,
+			Bonus:       month.Bonus,
+			Withholding: month.Withholding,
+		}
+	}
+
+	return result
+}
+
+func remapTaxRefund(refund money.Money) *money.Money {
+	if refund == 0 {
 		return nil
 	}
 
@@ -61,26 +236,150 @@ func remapAllowances(allowances []Allowance) []tax.Allowance {
 	return result
 }
 
-func remapTaxLevel(labels []string, levels []float64) []TaxLevel {
-	result := make([]TaxLevel, len(levels))
+func remapSpouse(spouse *Spouse) *tax.SpouseIncome {
+	if spouse == nil {
+		return nil
+	}
+
+	return &tax.SpouseIncome{
+		Income:     spouse.TotalIncome,
+		Incomes:    remapIncomes(spouse.Incomes),
+		WHT:        spouse.WHT,
+		Allowances: remapAllowances(spouse.Allowances),
+	}
+}
+
+func remapLumpSums(lumpSums []LumpSumPayment) []tax.LumpSum {
+	if len(lumpSums) == 0 {
+		return nil
+	}
+
+	result := make([]tax.LumpSum, len(lumpSums))
+	for i, l := range lumpSums {
+		result[i] = tax.LumpSum{
+			Type:           tax.LumpSumType(l.LumpSumType),
+			Amount:         l.Amount,
+			YearsOfService: l.YearsOfService,
+		}
+	}
+
+	return result
+}
 
-	if len(levels) != len(labels) {
-		labels = make([]string, len(levels))
-		for i := range labels {
-			labels[i] = fmt.Sprintf("Bucket: #%d", i+1)
+func remapIncomes(incomes []Income) []tax.Income {
+	result := make([]tax.Income, len(incomes))
+
+	for i, income := range incomes {
+		result[i] = tax.Income{
+			Type:             tax.IncomeType(income.IncomeType),
+			Amount:           income.Amount,
+			FinalWithholding: income.FinalWithholding,
+			CorporateRate:    income.CorporateRate,
+			Foreign:          income.Foreign,
+			ForeignTax:       income.ForeignTax,
+			Currency:         tax.Currency(income.Currency),
 		}
 	}
 
-	for i := range levels {
+	return result
+}
+
+func remapWithholdings(withholdings []WithholdingItem) []tax.Withholding {
+	if len(withholdings) == 0 {
+		return nil
+	}
+
+	result := make([]tax.Withholding, len(withholdings))
+	for i, w := range withholdings {
+		result[i] = tax.Withholding{
+			Amount:   w.Amount,
+			Currency: tax.Currency(w.Currency),
+		}
+	}
+
+	return result
+}
+
+func remapConversions(conversions []tax.Conversion) []CurrencyConversion {
+	if len(conversions) == 0 {
+		return nil
+	}
+
+	result := make([]CurrencyConversion, len(conversions))
+	for i, c := range conversions {
+		result[i] = CurrencyConversion{
+			Item:       string(c.Item),
+			IncomeType: string(c.IncomeType),
+			Spouse:     c.Spouse,
+			Currency:   string(c.Currency),
+			Amount:     c.Amount,
+			Rate:       c.Rate,
+			Converted:  c.Converted,
+		}
+	}
+
+	return result
+}
+
+func remapExpenses(expenses []tax.IncomeExpense) []Expense {
+	if len(expenses) == 0 {
+		return nil
+	}
+
+	result := make([]Expense, len(expenses))
+
+	for i, expense := range expenses {
+		result[i] = Expense{
+			IncomeType: string(expense.Type),
+			Income:     expense.Amount,
+			Expense:    expense.Expense,
+		}
+	}
+
+	return result
+}
+
+func remapTaxLevel(levels []tax.BracketTax) []TaxLevel {
+	result := make([]TaxLevel, len(levels))
+
+	for i, level := range levels {
 		result[i] = TaxLevel{
-			Level: labels[i],
-			Tax:   levels[i],
+			Level: toTaxLevelLabel(level.Bracket),
+			Tax:   level.Tax,
 		}
 	}
 
 	return result
 }
 
+func toTaxLevelLabel(bracket tax.Bracket) string {
+	if bracket.Unbounded() {
+		return fmt.Sprintf("%s ขึ้นไป", formatAmount(bracket.Lower+money.Baht))
+	}
+
+	return fmt.Sprintf("%s-%s", formatAmount(bracket.Lower), formatAmount(bracket.Upper))
+}
+
+func formatAmount(amount money.Money) string {
+	integer, fraction, _ := strings.Cut(amount.String(), ".")
+	fraction = strings.TrimRight(fraction, "0")
+	hasFraction := fraction != ""
+
+	var b strings.Builder
+	for i, r := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteRune(',')
+		}
+		b.WriteRune(r)
+	}
+
+	if hasFraction {
+		b.WriteString("." + fraction)
+	}
+
+	return b.String()
+}
+
 func getFileFromRequest(c api.Context) (*multipart.FileHeader, error) {
 	file, err := c.FormFile("taxFile")
 	if err != nil {
@@ -89,6 +388,54 @@ func getFileFromRequest(c api.Context) (*multipart.FileHeader, error) {
 	return file, nil
 }
 
+func getTaxYearFromRequest(c api.Context) (int, error) {
+	value := c.FormValue("taxYear")
+	if value == "" {
+		return 0, nil
+	}
+
+	taxYear, err := strconv.Atoi(value)
+	if err != nil || taxYear < 1900 || taxYear > 2100 {
+		return 0, ErrInvalidTaxYear
+	}
+
+	return taxYear, nil
+}
+
+func getExplainFromRequest(c api.Context) (bool, error) {
+	value := c.QueryParam("explain")
+	if value == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(value)
+}
+
+// isIncomeType validates an income type against the expense rules of the tax service.
+func isIncomeType(fl validator.FieldLevel) bool {
+	return tax.IsIncomeType(tax.IncomeType(fl.Field().String()))
+}
+
+// isClaimableAllowance validates an allowance type against the allowance rules of the tax service.
+func isClaimableAllowance(fl validator.FieldLevel) bool {
+	return tax.IsClaimable(tax.AllowanceType(fl.Field().String()))
+}
+
+// isFilingStatus validates a filing status against the filing statuses of the tax service.
+func isFilingStatus(fl validator.FieldLevel) bool {
+	return tax.IsFilingStatus(tax.FilingStatus(fl.Field().String()))
+}
+
+// isResidency validates a residency against the residencies of the tax service.
+func isResidency(fl validator.FieldLevel) bool {
+	return tax.IsResidency(tax.Residency(fl.Field().String()))
+}
+
+// isLumpSumType validates a lump sum type against the lump sum types of the tax service.
+func isLumpSumType(fl validator.FieldLevel) bool {
+	return tax.IsLumpSumType(tax.LumpSumType(fl.Field().String()))
+}
+
 func parseCSVFile(file *multipart.FileHeader) ([]tax.CalculateRequest, error) {
 	csvReader, fileCloser, err := csv.OpenCSV(file)
 	if err != nil {
@@ -135,7 +482,7 @@ func (h *handler) calculateTaxes(ctx context.Context, reqs []tax.CalculateReques
 	return taxes, nil
 }
 
-func toTax(income float64, r tax.CalculateResponse) Tax {
+func toTax(income money.Money, r tax.CalculateResponse) Tax {
 	result := Tax{
 		TotalIncome: income,
 		Tax:         r.Tax,
@@ -153,17 +500,17 @@ func parseTaxRecord(record []string) (interface{}, error) {
 		return nil, io.ErrUnexpectedEOF
 	}
 
-	totalIncome, err := strconv.ParseFloat(record[0], 64)
+	totalIncome, err := money.Parse(record[0])
 	if err != nil {
 		return nil, err
 	}
 
-	wht, err := strconv.ParseFloat(record[1], 64)
+	wht, err := money.Parse(record[1])
 	if err != nil {
 		return nil, err
 	}
 
-	donation, err := strconv.ParseFloat(record[2], 64)
+	donation, err := money.Parse(record[2])
 	if err != nil {
 		return nil, err
 	}
@@ -15,15 +15,27 @@ import (
 	"github.com/ztrixack/assessment-tax/internal/modules/api"
 	"github.com/ztrixack/assessment-tax/internal/modules/logger"
 	"github.com/ztrixack/assessment-tax/internal/services/tax"
-	"github.com/ztrixack/assessment-tax/internal/utils/constants"
 	"github.com/ztrixack/assessment-tax/internal/utils/csv"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
-func TestToCalculationsResponse(t *testing.T) {
-	pointerTo := func(value float64) *float64 {
-		return &value
+var defaultBrackets = []tax.Bracket{
+	{Lower: 0, Upper: 150000 * money.Baht, Rate: 0},
+	{Lower: 150000 * money.Baht, Upper: 500000 * money.Baht, Rate: 0.10},
+	{Lower: 500000 * money.Baht, Upper: 1000000 * money.Baht, Rate: 0.15},
+	{Lower: 1000000 * money.Baht, Upper: 2000000 * money.Baht, Rate: 0.20},
+	{Lower: 2000000 * money.Baht, Upper: money.Max, Rate: 0.35},
+}
+
+func toTaxLevels(taxes ...float64) []tax.BracketTax {
+	levels := make([]tax.BracketTax, len(defaultBrackets))
+	for i, bracket := range defaultBrackets {
+		levels[i] = tax.BracketTax{Bracket: bracket, Tax: money.FromBaht(taxes[i])}
 	}
+	return levels
+}
 
+func TestToCalculationsResponse(t *testing.T) {
 	tests := []struct {
 		name     string
 		input    tax.CalculateResponse
@@ -32,26 +44,75 @@ func TestToCalculationsResponse(t *testing.T) {
 		{
 			name: "no refund",
 			input: tax.CalculateResponse{
-				Tax:      100.0,
-				Refund:   0.0,
-				TaxLevel: []float64{0, 0, 0, 0, 0},
+				Tax:      100 * money.Baht,
+				Refund:   0,
+				TaxLevel: toTaxLevels(0, 0, 0, 0, 0),
 			},
 			expected: CalculationsResponse{
-				Tax:      100.0,
-				TaxLevel: []TaxLevel{{constants.T0_150k, 0}, {constants.T150k_500k, 0}, {constants.T500k_1M, 0}, {constants.T1M_2M, 0}, {constants.T2M, 0}},
+				Tax:      100 * money.Baht,
+				TaxLevel: []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 0}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
 			},
 		},
 		{
 			name: "with refund",
 			input: tax.CalculateResponse{
-				Tax:      100.0,
-				Refund:   50.0,
-				TaxLevel: []float64{0, 0, 0, 0, 0},
+				Tax:      100 * money.Baht,
+				Refund:   50 * money.Baht,
+				TaxLevel: toTaxLevels(0, 0, 0, 0, 0),
 			},
 			expected: CalculationsResponse{
-				Tax:       100.0,
+				Tax:       100 * money.Baht,
 				TaxRefund: pointerTo(50.0),
-				TaxLevel:  []TaxLevel{{constants.T0_150k, 0}, {constants.T150k_500k, 0}, {constants.T500k_1M, 0}, {constants.T1M_2M, 0}, {constants.T2M, 0}},
+				TaxLevel:  []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 0}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
+			},
+		},
+		{
+			name: "with expenses",
+			input: tax.CalculateResponse{
+				Tax:      100 * money.Baht,
+				TaxLevel: toTaxLevels(0, 0, 0, 0, 0),
+				Expenses: []tax.IncomeExpense{{Income: tax.Income{Type: tax.Salary, Amount: 600000 * money.Baht}, Expense: 100000 * money.Baht}},
+			},
+			expected: CalculationsResponse{
+				Tax:      100 * money.Baht,
+				TaxLevel: []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 0}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
+				Expenses: []Expense{{IncomeType: "40(1)", Income: 600000 * money.Baht, Expense: 100000 * money.Baht}},
+			},
+		},
+		{
+			name: "with gross income tax",
+			input: tax.CalculateResponse{
+				Tax:            5000 * money.Baht,
+				TaxLevel:       toTaxLevels(0, 0, 0, 0, 0),
+				Method:         tax.MethodGrossIncome,
+				ProgressiveTax: 0,
+				GrossIncomeTax: 5000 * money.Baht,
+			},
+			expected: CalculationsResponse{
+				Tax:            5000 * money.Baht,
+				TaxLevel:       []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 0}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
+				TaxMethod:      "gross-income",
+				ProgressiveTax: 0,
+				GrossIncomeTax: 5000 * money.Baht,
+			},
+		},
+		{
+			name: "with rounding",
+			input: tax.CalculateResponse{
+				Tax:      100 * money.Baht,
+				TaxLevel: toTaxLevels(0, 0, 0, 0, 0),
+				Rounding: tax.RevenueDepartmentRounding,
+			},
+			expected: CalculationsResponse{
+				Tax:      100 * money.Baht,
+				TaxLevel: []TaxLevel{{"0-150,000", 0}, {"150,000-500,000", 0}, {"500,000-1,000,000", 0}, {"1,000,000-2,000,000", 0}, {"2,000,001 ขึ้นไป", 0}},
+				Rounding: Rounding{
+					Policy:    "revenue-department",
+					NetIncome: RoundingRule{Unit: money.Baht, Mode: "down"},
+					TaxLevel:  RoundingRule{Unit: money.Satang, Mode: "down"},
+					Tax:       RoundingRule{Unit: money.Satang, Mode: "half-up"},
+					Refund:    RoundingRule{Unit: money.Satang, Mode: "down"},
+				},
 			},
 		},
 	}
@@ -78,25 +139,25 @@ func TestRemapAllowances(t *testing.T) {
 		{
 			name: "single element",
 			input: []Allowance{
-				{AllowanceType: "donation", Amount: 1000},
+				{AllowanceType: "donation", Amount: 1000 * money.Baht},
 			},
 			expected: []tax.Allowance{
-				{Type: tax.AllowanceType("donation"), Amount: 1000},
+				{Type: tax.AllowanceType("donation"), Amount: 1000 * money.Baht},
 			},
 		},
 		{
 			name: "multiple elements",
 			input: []Allowance{
-				{AllowanceType: "donation", Amount: 1000},
-				{AllowanceType: "donation", Amount: 100000},
-				{AllowanceType: "k-receipt", Amount: 500},
-				{AllowanceType: "unknown", Amount: 300},
+				{AllowanceType: "donation", Amount: 1000 * money.Baht},
+				{AllowanceType: "donation", Amount: 100000 * money.Baht},
+				{AllowanceType: "k-receipt", Amount: 500 * money.Baht},
+				{AllowanceType: "unknown", Amount: 300 * money.Baht},
 			},
 			expected: []tax.Allowance{
-				{Type: tax.AllowanceType("donation"), Amount: 1000},
-				{Type: tax.AllowanceType("donation"), Amount: 100000},
-				{Type: tax.AllowanceType("k-receipt"), Amount: 500},
-				{Type: tax.AllowanceType("unknown"), Amount: 300},
+				{Type: tax.AllowanceType("donation"), Amount: 1000 * money.Baht},
+				{Type: tax.AllowanceType("donation"), Amount: 100000 * money.Baht},
+				{Type: tax.AllowanceType("k-receipt"), Amount: 500 * money.Baht},
+				{Type: tax.AllowanceType("unknown"), Amount: 300 * money.Baht},
 			},
 		},
 	}
@@ -109,41 +170,75 @@ func TestRemapAllowances(t *testing.T) {
 	}
 }
 
+func TestRemapIncomes(t *testing.T) {
+	input := []Income{
+		{IncomeType: "40(1)", Amount: 600000 * money.Baht},
+		{IncomeType: "40(8)", Amount: 100000 * money.Baht},
+	}
+	expected := []tax.Income{
+		{Type: tax.Salary, Amount: 600000 * money.Baht},
+		{Type: tax.Business, Amount: 100000 * money.Baht},
+	}
+
+	assert.Equal(t, expected, remapIncomes(input))
+	assert.Equal(t, []tax.Income{}, remapIncomes(nil))
+}
+
 func TestRemapTaxLevels(t *testing.T) {
 	tests := []struct {
 		name     string
-		labels   []string
-		levels   []float64
+		levels   []tax.BracketTax
 		expected []TaxLevel
 	}{
 		{
-			name:     "matching lengths",
-			labels:   []string{constants.T0_150k, constants.T150k_500k, constants.T500k_1M},
-			levels:   []float64{10.0, 20.0, 30.0},
-			expected: []TaxLevel{{constants.T0_150k, 10.0}, {constants.T150k_500k, 20.0}, {constants.T500k_1M, 30.0}},
+			name:     "default brackets",
+			levels:   toTaxLevels(10.0, 20.0, 30.0, 40.0, 50.0),
+			expected: []TaxLevel{{"0-150,000", 10 * money.Baht}, {"150,000-500,000", 20 * money.Baht}, {"500,000-1,000,000", 30 * money.Baht}, {"1,000,000-2,000,000", 40 * money.Baht}, {"2,000,001 ขึ้นไป", 50 * money.Baht}},
 		},
 		{
-			name:     "labels fewer than levels",
-			labels:   []string{constants.T0_150k, constants.T150k_500k},
-			levels:   []float64{10.0, 20.0, 30.0},
-			expected: []TaxLevel{{"Bucket: #1", 10.0}, {"Bucket: #2", 20.0}, {"Bucket: #3", 30.0}},
+			name: "custom brackets",
+			levels: []tax.BracketTax{
+				{Bracket: tax.Bracket{Lower: 0, Upper: 100000 * money.Baht, Rate: 0}, Tax: 0},
+				{Bracket: tax.Bracket{Lower: 100000 * money.Baht, Upper: money.Max, Rate: 0.25}, Tax: 20 * money.Baht},
+			},
+			expected: []TaxLevel{{"0-100,000", 0}, {"100,001 ขึ้นไป", 20 * money.Baht}},
 		},
 		{
-			name:     "labels more than levels",
-			labels:   []string{constants.T0_150k, constants.T150k_500k, constants.T500k_1M, constants.T1M_2M},
-			levels:   []float64{10.0, 20.0, 30.0},
-			expected: []TaxLevel{{"Bucket: #1", 10.0}, {"Bucket: #2", 20.0}, {"Bucket: #3", 30.0}},
+			name:     "no levels",
+			levels:   []tax.BracketTax{},
+			expected: []TaxLevel{},
 		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			result := remapTaxLevel(tc.labels, tc.levels)
+			result := remapTaxLevel(tc.levels)
 			assert.Equal(t, tc.expected, result)
 		})
 	}
 }
 
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   money.Money
+		expected string
+	}{
+		{"zero", 0, "0"},
+		{"hundreds", 999 * money.Baht, "999"},
+		{"thousands", 150000 * money.Baht, "150,000"},
+		{"millions", 2000001 * money.Baht, "2,000,001"},
+		{"with fraction", 1234*money.Baht + 50*money.Satang, "1,234.5"},
+		{"with satang", 1234*money.Baht + 5*money.Satang, "1,234.05"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, formatAmount(tc.amount))
+		})
+	}
+}
+
 func TestGetFileFromRequest(t *testing.T) {
 	e := echo.New()
 	tests := []struct {
@@ -194,6 +289,43 @@ func TestGetFileFromRequest(t *testing.T) {
 	}
 }
 
+func TestGetTaxYearFromRequest(t *testing.T) {
+	tests := []struct {
+		name     string
+		taxYear  string
+		expected int
+		wantErr  bool
+	}{
+		{"Without tax year", "", 0, false},
+		{"With tax year", "2023", 2023, false},
+		{"Not a number", "twenty", 0, true},
+		{"Buddhist era year", "2567", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := new(bytes.Buffer)
+			writer := multipart.NewWriter(body)
+			if tt.taxYear != "" {
+				writer.WriteField("taxYear", tt.taxYear)
+			}
+			writer.Close()
+
+			req := httptest.NewRequest(http.MethodPost, "/", body)
+			req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			taxYear, err := getTaxYearFromRequest(c)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, taxYear)
+			}
+		})
+	}
+}
+
 func TestParseCSVFile(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -212,23 +344,52 @@ func TestParseCSVFile(t *testing.T) {
 			},
 			expectedResult: []tax.CalculateRequest{
 				{
-					Income:     500000.0,
-					WHT:        0.0,
-					Allowances: []tax.Allowance{{Type: "donation", Amount: 0.0}},
+					Income:     500000 * money.Baht,
+					WHT:        0,
+					Allowances: []tax.Allowance{{Type: "donation", Amount: 0}},
 				},
 				{
-					Income:     600000.0,
-					WHT:        40000.0,
-					Allowances: []tax.Allowance{{Type: "donation", Amount: 20000.0}},
+					Income:     600000 * money.Baht,
+					WHT:        40000 * money.Baht,
+					Allowances: []tax.Allowance{{Type: "donation", Amount: 20000 * money.Baht}},
 				},
 				{
-					Income:     750000.0,
-					WHT:        50000.0,
-					Allowances: []tax.Allowance{{Type: "donation", Amount: 15000.0}},
+					Income:     750000 * money.Baht,
+					WHT:        50000 * money.Baht,
+					Allowances: []tax.Allowance{{Type: "donation", Amount: 15000 * money.Baht}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Amounts in satang",
+			setup: func(t *testing.T) *multipart.FileHeader {
+				file, err := csv.MockFile("totalIncome,wht,donation\n500000.05,0.1,100.125", "taxFile")
+				if err != nil {
+					t.Error(err)
+				}
+				return file
+			},
+			expectedResult: []tax.CalculateRequest{
+				{
+					Income:     500000*money.Baht + 5*money.Satang,
+					WHT:        10 * money.Satang,
+					Allowances: []tax.Allowance{{Type: "donation", Amount: 100*money.Baht + 13*money.Satang}},
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "Amount is not a number",
+			setup: func(t *testing.T) *multipart.FileHeader {
+				file, err := csv.MockFile("totalIncome,wht,donation\n500000,abc,0", "taxFile")
+				if err != nil {
+					t.Error(err)
+				}
+				return file
+			},
+			wantErr: true,
+		},
 		{
 			name: "File format is not match",
 			setup: func(t *testing.T) *multipart.FileHeader {
@@ -268,10 +429,6 @@ func TestParseCSVFile(t *testing.T) {
 }
 
 func TestCalculateTaxes(t *testing.T) {
-	pointerTo := func(value float64) *float64 {
-		return &value
-	}
-
 	tests := []struct {
 		name          string
 		requests      []tax.CalculateRequest
@@ -282,43 +439,43 @@ func TestCalculateTaxes(t *testing.T) {
 		{
 			name: "successful calculations",
 			requests: []tax.CalculateRequest{
-				{Income: 500000.0},
-				{Income: 600000.0, WHT: 40000.0},
-				{Income: 500000.0, WHT: 50000.0, Allowances: []tax.Allowance{{Type: tax.Donation, Amount: 15000.0}}},
+				{Income: 500000 * money.Baht},
+				{Income: 600000 * money.Baht, WHT: 40000 * money.Baht},
+				{Income: 500000 * money.Baht, WHT: 50000 * money.Baht, Allowances: []tax.Allowance{{Type: tax.Donation, Amount: 15000 * money.Baht}}},
 			},
 			mockBehavior: func(ms *tax.MockService) {
-				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 29000.0}, nil).Once()
-				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 25000.0}, nil).Once()
-				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 0.0}, nil).Once()
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 29000 * money.Baht}, nil).Once()
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 25000 * money.Baht}, nil).Once()
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 0}, nil).Once()
 			},
 			expectedTaxes: []Tax{
-				{TotalIncome: 500000.0, Tax: 29000.0},
-				{TotalIncome: 600000.0, Tax: 25000.0},
-				{TotalIncome: 500000.0, Tax: 0.0},
+				{TotalIncome: 500000 * money.Baht, Tax: 29000 * money.Baht},
+				{TotalIncome: 600000 * money.Baht, Tax: 25000 * money.Baht},
+				{TotalIncome: 500000 * money.Baht, Tax: 0},
 			},
 			wantErr: false,
 		},
 		{
 			name: "successful with refund",
 			requests: []tax.CalculateRequest{
-				{Income: 600000.0, WHT: 100000.0},
+				{Income: 600000 * money.Baht, WHT: 100000 * money.Baht},
 			},
 			mockBehavior: func(ms *tax.MockService) {
-				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 0.0, Refund: 50000.0}, nil).Once()
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 0, Refund: 50000 * money.Baht}, nil).Once()
 			},
 			expectedTaxes: []Tax{
-				{TotalIncome: 600000.0, Tax: 0.0, TaxRefund: pointerTo(50000.0)},
+				{TotalIncome: 600000 * money.Baht, Tax: 0, TaxRefund: pointerTo(50000.0)},
 			},
 			wantErr: false,
 		},
 		{
 			name: "error on second calculation",
 			requests: []tax.CalculateRequest{
-				{Income: 500000.0},
-				{Income: 600000.0, WHT: 40000.0},
+				{Income: 500000 * money.Baht},
+				{Income: 600000 * money.Baht, WHT: 40000 * money.Baht},
 			},
 			mockBehavior: func(ms *tax.MockService) {
-				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 29000.0}, nil).Once()
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 29000 * money.Baht}, nil).Once()
 				ms.On("Calculate", mock.Anything, mock.Anything).Return(nil, errors.New("calculation error")).Once()
 			},
 			wantErr: true,
@@ -351,33 +508,33 @@ func TestCalculateTaxes(t *testing.T) {
 func TestToTax(t *testing.T) {
 	tests := []struct {
 		name        string
-		income      float64
+		income      money.Money
 		response    tax.CalculateResponse
 		expectedTax Tax
 	}{
 		{
 			name:   "Successfully case",
-			income: 500000.0,
+			income: 500000 * money.Baht,
 			response: tax.CalculateResponse{
-				Tax:    29000.0,
+				Tax:    29000 * money.Baht,
 				Refund: 0,
 			},
 			expectedTax: Tax{
-				TotalIncome: 500000.0,
-				Tax:         29000.0,
+				TotalIncome: 500000 * money.Baht,
+				Tax:         29000 * money.Baht,
 			},
 		},
 		{
 			name:   "Successfully case with refund",
-			income: 500000.0,
+			income: 500000 * money.Baht,
 			response: tax.CalculateResponse{
-				Tax:    0.0,
-				Refund: 1000.0,
+				Tax:    0,
+				Refund: 1000 * money.Baht,
 			},
 			expectedTax: Tax{
-				TotalIncome: 500000.0,
-				Tax:         0.0,
-				TaxRefund:   &[]float64{1000.0}[0],
+				TotalIncome: 500000 * money.Baht,
+				Tax:         0,
+				TaxRefund:   pointerTo(1000.0),
 			},
 		},
 	}
@@ -0,0 +1,94 @@
+package tax
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/tax"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+type LateFilingRequest struct {
+	TaxYear int `json:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2024"`
+	// Tax is the tax due, such as the tax of a calculation.
+	Tax      money.Money `json:"tax" validate:"min=0" example:"100000.0"`
+	Deadline time.Time   `json:"deadline" validate:"required" example:"2025-04-08T00:00:00Z"`
+	PaidAt   time.Time   `json:"paidAt" validate:"required" example:"2025-05-09T00:00:00Z"`
+}
+
+type LateFilingResponse struct {
+	TaxYear    int         `json:"taxYear" example:"2024"`
+	Tax        money.Money `json:"tax" example:"100000.0"`
+	MonthsLate int         `json:"monthsLate" example:"2"`
+	// Rate is the percentage of the tax charged for every month or part of a month late.
+	Rate      float64     `json:"rate" example:"1.5"`
+	Surcharge money.Money `json:"surcharge" example:"3000.0"`
+	Penalty   money.Money `json:"penalty" example:"200.0"`
+	Total     money.Money `json:"total" example:"103200.0"`
+}
+
+// LateFiling calculates the surcharge and penalty of paying the tax due after the deadline.
+//
+//	@summary		Calculate late filing surcharge
+//	@description	Charges the configured surcharge rate of the tax due for every month or part of a month from the deadline to the payment date, capped at the tax due.
+//	@description	The configured fixed penalty is added whenever the payment is late. The rate and the penalty are configured by the admin as the late filing rules.
+//	@tags			tax
+//	@accept			json
+//	@produce		json
+//	@param			request	body		LateFilingRequest	true	"Input request for the late filing surcharge"
+//	@success		200		{object}	LateFilingResponse	"Successfully calculated the late filing surcharge"
+//	@failure		400		{object}	ErrorResponse		"Bad request if the input validation fails"
+//	@failure		404		{object}	ErrorResponse		"Not found if the tax year is not configured"
+//	@failure		500		{object}	ErrorResponse		"Internal server error if the tax calculations service fails"
+//	@router			/tax/late-filing [post]
+func (h *handler) LateFiling(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if c.Request().Body == http.NoBody {
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	var req LateFilingRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.tax.CalculateSurcharge(ctx, req.toServiceRequest())
+	if err != nil {
+		h.log.Err(err).E("Failed to calculate late filing surcharge")
+		return c.JSON(toServiceErrorResponse(err, ErrCalculateTax))
+	}
+
+	return c.JSON(http.StatusOK, toLateFilingResponse(*res))
+}
+
+func (r *LateFilingRequest) toServiceRequest() tax.SurchargeRequest {
+	return tax.SurchargeRequest{
+		TaxYear:  r.TaxYear,
+		Tax:      r.Tax,
+		Deadline: r.Deadline,
+		PaidAt:   r.PaidAt,
+	}
+}
+
+func toLateFilingResponse(r tax.SurchargeResponse) LateFilingResponse {
+	return LateFilingResponse{
+		TaxYear:    r.TaxYear,
+		Tax:        r.Tax,
+		MonthsLate: r.MonthsLate,
+		Rate:       r.Rate,
+		Surcharge:  r.Surcharge,
+		Penalty:    r.Penalty,
+		Total:      r.Total,
+	}
+}
@@ -0,0 +1,130 @@
+package tax
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/tax"
+	"github.com/ztrixack/assessment-tax/internal/utils/constants"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestLateFiling(t *testing.T) {
+	deadline := time.Date(2025, time.April, 8, 0, 0, 0, 0, time.UTC)
+	paidAt := time.Date(2025, time.May, 9, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		mockBehavior func(*tax.MockService)
+		request      interface{}
+		expected     LateFilingResponse
+		expectedCode int
+	}{
+		{
+			name: "Paid two months late",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("CalculateSurcharge", mock.Anything, tax.SurchargeRequest{
+					TaxYear:  2024,
+					Tax:      100000 * money.Baht,
+					Deadline: deadline,
+					PaidAt:   paidAt,
+				}).Return(&tax.SurchargeResponse{
+					TaxYear:    2024,
+					Tax:        100000 * money.Baht,
+					MonthsLate: 2,
+					Rate:       1.5,
+					Surcharge:  3000 * money.Baht,
+					Penalty:    200 * money.Baht,
+					Total:      103200 * money.Baht,
+				}, nil)
+			},
+			request: LateFilingRequest{TaxYear: 2024, Tax: 100000 * money.Baht, Deadline: deadline, PaidAt: paidAt},
+			expected: LateFilingResponse{
+				TaxYear:    2024,
+				Tax:        100000 * money.Baht,
+				MonthsLate: 2,
+				Rate:       1.5,
+				Surcharge:  3000 * money.Baht,
+				Penalty:    200 * money.Baht,
+				Total:      103200 * money.Baht,
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Missing payment date",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      LateFilingRequest{Tax: 100000 * money.Baht, Deadline: deadline},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Negative tax due",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      LateFilingRequest{Tax: -money.Baht, Deadline: deadline, PaidAt: paidAt},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Invalid date",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      map[string]interface{}{"tax": 100000.0, "deadline": "2025-04-08", "paidAt": "2025-05-09"},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "No surcharge configured",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("CalculateSurcharge", mock.Anything, mock.Anything).Return(nil, tax.ErrNoSurchargeRules)
+			},
+			request:      LateFilingRequest{Tax: 100000 * money.Baht, Deadline: deadline, PaidAt: paidAt},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Calculation service is broken",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("CalculateSurcharge", mock.Anything, mock.Anything).Return(nil, errors.New("some error"))
+			},
+			request:      LateFilingRequest{Tax: 100000 * money.Baht, Deadline: deadline, PaidAt: paidAt},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			reqb, err := json.Marshal(tt.request)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/tax/late-filing", bytes.NewBuffer(reqb))
+			req.Header.Set("Content-Type", constants.APPLICATION_JSON)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+
+			log := logger.NewMockLogger()
+			ms := new(tax.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err = h.LateFiling(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result LateFilingResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
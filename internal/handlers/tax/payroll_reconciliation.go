@@ -0,0 +1,113 @@
+package tax
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/tax"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+type PayrollReconciliationRequest struct {
+	TaxYear int                `json:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2024"`
+	Months  []PaidPayrollMonth `json:"months" validate:"required,min=1,max=12,dive"`
+	// MonthlyIncome is the salary expected in every month left, the income of the last paid month when it is not set.
+	MonthlyIncome money.Money `json:"monthlyIncome" validate:"min=0" example:"50000.0"`
+	Allowances    []Allowance `json:"allowances" validate:"dive"`
+}
+
+type PaidPayrollMonth struct {
+	Month       int         `json:"month" validate:"required,min=1,max=12" example:"1"`
+	Income      money.Money `json:"income" validate:"min=0" example:"40000.0"`
+	Withholding money.Money `json:"withholding" validate:"min=0" example:"1000.0"`
+}
+
+type PayrollReconciliationResponse struct {
+	TaxYear         int                `json:"taxYear" example:"2024"`
+	IncomeToDate    money.Money        `json:"incomeToDate" example:"120000.0"`
+	WithheldToDate  money.Money        `json:"withheldToDate" example:"3000.0"`
+	ProjectedIncome money.Money        `json:"projectedIncome" example:"570000.0"`
+	ProjectedTax    money.Money        `json:"projectedTax" example:"26000.0"`
+	ExpectedToDate  money.Money        `json:"expectedToDate" example:"5473.68"`
+	OverWithheld    money.Money        `json:"overWithheld" example:"-2473.68"`
+	Remaining       []WithholdingMonth `json:"remaining"`
+	// Balance is the tax left to pay when filing, negative for a refund.
+	Balance money.Money `json:"balance" example:"0.0"`
+}
+
+// PayrollReconciliation reconciles the salary withholding to date with the projected annual tax.
+//
+//	@summary		Reconcile payroll withholding
+//	@description	Projects the annual tax from the income and withholding of every month paid to date and the monthly income expected for the rest of the tax year.
+//	@description	Returns how much more or less than the projected tax was withheld to date, and the adjusted withholding of every month left. A negative balance is refunded when filing.
+//	@tags			tax
+//	@accept			json
+//	@produce		json
+//	@param			request	body		PayrollReconciliationRequest	true	"Input request for the reconciliation"
+//	@success		200		{object}	PayrollReconciliationResponse	"Successfully reconciled the withholding to date"
+//	@failure		400		{object}	ErrorResponse					"Bad request if the input validation fails or a month is paid twice"
+//	@failure		404		{object}	ErrorResponse					"Not found if the tax year is not configured"
+//	@failure		500		{object}	ErrorResponse					"Internal server error if the tax calculations service fails"
+//	@router			/tax/payroll/reconciliation [post]
+func (h *handler) PayrollReconciliation(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if c.Request().Body == http.NoBody {
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	var req PayrollReconciliationRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.tax.ReconcileWithholding(ctx, req.toServiceRequest())
+	if err != nil {
+		h.log.Err(err).E("Failed to reconcile payroll withholding")
+		return c.JSON(toServiceErrorResponse(err, ErrCalculateTax))
+	}
+
+	return c.JSON(http.StatusOK, toPayrollReconciliationResponse(*res))
+}
+
+func (r *PayrollReconciliationRequest) toServiceRequest() tax.ReconcileRequest {
+	months := make([]tax.PaidMonth, len(r.Months))
+	for i, month := range r.Months {
+		months[i] = tax.PaidMonth{
+			Month:       month.Month,
+			Income:      month.Income,
+			Withholding: month.Withholding,
+		}
+	}
+
+	return tax.ReconcileRequest{
+		TaxYear:       r.TaxYear,
+		Months:        months,
+		MonthlyIncome: r.MonthlyIncome,
+		Allowances:    remapAllowances(r.Allowances),
+	}
+}
+
+func toPayrollReconciliationResponse(r tax.ReconcileResponse) PayrollReconciliationResponse {
+	return PayrollReconciliationResponse{
+		TaxYear:         r.TaxYear,
+		IncomeToDate:    r.IncomeToDate,
+		WithheldToDate:  r.WithheldToDate,
+		ProjectedIncome: r.ProjectedIncome,
+		ProjectedTax:    r.ProjectedTax,
+		ExpectedToDate:  r.ExpectedToDate,
+		OverWithheld:    r.OverWithheld,
+		Remaining:       remapWithholdingMonths(r.Remaining),
+		Balance:         r.Balance,
+	}
+}
@@ -0,0 +1,143 @@
+package tax
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/tax"
+	"github.com/ztrixack/assessment-tax/internal/utils/constants"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestPayrollReconciliation(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockBehavior func(*tax.MockService)
+		request      interface{}
+		expected     PayrollReconciliationResponse
+		expectedCode int
+	}{
+		{
+			name: "Reconcile the first quarter",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("ReconcileWithholding", mock.Anything, tax.ReconcileRequest{
+					Months: []tax.PaidMonth{
+						{Month: 1, Income: 40000 * money.Baht, Withholding: 1000 * money.Baht},
+						{Month: 2, Income: 40000 * money.Baht, Withholding: 1000 * money.Baht},
+					},
+					MonthlyIncome: 50000 * money.Baht,
+					Allowances:    []tax.Allowance{},
+				}).Return(&tax.ReconcileResponse{
+					TaxYear:         2024,
+					IncomeToDate:    80000 * money.Baht,
+					WithheldToDate:  2000 * money.Baht,
+					ProjectedIncome: 580000 * money.Baht,
+					ProjectedTax:    27000 * money.Baht,
+					ExpectedToDate:  money.FromBaht(3724.14),
+					OverWithheld:    money.FromBaht(-1724.14),
+					Remaining: []tax.PayrollMonth{
+						{Month: 3, Salary: 50000 * money.Baht, Withholding: 2500 * money.Baht},
+					},
+				}, nil)
+			},
+			request: PayrollReconciliationRequest{
+				Months: []PaidPayrollMonth{
+					{Month: 1, Income: 40000 * money.Baht, Withholding: 1000 * money.Baht},
+					{Month: 2, Income: 40000 * money.Baht, Withholding: 1000 * money.Baht},
+				},
+				MonthlyIncome: 50000 * money.Baht,
+			},
+			expected: PayrollReconciliationResponse{
+				TaxYear:         2024,
+				IncomeToDate:    80000 * money.Baht,
+				WithheldToDate:  2000 * money.Baht,
+				ProjectedIncome: 580000 * money.Baht,
+				ProjectedTax:    27000 * money.Baht,
+				ExpectedToDate:  money.FromBaht(3724.14),
+				OverWithheld:    money.FromBaht(-1724.14),
+				Remaining: []WithholdingMonth{
+					{Month: 3, Salary: 50000 * money.Baht, Withholding: 2500 * money.Baht},
+				},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "No paid months",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      PayrollReconciliationRequest{MonthlyIncome: 50000 * money.Baht},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Paid month out of range",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      PayrollReconciliationRequest{Months: []PaidPayrollMonth{{Month: 13, Income: 50000 * money.Baht}}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Negative withholding",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      PayrollReconciliationRequest{Months: []PaidPayrollMonth{{Month: 1, Income: 50000 * money.Baht, Withholding: -1 * money.Baht}}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Month paid twice",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("ReconcileWithholding", mock.Anything, mock.Anything).Return(nil, tax.ErrDuplicatePaidMonth)
+			},
+			request: PayrollReconciliationRequest{Months: []PaidPayrollMonth{
+				{Month: 1, Income: 50000 * money.Baht},
+				{Month: 1, Income: 50000 * money.Baht},
+			}},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Calculation service is broken",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("ReconcileWithholding", mock.Anything, mock.Anything).Return(nil, errors.New("some error"))
+			},
+			request:      PayrollReconciliationRequest{Months: []PaidPayrollMonth{{Month: 1, Income: 50000 * money.Baht}}},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			reqb, err := json.Marshal(tt.request)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/tax/payroll/reconciliation", bytes.NewBuffer(reqb))
+			req.Header.Set("Content-Type", constants.APPLICATION_JSON)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+
+			log := logger.NewMockLogger()
+			ms := new(tax.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err = h.PayrollReconciliation(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result PayrollReconciliationResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
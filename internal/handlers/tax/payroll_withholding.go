@@ -0,0 +1,100 @@
+package tax
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/tax"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+type PayrollWithholdingRequest struct {
+	TaxYear       int         `json:"taxYear" validate:"omitempty,min=1900,max=2100" example:"2024"`
+	MonthlySalary money.Money `json:"monthlySalary" validate:"min=0" example:"50000.0"`
+	// StartMonth is the first month the salary is paid in the tax year, January when it is not set.
+	StartMonth int         `json:"startMonth" validate:"min=0,max=12" example:"1"`
+	Bonus      money.Money `json:"bonus" validate:"min=0" example:"100000.0"`
+	BonusMonth int         `json:"bonusMonth" validate:"required_with=Bonus,omitempty,min=1,max=12" example:"3"`
+	Allowances []Allowance `json:"allowances" validate:"dive"`
+}
+
+type PayrollWithholdingResponse struct {
+	TaxYear      int                `json:"taxYear" example:"2024"`
+	AnnualIncome money.Money        `json:"annualIncome" example:"700000.0"`
+	AnnualTax    money.Money        `json:"annualTax" example:"41000.0"`
+	BonusTax     money.Money        `json:"bonusTax" example:"12000.0"`
+	Schedule     []WithholdingMonth `json:"schedule"`
+}
+
+type WithholdingMonth struct {
+	Month       int         `json:"month" example:"3"`
+	Salary      money.Money `json:"salary" example:"50000.0"`
+	Bonus       money.Money `json:"bonus" example:"100000.0"`
+	Withholding money.Money `json:"withholding" example:"14416.67"`
+}
+
+// PayrollWithholding calculates the monthly salary withholding of a tax year.
+//
+//	@summary		Calculate payroll withholding
+//	@description	Works out the tax withheld from a monthly salary on form PND1: the salary is annualised over the months it is paid, its expense and the allowances are deducted, and the annual tax is divided by those months.
+//	@description	The extra tax due to a bonus is withheld in full in the month the bonus is paid. The schedule covers the 12 months of the tax year and adds up to the annual tax.
+//	@tags			tax
+//	@accept			json
+//	@produce		json
+//	@param			request	body		PayrollWithholdingRequest	true	"Input request for the payroll withholding"
+//	@success		200		{object}	PayrollWithholdingResponse	"Successfully calculated the withholding of every month"
+//	@failure		400		{object}	ErrorResponse				"Bad request if the input validation fails or the bonus is paid before the start month"
+//	@failure		404		{object}	ErrorResponse				"Not found if the tax year is not configured"
+//	@failure		500		{object}	ErrorResponse				"Internal server error if the tax calculations service fails"
+//	@router			/tax/payroll/withholding [post]
+func (h *handler) PayrollWithholding(c api.Context) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	if c.Request().Body == http.NoBody {
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	var req PayrollWithholdingRequest
+	if err := c.Bind(&req); err != nil {
+		h.log.Err(err).E("Failed to bind request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	if err := c.Validate(&req); err != nil {
+		h.log.Err(err).Fields(logger.Fields{"request": req}).E("Failed to validate request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidRequest))
+	}
+
+	res, err := h.tax.CalculatePayroll(ctx, req.toServiceRequest())
+	if err != nil {
+		h.log.Err(err).E("Failed to calculate payroll withholding")
+		return c.JSON(toServiceErrorResponse(err, ErrCalculateTax))
+	}
+
+	return c.JSON(http.StatusOK, toPayrollWithholdingResponse(*res))
+}
+
+func (r *PayrollWithholdingRequest) toServiceRequest() tax.PayrollRequest {
+	return tax.PayrollRequest{
+		TaxYear:       r.TaxYear,
+		MonthlySalary: r.MonthlySalary,
+		StartMonth:    r.StartMonth,
+		Bonus:         r.Bonus,
+		BonusMonth:    r.BonusMonth,
+		Allowances:    remapAllowances(r.Allowances),
+	}
+}
+
+func toPayrollWithholdingResponse(r tax.PayrollResponse) PayrollWithholdingResponse {
+	return PayrollWithholdingResponse{
+		TaxYear:      r.TaxYear,
+		AnnualIncome: r.AnnualIncome,
+		AnnualTax:    r.AnnualTax,
+		BonusTax:     r.BonusTax,
+		Schedule:     remapWithholdingMonths(r.Schedule),
+	}
+}
@@ -0,0 +1,134 @@
+package tax
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/services/tax"
+	"github.com/ztrixack/assessment-tax/internal/utils/constants"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestPayrollWithholding(t *testing.T) {
+	tests := []struct {
+		name         string
+		mockBehavior func(*tax.MockService)
+		request      interface{}
+		expected     PayrollWithholdingResponse
+		expectedCode int
+	}{
+		{
+			name: "Withholding with a bonus month",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("CalculatePayroll", mock.Anything, tax.PayrollRequest{
+					MonthlySalary: 50000 * money.Baht,
+					Bonus:         100000 * money.Baht,
+					BonusMonth:    3,
+					Allowances:    []tax.Allowance{},
+				}).Return(&tax.PayrollResponse{
+					TaxYear:      2024,
+					AnnualIncome: 700000 * money.Baht,
+					AnnualTax:    41000 * money.Baht,
+					BonusTax:     12000 * money.Baht,
+					Schedule: []tax.PayrollMonth{
+						{Month: 1, Salary: 50000 * money.Baht, Withholding: money.FromBaht(2416.66)},
+						{Month: 3, Salary: 50000 * money.Baht, Bonus: 100000 * money.Baht, Withholding: money.FromBaht(14416.67)},
+					},
+				}, nil)
+			},
+			request: PayrollWithholdingRequest{MonthlySalary: 50000 * money.Baht, Bonus: 100000 * money.Baht, BonusMonth: 3},
+			expected: PayrollWithholdingResponse{
+				TaxYear:      2024,
+				AnnualIncome: 700000 * money.Baht,
+				AnnualTax:    41000 * money.Baht,
+				BonusTax:     12000 * money.Baht,
+				Schedule: []WithholdingMonth{
+					{Month: 1, Salary: 50000 * money.Baht, Withholding: money.FromBaht(2416.66)},
+					{Month: 3, Salary: 50000 * money.Baht, Bonus: 100000 * money.Baht, Withholding: money.FromBaht(14416.67)},
+				},
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "Bonus without a month",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      PayrollWithholdingRequest{MonthlySalary: 50000 * money.Baht, Bonus: 100000 * money.Baht},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Start month out of range",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      PayrollWithholdingRequest{MonthlySalary: 50000 * money.Baht, StartMonth: 13},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Negative start month",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      PayrollWithholdingRequest{MonthlySalary: 50000 * money.Baht, StartMonth: -1},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Negative salary",
+			mockBehavior: func(ms *tax.MockService) {},
+			request:      PayrollWithholdingRequest{MonthlySalary: -1 * money.Baht},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Bonus before the start month",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("CalculatePayroll", mock.Anything, mock.Anything).Return(nil, tax.ErrInvalidPayrollMonth)
+			},
+			request:      PayrollWithholdingRequest{MonthlySalary: 50000 * money.Baht, StartMonth: 7, Bonus: 100000 * money.Baht, BonusMonth: 3},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Calculation service is broken",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("CalculatePayroll", mock.Anything, mock.Anything).Return(nil, errors.New("some error"))
+			},
+			request:      PayrollWithholdingRequest{MonthlySalary: 50000 * money.Baht},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := api.NewEchoAPI(api.Config())
+
+			reqb, err := json.Marshal(tt.request)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/tax/payroll/withholding", bytes.NewBuffer(reqb))
+			req.Header.Set("Content-Type", constants.APPLICATION_JSON)
+			rec := httptest.NewRecorder()
+			c := server.NewContext(req, rec)
+
+			log := logger.NewMockLogger()
+			ms := new(tax.MockService)
+			h := New(log, server, ms)
+
+			tt.mockBehavior(ms)
+			err = h.PayrollWithholding(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCode, rec.Code)
+
+			if tt.expectedCode == http.StatusOK {
+				var result PayrollWithholdingResponse
+				err := json.Unmarshal(rec.Body.Bytes(), &result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			ms.AssertExpectations(t)
+		})
+	}
+}
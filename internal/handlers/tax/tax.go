@@ -13,6 +13,7 @@ type handler struct {
 
 func New(log logger.Logger, e api.API, tax tax.Servicer) *handler {
 	handler := &handler{log, tax}
+	handler.setupValidations(e)
 	handler.setupRoutes(e.GetRouter())
 	return handler
 }
@@ -20,4 +21,33 @@ func New(log logger.Logger, e api.API, tax tax.Servicer) *handler {
 func (h handler) setupRoutes(r api.Router) {
 	r.POST("/tax/calculations", h.Calculations)
 	r.POST("/tax/calculations/upload-csv", h.UploadCSV)
+	r.POST("/tax/calculations/gross-up", h.CalculationsGrossUp)
+	r.POST("/tax/calculations/compare", h.CalculationsCompare)
+	r.POST("/tax/calculations/optimize", h.CalculationsOptimize)
+	r.POST("/tax/calculations/installments", h.CalculationsInstallments)
+	r.POST("/tax/payroll/withholding", h.PayrollWithholding)
+	r.POST("/tax/payroll/reconciliation", h.PayrollReconciliation)
+	r.POST("/tax/late-filing", h.LateFiling)
+}
+
+func (h handler) setupValidations(e api.API) {
+	if err := e.RegisterValidation("allowance", isClaimableAllowance); err != nil {
+		h.log.Err(err).E("Failed to register allowance validation")
+	}
+
+	if err := e.RegisterValidation("income", isIncomeType); err != nil {
+		h.log.Err(err).E("Failed to register income validation")
+	}
+
+	if err := e.RegisterValidation("filing", isFilingStatus); err != nil {
+		h.log.Err(err).E("Failed to register filing status validation")
+	}
+
+	if err := e.RegisterValidation("lumpsum", isLumpSumType); err != nil {
+		h.log.Err(err).E("Failed to register lump sum validation")
+	}
+
+	if err := e.RegisterValidation("residency", isResidency); err != nil {
+		h.log.Err(err).E("Failed to register residency validation")
+	}
 }
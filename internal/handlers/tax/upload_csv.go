@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ztrixack/assessment-tax/internal/modules/api"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
 type UploadCSVResponse struct {
@@ -13,9 +14,9 @@ type UploadCSVResponse struct {
 }
 
 type Tax struct {
-	TotalIncome float64  `json:"totalIncome"`
-	Tax         float64  `json:"tax"`
-	TaxRefund   *float64 `json:"taxRefund,omitempty"`
+	TotalIncome money.Money  `json:"totalIncome"`
+	Tax         money.Money  `json:"tax"`
+	TaxRefund   *money.Money `json:"taxRefund,omitempty"`
 }
 
 // UploadCSV handles the uploading and processing of a CSV file
@@ -26,8 +27,10 @@ type Tax struct {
 //	@accept			multipart/form-data
 //	@produce		json
 //	@param			taxFile	formData	file				true	"Upload CSV tax file"
+//	@param			taxYear	formData	int					false	"Tax year of every record in the file, defaults to the current year"
 //	@success		200		{object}	UploadCSVResponse	"Successfully parsed tax data"
 //	@failure		400		{object}	ErrorResponse		"Unable to process the file, error in file retrieval or content"
+//	@failure		404		{object}	ErrorResponse		"Not found if the tax year is not configured"
 //	@failure		500		{object}	ErrorResponse		"Internal server error, failed to read CSV header or records"
 //	@router			/tax/calculations/upload-csv [post]
 func (h *handler) UploadCSV(c api.Context) error {
@@ -40,16 +43,26 @@ func (h *handler) UploadCSV(c api.Context) error {
 		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrGetFileFailed))
 	}
 
+	taxYear, err := getTaxYearFromRequest(c)
+	if err != nil {
+		h.log.Err(err).E("Failed to get tax year from request")
+		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidTaxYear))
+	}
+
 	reqs, err := parseCSVFile(file)
 	if err != nil {
 		h.log.Err(err).E("Failed to parse CSV file")
 		return c.JSON(http.StatusBadRequest, toErrorResponse(ErrInvalidFile))
 	}
 
+	for i := range reqs {
+		reqs[i].TaxYear = taxYear
+	}
+
 	taxes, err := h.calculateTaxes(ctx, reqs)
 	if err != nil {
 		h.log.Err(err).E("Failed to calculate taxes")
-		return c.JSON(http.StatusInternalServerError, toErrorResponse(ErrCalculateTax))
+		return c.JSON(toServiceErrorResponse(err, ErrCalculateTax))
 	}
 
 	return c.JSON(http.StatusOK, UploadCSVResponse{Taxes: taxes})
@@ -13,6 +13,7 @@ import (
 	"github.com/ztrixack/assessment-tax/internal/modules/api"
 	"github.com/ztrixack/assessment-tax/internal/modules/logger"
 	"github.com/ztrixack/assessment-tax/internal/services/tax"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
 func TestUploadCSV(t *testing.T) {
@@ -20,25 +21,65 @@ func TestUploadCSV(t *testing.T) {
 		name         string
 		mockBehavior func(*tax.MockService)
 		taxFile      *multipart.FileHeader
+		taxYear      string
 		expected     UploadCSVResponse
 		expectedCode int
 	}{
 		{
 			name: "Story: EXP06",
 			mockBehavior: func(ms *tax.MockService) {
-				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 29000.0}, nil).Once()
-				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 25000.0}, nil).Once()
-				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 0.0}, nil).Once()
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 29000 * money.Baht}, nil).Once()
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 25000 * money.Baht}, nil).Once()
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(&tax.CalculateResponse{Tax: 0}, nil).Once()
 			},
 			expectedCode: http.StatusOK,
 			expected: UploadCSVResponse{
 				Taxes: []Tax{
-					{TotalIncome: 500000, Tax: 29000},
-					{TotalIncome: 600000, Tax: 25000},
-					{TotalIncome: 750000, Tax: 0},
+					{TotalIncome: 500000 * money.Baht, Tax: 29000 * money.Baht},
+					{TotalIncome: 600000 * money.Baht, Tax: 25000 * money.Baht},
+					{TotalIncome: 750000 * money.Baht, Tax: 0},
 				},
 			},
 		},
+		{
+			name: "With tax year",
+			mockBehavior: func(ms *tax.MockService) {
+				withTaxYear := mock.MatchedBy(func(req tax.CalculateRequest) bool { return req.TaxYear == 2023 })
+				ms.On("Calculate", mock.Anything, withTaxYear).Return(&tax.CalculateResponse{Tax: 29000 * money.Baht}, nil).Times(3)
+			},
+			taxYear:      "2023",
+			expectedCode: http.StatusOK,
+			expected: UploadCSVResponse{
+				Taxes: []Tax{
+					{TotalIncome: 500000 * money.Baht, Tax: 29000 * money.Baht},
+					{TotalIncome: 600000 * money.Baht, Tax: 29000 * money.Baht},
+					{TotalIncome: 750000 * money.Baht, Tax: 29000 * money.Baht},
+				},
+			},
+		},
+		{
+			name: "Invalid tax year",
+			mockBehavior: func(ms *tax.MockService) {
+				// Do nothing
+			},
+			taxYear:      "twenty",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name: "Tax year not configured",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(nil, tax.ErrNoAllowances).Once()
+			},
+			taxYear:      "2016",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name: "Calculation service is broken",
+			mockBehavior: func(ms *tax.MockService) {
+				ms.On("Calculate", mock.Anything, mock.Anything).Return(nil, assert.AnError).Once()
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
 	}
 
 	for _, tt := range tests {
@@ -49,6 +90,9 @@ func TestUploadCSV(t *testing.T) {
 			writer := multipart.NewWriter(body)
 			fileField, _ := writer.CreateFormFile("taxFile", "taxes.csv")
 			fileField.Write([]byte("totalIncome,wht,donation\n500000,0,0\n600000,40000,20000\n750000,50000,15000"))
+			if tt.taxYear != "" {
+				writer.WriteField("taxYear", tt.taxYear)
+			}
 			writer.Close()
 
 			req := httptest.NewRequest(http.MethodPost, "/tax/calculations/upload-csv", body)
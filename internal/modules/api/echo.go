@@ -17,17 +17,20 @@ import (
 var _ API = (*echoAPI)(nil)
 
 type echoAPI struct {
-	config *config
-	router *echo.Echo
+	config    *config
+	router    *echo.Echo
+	validator *CustomValidator
 }
 
 func NewEchoAPI(c *config) *echoAPI {
 	e := echo.New()
 	e.Logger.SetOutput(io.Discard)
-	e.Validator = &CustomValidator{validator: validator.New()}
+	v := &CustomValidator{validator: validator.New()}
+	e.Validator = v
 	server := &echoAPI{
-		config: c,
-		router: e,
+		config:    c,
+		router:    e,
+		validator: v,
 	}
 	return server
 }
@@ -76,3 +79,7 @@ func (s *echoAPI) Use(middleware ...echo.MiddlewareFunc) {
 func (s *echoAPI) NewContext(r *http.Request, w http.ResponseWriter) echo.Context {
 	return s.router.NewContext(r, w)
 }
+
+func (s *echoAPI) RegisterValidation(tag string, fn validator.Func) error {
+	return s.validator.RegisterValidation(tag, fn)
+}
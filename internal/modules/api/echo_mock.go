@@ -4,6 +4,7 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 )
 
@@ -47,3 +48,7 @@ func (s *echoMockAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 func (s *echoMockAPI) NewContext(r *http.Request, w http.ResponseWriter) echo.Context {
 	return s.router.NewContext(r, w)
 }
+
+func (s *echoMockAPI) RegisterValidation(tag string, fn validator.Func) error {
+	return nil
+}
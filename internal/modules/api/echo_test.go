@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 )
 
@@ -54,6 +55,29 @@ func TestEchoAPIRouting(t *testing.T) {
 	}
 }
 
+func TestEchoAPIRegisterValidation(t *testing.T) {
+	api := setup()
+	defer api.Close()
+
+	err := api.RegisterValidation("even", func(fl validator.FieldLevel) bool {
+		return fl.Field().Int()%2 == 0
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error registering validation: %v", err)
+	}
+
+	type request struct {
+		Value int `validate:"even"`
+	}
+
+	if err := api.router.Validator.Validate(request{Value: 2}); err != nil {
+		t.Errorf("Expected an even value to be valid; got %v", err)
+	}
+	if err := api.router.Validator.Validate(request{Value: 3}); err == nil {
+		t.Errorf("Expected an odd value to be invalid")
+	}
+}
+
 func TestEchoAPINotify(t *testing.T) {
 	server := setup()
 	defer server.Close()
@@ -14,6 +14,7 @@ type API interface {
 	GetRouter() Router
 	Use(middleware ...echo.MiddlewareFunc)
 	NewContext(*http.Request, http.ResponseWriter) echo.Context
+	RegisterValidation(tag string, fn validator.Func) error
 }
 
 type Router interface {
@@ -35,3 +36,8 @@ type CustomValidator struct {
 func (cv *CustomValidator) Validate(i interface{}) error {
 	return cv.validator.Struct(i)
 }
+
+// RegisterValidation adds a validation rule usable through its tag
+func (cv *CustomValidator) RegisterValidation(tag string, fn validator.Func) error {
+	return cv.validator.RegisterValidation(tag, fn)
+}
@@ -1,10 +1,14 @@
 package middlewares
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
 )
 
 func TestIsValidCredentials(t *testing.T) {
@@ -51,3 +55,50 @@ func TestSecurePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestBasicAuth(t *testing.T) {
+	os.Setenv("ADMIN_USERNAME", "adminTax")
+	os.Setenv("ADMIN_PASSWORD", "admin!")
+	defer func() {
+		os.Unsetenv("ADMIN_USERNAME")
+		os.Unsetenv("ADMIN_PASSWORD")
+	}()
+
+	tests := []struct {
+		name         string
+		username     string
+		password     string
+		wantErr      error
+		wantUsername string
+	}{
+		{"valid credentials", "adminTax", "admin!", nil, "adminTax"},
+		{"invalid credentials", "adminTax", "invalidPassword", echo.ErrUnauthorized, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.SetBasicAuth(tt.username, tt.password)
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			var username string
+			next := func(c echo.Context) error {
+				username = Username(c)
+				return nil
+			}
+
+			err := BasicAuth(logger.NewMockLogger())(next)(c)
+
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantUsername, username)
+		})
+	}
+}
+
+func TestUsername(t *testing.T) {
+	c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	assert.Equal(t, "", Username(c))
+
+	c.Set(UsernameKey, "adminTax")
+	assert.Equal(t, "adminTax", Username(c))
+}
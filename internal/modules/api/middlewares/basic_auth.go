@@ -7,6 +7,9 @@ import (
 	"github.com/ztrixack/assessment-tax/internal/modules/logger"
 )
 
+// UsernameKey is the context key holding the username of an authenticated admin.
+const UsernameKey = "username"
+
 func BasicAuth(log logger.Logger) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -16,11 +19,18 @@ func BasicAuth(log logger.Logger) echo.MiddlewareFunc {
 				return echo.ErrUnauthorized
 			}
 
+			c.Set(UsernameKey, username)
 			return next(c)
 		}
 	}
 }
 
+// Username returns the username of the admin authenticated by BasicAuth, or an empty string.
+func Username(c echo.Context) string {
+	username, _ := c.Get(UsernameKey).(string)
+	return username
+}
+
 func isValidCredentials(username, password string) bool {
 	if username != os.Getenv("ADMIN_USERNAME") || password != os.Getenv("ADMIN_PASSWORD") {
 		return false
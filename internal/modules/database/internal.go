@@ -3,14 +3,69 @@ package database
 import "database/sql"
 
 type Database interface {
+	Executor
 	Config() config
 	Close() error
+	Transaction(fn func(tx Executor) error) error
+}
+
+type Executor interface {
 	Query(query string, args ...interface{}) (*sql.Rows, error)
 	QueryOne(query string, args ...interface{}) (*sql.Row, error)
 	Execute(query string, args ...interface{}) (sql.Result, error)
 }
 
-func queryOne(db *sql.DB, query string, args ...interface{}) (*sql.Row, error) {
+type preparer interface {
+	Prepare(query string) (*sql.Stmt, error)
+}
+
+var _ Executor = (*txExecutor)(nil)
+
+type txExecutor struct {
+	tx *sql.Tx
+}
+
+// Query leaves the statement open, as closing a statement of a transaction closes it on the connection at once,
+// before the rows are read. The transaction closes its statements when it is committed or rolled back.
+func (t *txExecutor) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	stmt, err := t.tx.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return stmt.Query(args...)
+}
+
+// QueryOne leaves the statement open until the transaction ends, as Query does.
+func (t *txExecutor) QueryOne(query string, args ...interface{}) (*sql.Row, error) {
+	stmt, err := t.tx.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return stmt.QueryRow(args...), nil
+}
+
+func (t *txExecutor) Execute(query string, args ...interface{}) (sql.Result, error) {
+	return execute(t.tx, query, args...)
+}
+
+// transaction runs fn in a transaction that is committed when fn succeeds and rolled back otherwise.
+func transaction(db *sql.DB, fn func(tx Executor) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if err := fn(&txExecutor{tx}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func queryOne(db preparer, query string, args ...interface{}) (*sql.Row, error) {
 	stmt, err := db.Prepare(query)
 	if err != nil {
 		return nil, err
@@ -21,7 +76,7 @@ func queryOne(db *sql.DB, query string, args ...interface{}) (*sql.Row, error) {
 	return row, nil
 }
 
-func query(db *sql.DB, query string, args ...interface{}) (*sql.Rows, error) {
+func query(db preparer, query string, args ...interface{}) (*sql.Rows, error) {
 	stmt, err := db.Prepare(query)
 	if err != nil {
 		return nil, err
@@ -32,7 +87,7 @@ func query(db *sql.DB, query string, args ...interface{}) (*sql.Rows, error) {
 	return rows, err
 }
 
-func execute(db *sql.DB, query string, args ...interface{}) (sql.Result, error) {
+func execute(db preparer, query string, args ...interface{}) (sql.Result, error) {
 	stmt, err := db.Prepare(query)
 	if err != nil {
 		return nil, err
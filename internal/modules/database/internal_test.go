@@ -209,3 +209,98 @@ func TestExecute(t *testing.T) {
 		})
 	}
 }
+
+func TestTransaction(t *testing.T) {
+	tests := []struct {
+		name          string
+		fn            func(tx Executor) error
+		mockBehaviour func(mock sqlmock.Sqlmock)
+		wantErr       bool
+	}{
+		{
+			name: "Successful transaction",
+			fn: func(tx Executor) error {
+				_, err := tx.Execute("UPDATE users SET username = ? WHERE id = ?", "john", 1)
+				return err
+			},
+			mockBehaviour: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectPrepare("UPDATE users SET username = \\? WHERE id = \\?").
+					ExpectExec().WithArgs("john", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Rollback on failure",
+			fn: func(tx Executor) error {
+				_, err := tx.Execute("UPDATE users SET username = ? WHERE id = ?", "john", 1)
+				return err
+			},
+			mockBehaviour: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectPrepare("UPDATE users SET username = \\? WHERE id = \\?").
+					ExpectExec().WithArgs("john", 1).WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Queries run in the transaction",
+			fn: func(tx Executor) error {
+				row, err := tx.QueryOne("SELECT id FROM users WHERE username = ?", "john")
+				if err != nil {
+					return err
+				}
+
+				var id int
+				if err := row.Scan(&id); err != nil {
+					return err
+				}
+
+				rows, err := tx.Query("SELECT id FROM users")
+				if err != nil {
+					return err
+				}
+				return rows.Close()
+			},
+			mockBehaviour: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectPrepare("SELECT id FROM users WHERE username = \\?").
+					ExpectQuery().WithArgs("john").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectPrepare("SELECT id FROM users").
+					ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "Begin error",
+			fn: func(tx Executor) error {
+				return nil
+			},
+			mockBehaviour: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin().WillReturnError(sql.ErrConnDone)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := setup(t)
+			defer db.Close()
+
+			tt.mockBehaviour(mock)
+			err := transaction(db, tt.fn)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
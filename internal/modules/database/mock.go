@@ -41,3 +41,7 @@ func (p *sqlMockDB) QueryOne(query string, args ...interface{}) (*sql.Row, error
 func (p *sqlMockDB) Execute(query string, args ...interface{}) (sql.Result, error) {
 	return execute(p.db, query, args...)
 }
+
+func (p *sqlMockDB) Transaction(fn func(tx Executor) error) error {
+	return transaction(p.db, fn)
+}
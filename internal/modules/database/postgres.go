@@ -45,3 +45,7 @@ func (p *postgresDB) QueryOne(query string, args ...interface{}) (*sql.Row, erro
 func (p *postgresDB) Execute(query string, args ...interface{}) (sql.Result, error) {
 	return execute(p.db, query, args...)
 }
+
+func (p *postgresDB) Transaction(fn func(tx Executor) error) error {
+	return transaction(p.db, fn)
+}
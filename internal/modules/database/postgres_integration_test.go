@@ -25,3 +25,58 @@ func TestNewPostgresDB(t *testing.T) {
 		})
 	}
 }
+
+func TestPostgresTransaction(t *testing.T) {
+	db, err := NewPostgresDB(&config{DatabaseURL: "host=postgres-test port=5432 user=test password=test dbname=testdb sslmode=disable"})
+	if err != nil {
+		t.Fatalf("failed to connect to the database: %v", err)
+	}
+	defer db.Close()
+
+	err = db.Transaction(func(tx Executor) error {
+		if _, err := tx.Execute("CREATE TEMPORARY TABLE transaction_test (id SERIAL PRIMARY KEY, name TEXT NOT NULL) ON COMMIT DROP"); err != nil {
+			return err
+		}
+
+		row, err := tx.QueryOne("INSERT INTO transaction_test (name) VALUES ($1) RETURNING id", "first")
+		if err != nil {
+			return err
+		}
+
+		var id int
+		if err := row.Scan(&id); err != nil {
+			return err
+		}
+
+		if _, err := tx.Execute("INSERT INTO transaction_test (name) VALUES ($1)", "second"); err != nil {
+			return err
+		}
+
+		rows, err := tx.Query("SELECT id, name FROM transaction_test ORDER BY id")
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		var names []string
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&id, &name); err != nil {
+				return err
+			}
+			names = append(names, name)
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		if len(names) != 2 {
+			t.Errorf("expected 2 rows read in the transaction but got %v", names)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Errorf("expected the transaction to commit but got '%v'", err)
+	}
+}
@@ -0,0 +1,66 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/database"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+)
+
+type CancelScheduledDeductionRequest struct {
+	ID        int64  `json:"id"`
+	ChangedBy string `json:"changedBy"`
+}
+
+// CancelScheduledDeduction withdraws a deduction that has not taken effect yet and records the change in the audit trail.
+func (s *service) CancelScheduledDeduction(ctx context.Context, request CancelScheduledDeductionRequest) (*ScheduledDeduction, error) {
+	var scheduled ScheduledDeduction
+	err := s.db.Transaction(func(tx database.Executor) error {
+		row, err := tx.QueryOne("SELECT id, tax_year, type, amount, effective_from FROM deductions WHERE id = $1 AND effective_from > $2 AND deleted_at IS NULL", request.ID, time.Now())
+		if err != nil {
+			return err
+		}
+
+		err = row.Scan(&scheduled.ID, &scheduled.TaxYear, &scheduled.Type, &scheduled.Amount, &scheduled.EffectiveFrom)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrScheduleNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Execute("UPDATE deductions SET deleted_at = NOW() WHERE id = $1", scheduled.ID); err != nil {
+			return err
+		}
+
+		// The amount active at the effective date once the scheduled one is gone
+		allowances, err := getAllowances(tx, scheduled.TaxYear, scheduled.EffectiveFrom)
+		if err != nil && !errors.Is(err, ErrNoAllowances) {
+			return err
+		}
+
+		return recordDeductionChange(tx, DeductionChange{
+			TaxYear:   scheduled.TaxYear,
+			Type:      scheduled.Type,
+			Action:    ActionCancel,
+			OldAmount: scheduled.Amount,
+			NewAmount: allowances[scheduled.Type],
+			ChangedBy: request.ChangedBy,
+		})
+	})
+
+	if errors.Is(err, ErrScheduleNotFound) {
+		s.log.Fields(logger.Fields{"id": request.ID}).E("No pending scheduled deduction to cancel")
+		return nil, err
+	}
+
+	if err != nil {
+		s.log.Err(err).Fields(logger.Fields{"id": request.ID}).E("Failed to cancel scheduled deduction in database")
+		return nil, ErrCancelDatabase
+	}
+
+	return &scheduled, nil
+}
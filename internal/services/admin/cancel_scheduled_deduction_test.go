@@ -0,0 +1,97 @@
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestCancelScheduledDeduction(t *testing.T) {
+	s, mock, err := setup()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer s.db.Close()
+
+	effectiveFrom := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "tax_year", "type", "amount", "effective_from"}
+
+	tests := []struct {
+		name          string
+		request       CancelScheduledDeductionRequest
+		mockBehaviour func()
+		expected      *ScheduledDeduction
+		expectedError error
+	}{
+		{
+			name:    "Successful to cancel",
+			request: CancelScheduledDeductionRequest{ID: 7, ChangedBy: "adminTax"},
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mock.ExpectPrepare("SELECT id, tax_year, type, amount, effective_from FROM deductions WHERE id = \\$1 AND effective_from > \\$2 AND deleted_at IS NULL").
+					ExpectQuery().
+					WithArgs(7, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(7, 2030, "personal", 70000, effectiveFrom))
+				mock.ExpectPrepare("UPDATE deductions SET deleted_at = NOW\\(\\) WHERE id = \\$1").
+					ExpectExec().
+					WithArgs(7).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").
+					ExpectQuery().
+					WithArgs(2030, effectiveFrom).
+					WillReturnRows(sqlmock.NewRows([]string{"type", "amount"}).AddRow("personal", 60000))
+				mock.ExpectPrepare("INSERT INTO deduction_audits").
+					ExpectExec().
+					WithArgs(2030, Personal, ActionCancel, 70000*money.Baht, 60000*money.Baht, "adminTax").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			expected: &ScheduledDeduction{ID: 7, TaxYear: 2030, Type: Personal, Amount: 70000 * money.Baht, EffectiveFrom: effectiveFrom},
+		},
+		{
+			name:    "Not pending",
+			request: CancelScheduledDeductionRequest{ID: 8},
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mock.ExpectPrepare("SELECT id, tax_year, type, amount, effective_from FROM deductions WHERE id = \\$1").
+					ExpectQuery().
+					WithArgs(8, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrScheduleNotFound,
+		},
+		{
+			name:    "Database error",
+			request: CancelScheduledDeductionRequest{ID: 7},
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mock.ExpectPrepare("SELECT id, tax_year, type, amount, effective_from FROM deductions WHERE id = \\$1").
+					ExpectQuery().
+					WithArgs(7, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(7, 2030, "personal", 70000, effectiveFrom))
+				mock.ExpectPrepare("UPDATE deductions").
+					ExpectExec().
+					WillReturnError(assert.AnError)
+				mock.ExpectRollback()
+			},
+			expectedError: ErrCancelDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour()
+
+			result, err := s.CancelScheduledDeduction(context.Background(), tt.request)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expected, result)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/utils/taxyear"
+)
+
+func (s *service) GetDeduction(ctx context.Context, request DeductionRequest) (*Deduction, error) {
+	rule, ok := findDeductionRule(request.Type)
+	if !ok {
+		s.log.Fields(logger.Fields{"type": request.Type}).E("Invalid request to get %s deduction", request.Type)
+		return nil, ErrInvalidDeductionType
+	}
+
+	taxYear := taxyear.Resolve(request.TaxYear)
+	allowances, err := getAllowances(s.db, taxYear, time.Now())
+	if err != nil {
+		s.log.Err(err).
+			Fields(logger.Fields{"taxYear": taxYear}).
+			E("Failed to get %s deduction from deductions table in database", request.Type)
+		if errors.Is(err, ErrNoAllowances) {
+			return nil, err
+		}
+		return nil, ErrQueryDatabase
+	}
+
+	return &Deduction{
+		DeductionRule: rule,
+		TaxYear:       taxYear,
+		Amount:        allowances[rule.Type],
+	}, nil
+}
@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestGetDeduction(t *testing.T) {
+	s, mock, err := setup()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer s.db.Close()
+
+	tests := []struct {
+		name          string
+		request       DeductionRequest
+		mockBehaviour func()
+		expected      *Deduction
+		expectedError error
+	}{
+		{
+			name:    "Successful to get donation deduction",
+			request: DeductionRequest{TaxYear: 2024, Type: Donation},
+			mockBehaviour: func() {
+				rows := sqlmock.NewRows([]string{"type", "amount"}).AddRow("donation", 8).AddRow("k-receipt", 50000).AddRow("personal", 60000)
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").
+					ExpectQuery().
+					WithArgs(2024, sqlmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			expected: &Deduction{
+				DeductionRule: DeductionRule{Type: Donation, Unit: UnitPercent, Minimum: DonationMinimum, Maximum: DonationMaximum, Default: DonationDefault},
+				TaxYear:       2024,
+				Amount:        8 * money.Baht,
+			},
+		},
+		{
+			name:    "No allowances for the tax year",
+			request: DeductionRequest{TaxYear: 2000, Type: Personal},
+			mockBehaviour: func() {
+				rows := sqlmock.NewRows([]string{"type", "amount"})
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").
+					ExpectQuery().
+					WithArgs(2000, sqlmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			expectedError: ErrTaxYearNotFound(2000),
+		},
+		{
+			name:    "Database error",
+			request: DeductionRequest{TaxYear: 2024, Type: Personal},
+			mockBehaviour: func() {
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").
+					ExpectQuery().
+					WithArgs(2024, sqlmock.AnyArg()).
+					WillReturnError(assert.AnError)
+			},
+			expectedError: ErrQueryDatabase,
+		},
+		{
+			name:    "Unknown Type",
+			request: DeductionRequest{TaxYear: 2024, Type: "unknown"},
+			mockBehaviour: func() {
+				// Do nothing
+			},
+			expectedError: ErrInvalidDeductionType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour()
+
+			result, err := s.GetDeduction(context.Background(), tt.request)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expected, result)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/utils/taxyear"
+)
+
+type LateFilingRulesRequest struct {
+	TaxYear int `json:"taxYear"`
+}
+
+// GetLateFilingRules returns the late filing rules of the latest tax year configured up to the given one.
+func (s *service) GetLateFilingRules(ctx context.Context, request LateFilingRulesRequest) (*LateFilingRules, error) {
+	taxYear := taxyear.Resolve(request.TaxYear)
+	row, err := s.db.QueryOne(`SELECT tax_year, surcharge_rate, penalty FROM late_filing_rules
+		WHERE tax_year <= $1 AND deleted_at IS NULL ORDER BY tax_year DESC LIMIT 1`, taxYear)
+
+	var rules LateFilingRules
+	if err == nil {
+		err = row.Scan(&rules.TaxYear, &rules.SurchargeRate, &rules.Penalty)
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		s.log.Fields(logger.Fields{"taxYear": taxYear}).E("No late filing rules up to the %d tax year", taxYear)
+		return nil, ErrLateFilingRulesNotFound(taxYear)
+	}
+
+	if err != nil {
+		s.log.Err(err).Fields(logger.Fields{"taxYear": taxYear}).E("Failed to get late filing rules from late_filing_rules table in database")
+		return nil, ErrQueryLateFilingRules
+	}
+
+	return &rules, nil
+}
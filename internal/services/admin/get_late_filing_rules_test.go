@@ -0,0 +1,73 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestGetLateFilingRules(t *testing.T) {
+	s, mock, err := setup()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer s.db.Close()
+
+	tests := []struct {
+		name          string
+		request       LateFilingRulesRequest
+		mockBehaviour func()
+		expected      *LateFilingRules
+		expectedError error
+	}{
+		{
+			name:    "Successful to get the rules of the latest tax year configured",
+			request: LateFilingRulesRequest{TaxYear: 2024},
+			mockBehaviour: func() {
+				rows := sqlmock.NewRows([]string{"tax_year", "surcharge_rate", "penalty"}).AddRow(2017, 1.5, 200)
+				mock.ExpectPrepare("SELECT tax_year, surcharge_rate, penalty FROM late_filing_rules").
+					ExpectQuery().
+					WithArgs(2024).
+					WillReturnRows(rows)
+			},
+			expected: &LateFilingRules{TaxYear: 2017, SurchargeRate: 1.5, Penalty: 200 * money.Baht},
+		},
+		{
+			name:    "No rules up to the tax year",
+			request: LateFilingRulesRequest{TaxYear: 2000},
+			mockBehaviour: func() {
+				mock.ExpectPrepare("SELECT tax_year, surcharge_rate, penalty FROM late_filing_rules").
+					ExpectQuery().
+					WithArgs(2000).
+					WillReturnRows(sqlmock.NewRows([]string{"tax_year", "surcharge_rate", "penalty"}))
+			},
+			expectedError: ErrLateFilingRulesNotFound(2000),
+		},
+		{
+			name:    "Database error",
+			request: LateFilingRulesRequest{TaxYear: 2024},
+			mockBehaviour: func() {
+				mock.ExpectPrepare("SELECT tax_year, surcharge_rate, penalty FROM late_filing_rules").
+					ExpectQuery().
+					WithArgs(2024).
+					WillReturnError(assert.AnError)
+			},
+			expectedError: ErrQueryLateFilingRules,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour()
+
+			result, err := s.GetLateFilingRules(context.Background(), tt.request)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expected, result)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -3,52 +3,238 @@ package admin
 import (
 	"context"
 	"fmt"
-	"unicode"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/database"
+	"github.com/ztrixack/assessment-tax/internal/utils/deductions"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
 type Servicer interface {
-	SetDeduction(ctx context.Context, request SetDeductionRequest) (float64, error)
+	ListDeductions(ctx context.Context, request ListDeductionsRequest) ([]Deduction, error)
+	GetDeduction(ctx context.Context, request DeductionRequest) (*Deduction, error)
+	SetDeduction(ctx context.Context, request SetDeductionRequest) (money.Money, error)
+	ResetDeduction(ctx context.Context, request DeductionRequest) (money.Money, error)
+	ListDeductionHistory(ctx context.Context, request ListDeductionHistoryRequest) (*DeductionHistory, error)
+	ScheduleDeduction(ctx context.Context, request ScheduleDeductionRequest) (*ScheduledDeduction, error)
+	ListScheduledDeductions(ctx context.Context, request ListScheduledDeductionsRequest) ([]ScheduledDeduction, error)
+	CancelScheduledDeduction(ctx context.Context, request CancelScheduledDeductionRequest) (*ScheduledDeduction, error)
+	ListExchangeRates(ctx context.Context, request ListExchangeRatesRequest) ([]ExchangeRate, error)
+	SetExchangeRates(ctx context.Context, request SetExchangeRatesRequest) ([]ExchangeRate, error)
+	GetLateFilingRules(ctx context.Context, request LateFilingRulesRequest) (*LateFilingRules, error)
+	SetLateFilingRules(ctx context.Context, request SetLateFilingRulesRequest) (*LateFilingRules, error)
 }
 
 type DeductionType string
 
 const (
 	Personal DeductionType = "personal"
+	Donation DeductionType = "donation"
 	KReceipt DeductionType = "k-receipt"
 
-	PersonalMinimum = 10000
-	PersonalMaximum = 100000
+	PersonalMinimum = 10000 * money.Baht
+	PersonalMaximum = 100000 * money.Baht
+	PersonalDefault = 60000 * money.Baht
+
+	DonationMinimum = 0
+	DonationMaximum = 100 * money.Baht
+	DonationDefault = 10 * money.Baht
 
 	KReceiptMinimum = 0
-	KReceiptMaximum = 100000
+	KReceiptMaximum = 100000 * money.Baht
+	KReceiptDefault = 50000 * money.Baht
+
+	SurchargeRateMinimum = 0
+	SurchargeRateMaximum = 100
+
+	LateFilingPenaltyMinimum = 0
+	LateFilingPenaltyMaximum = 2000 * money.Baht
+)
+
+// DeductionUnit tells how the amount of a deduction is read.
+type DeductionUnit string
+
+const (
+	// UnitAmount is an amount in baht.
+	UnitAmount DeductionUnit = "amount"
+	// UnitPercent is a percentage of the income left after the other deductions.
+	UnitPercent DeductionUnit = "percent"
+)
+
+// DeductionRule describes a deduction an admin can configure and the range it must stay in.
+type DeductionRule struct {
+	Type    DeductionType
+	Unit    DeductionUnit
+	Minimum money.Money
+	Maximum money.Money
+	Default money.Money
+}
+
+type Deduction struct {
+	DeductionRule
+	TaxYear int
+	Amount  money.Money
+}
+
+type DeductionRequest struct {
+	TaxYear   int           `json:"taxYear"`
+	Type      DeductionType `json:"type"`
+	ChangedBy string        `json:"changedBy"`
+}
+
+// ScheduledDeduction is an amount of a deduction that takes effect at a later time.
+type ScheduledDeduction struct {
+	ID            int64
+	TaxYear       int
+	Type          DeductionType
+	Amount        money.Money
+	EffectiveFrom time.Time
+}
+
+// ExchangeRate is the Bank of Thailand average rate of a currency over a tax year, in baht per unit.
+type ExchangeRate struct {
+	TaxYear  int
+	Currency string
+	Rate     float64
+}
+
+// LateFilingRules are the rules of paying the tax of a tax year late, configured alongside the allowances.
+type LateFilingRules struct {
+	// TaxYear is the tax year the rules were set for, which may be earlier than the one they apply to.
+	TaxYear int
+	// SurchargeRate is the percentage of the tax due charged for every month or part of a month it is paid late.
+	SurchargeRate float64
+	// Penalty is the fixed penalty of paying late.
+	Penalty money.Money
+}
+
+type DeductionAction string
+
+const (
+	ActionSet      DeductionAction = "set"
+	ActionReset    DeductionAction = "reset"
+	ActionSchedule DeductionAction = "schedule"
+	ActionCancel   DeductionAction = "cancel"
+	// ActionConvert records an amount rewritten in another unit, such as the donation converted from baht to a percentage.
+	ActionConvert DeductionAction = "convert"
 )
 
+// DeductionChange is an entry of the append-only audit trail of deduction changes.
+type DeductionChange struct {
+	ID        int64
+	TaxYear   int
+	Type      DeductionType
+	Action    DeductionAction
+	OldAmount money.Money
+	NewAmount money.Money
+	ChangedBy string
+	ChangedAt time.Time
+}
+
+// deductionRules is the registry of configurable deductions, in the order they are listed.
+var deductionRules = []DeductionRule{
+	{Type: Personal, Unit: UnitAmount, Minimum: PersonalMinimum, Maximum: PersonalMaximum, Default: PersonalDefault},
+	{Type: Donation, Unit: UnitPercent, Minimum: DonationMinimum, Maximum: DonationMaximum, Default: DonationDefault},
+	{Type: KReceipt, Unit: UnitAmount, Minimum: KReceiptMinimum, Maximum: KReceiptMaximum, Default: KReceiptDefault},
+}
+
 var (
 	ErrInvalidDeductionType = fmt.Errorf("invalid deduction type")
-	ErrLessThanLimit        = func(dtype DeductionType, value float64) error {
-		return fmt.Errorf("the %s deduction cannot be less than %f", dtype, value)
+	ErrOutOfLimit           = fmt.Errorf("deduction out of limit")
+	ErrLessThanLimit        = func(dtype DeductionType, value money.Money) error {
+		return fmt.Errorf("%w: the %s deduction cannot be less than %s", ErrOutOfLimit, dtype, value)
 	}
-	ErrMoreThanLimit = func(dtype DeductionType, value float64) error {
-		return fmt.Errorf("the %s deduction cannot be more than %f", dtype, value)
+	ErrMoreThanLimit = func(dtype DeductionType, value money.Money) error {
+		return fmt.Errorf("%w: the %s deduction cannot be more than %s", ErrOutOfLimit, dtype, value)
 	}
+	ErrQueryDatabase  = fmt.Errorf("failed to get deductions")
 	ErrUpdateDatabase = func(dtype DeductionType) error {
 		return fmt.Errorf("failed to set %s deduction", dtype)
 	}
+	ErrNoAllowances    = fmt.Errorf("no allowances configured")
+	ErrTaxYearNotFound = func(taxYear int) error {
+		return fmt.Errorf("%w up to the %d tax year", ErrNoAllowances, taxYear)
+	}
+	ErrNotInFuture      = fmt.Errorf("effective date must be in the future")
+	ErrScheduleNotFound = fmt.Errorf("scheduled deduction not found")
+	ErrCancelDatabase   = fmt.Errorf("failed to cancel scheduled deduction")
+
+	ErrNoExchangeRates     = fmt.Errorf("no exchange rates to set")
+	ErrInvalidCurrency     = fmt.Errorf("currency must be an ISO 4217 code other than THB")
+	ErrDuplicateCurrency   = fmt.Errorf("currencies must be unique")
+	ErrInvalidExchangeRate = fmt.Errorf("exchange rate must be above zero")
+	ErrUpdateExchangeRates = fmt.Errorf("failed to set exchange rates")
+
+	ErrNoLateFilingRules       = fmt.Errorf("no late filing rules configured")
+	ErrLateFilingRulesNotFound = func(taxYear int) error {
+		return fmt.Errorf("%w up to the %d tax year", ErrNoLateFilingRules, taxYear)
+	}
+	ErrInvalidSurchargeRate     = fmt.Errorf("surcharge rate must be a percentage between 0 and 100 with up to two decimals")
+	ErrInvalidLateFilingPenalty = fmt.Errorf("late filing penalty must be between 0 and %s", LateFilingPenaltyMaximum)
+	ErrQueryLateFilingRules     = fmt.Errorf("failed to get late filing rules")
+	ErrUpdateLateFilingRules    = fmt.Errorf("failed to set late filing rules")
 )
 
 func (r SetDeductionRequest) validate() error {
-	switch r.Type {
-	case Personal:
-		return limiter(Personal, r.Amount, PersonalMinimum, PersonalMaximum)
+	rule, ok := findDeductionRule(r.Type)
+	if !ok {
+		return ErrInvalidDeductionType
+	}
+
+	return limiter(r.Type, r.Amount, rule.Minimum, rule.Maximum)
+}
+
+func findDeductionRule(dtype DeductionType) (DeductionRule, bool) {
+	for _, rule := range deductionRules {
+		if rule.Type == dtype {
+			return rule, true
+		}
+	}
+
+	return DeductionRule{}, false
+}
+
+// getAllowances returns the amount of every deduction active at the given time for the latest tax year up to the given one.
+func getAllowances(db database.Executor, taxYear int, at time.Time) (map[DeductionType]money.Money, error) {
+	allowances, err := deductions.Active[DeductionType](db, taxYear, at)
+	if err != nil {
+		return nil, err
+	}
 
-	case KReceipt:
-		return limiter(KReceipt, r.Amount, KReceiptMinimum, KReceiptMaximum)
+	if len(allowances) == 0 {
+		return nil, ErrTaxYearNotFound(taxYear)
 	}
 
-	return ErrInvalidDeductionType
+	return allowances, nil
 }
 
-func limiter(dtype DeductionType, amount, lower, upper float64) error {
+// insertDeduction stores the amount of a deduction effective from the given time,
+// replacing a pending amount of the same deduction effective at the same time.
+func insertDeduction(db database.Executor, taxYear int, dtype DeductionType, amount money.Money, effectiveFrom time.Time) (int64, error) {
+	row, err := db.QueryOne(`INSERT INTO deductions (tax_year, type, amount, effective_from) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (tax_year, type, effective_from) WHERE deleted_at IS NULL DO UPDATE SET amount = EXCLUDED.amount, updated_at = NOW()
+		RETURNING id`, taxYear, dtype, amount, effectiveFrom)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func recordDeductionChange(db database.Executor, change DeductionChange) error {
+	_, err := db.Execute(
+		"INSERT INTO deduction_audits (tax_year, type, action, old_amount, new_amount, changed_by) VALUES ($1, $2, $3, $4, $5, $6)",
+		change.TaxYear, change.Type, change.Action, change.OldAmount, change.NewAmount, change.ChangedBy,
+	)
+	return err
+}
+
+func limiter(dtype DeductionType, amount, lower, upper money.Money) error {
 	if amount < lower {
 		return ErrLessThanLimit(dtype, lower)
 	}
@@ -59,16 +245,3 @@ func limiter(dtype DeductionType, amount, lower, upper float64) error {
 
 	return nil
 }
-
-func sanitizeType(dtype DeductionType) string {
-	sanitized := make([]rune, len(dtype))
-	for i, r := range dtype {
-		if unicode.IsLetter(r) || unicode.IsDigit(r) {
-			sanitized[i] = r
-		} else {
-			sanitized[i] = '_'
-		}
-	}
-
-	return string(sanitized)
-}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
 func TestValidate(t *testing.T) {
@@ -13,11 +14,14 @@ func TestValidate(t *testing.T) {
 		wantErr  bool
 		errValue error
 	}{
-		{"Valid Personal Request", SetDeductionRequest{Type: Personal, Amount: 50000}, false, nil},
-		{"Invalid Personal Request", SetDeductionRequest{Type: Personal, Amount: 1000}, true, ErrLessThanLimit(Personal, 10000)},
-		{"Valid KReceipt Request", SetDeductionRequest{Type: KReceipt, Amount: 5000}, false, nil},
-		{"Invalid KReceipt Request", SetDeductionRequest{Type: KReceipt, Amount: 1000000}, true, ErrMoreThanLimit(KReceipt, 100000)},
-		{"Unknown Type", SetDeductionRequest{Type: DeductionType("Unknown"), Amount: 10000}, true, ErrInvalidDeductionType},
+		{"Valid Personal Request", SetDeductionRequest{Type: Personal, Amount: 50000 * money.Baht}, false, nil},
+		{"Invalid Personal Request", SetDeductionRequest{Type: Personal, Amount: 1000 * money.Baht}, true, ErrLessThanLimit(Personal, 10000*money.Baht)},
+		{"Valid KReceipt Request", SetDeductionRequest{Type: KReceipt, Amount: 5000 * money.Baht}, false, nil},
+		{"Invalid KReceipt Request", SetDeductionRequest{Type: KReceipt, Amount: 1000000 * money.Baht}, true, ErrMoreThanLimit(KReceipt, 100000*money.Baht)},
+		{"Valid Donation Request", SetDeductionRequest{Type: Donation, Amount: 10 * money.Baht}, false, nil},
+		{"Invalid Donation Percentage", SetDeductionRequest{Type: Donation, Amount: 101 * money.Baht}, true, ErrMoreThanLimit(Donation, 100*money.Baht)},
+		{"Invalid Donation Request", SetDeductionRequest{Type: Donation, Amount: -1 * money.Baht}, true, ErrLessThanLimit(Donation, 0*money.Baht)},
+		{"Unknown Type", SetDeductionRequest{Type: DeductionType("Unknown"), Amount: 10000 * money.Baht}, true, ErrInvalidDeductionType},
 	}
 
 	for _, tc := range tests {
@@ -37,17 +41,18 @@ func TestLimiter(t *testing.T) {
 	tests := []struct {
 		name    string
 		dtype   DeductionType
-		amount  float64
-		lower   float64
-		upper   float64
+		amount  money.Money
+		lower   money.Money
+		upper   money.Money
 		wantErr bool
 	}{
-		{"Valid Personal Deduction", Personal, 50000, PersonalMinimum, PersonalMaximum, false},
-		{"Too Low Personal Deduction", Personal, 9999, PersonalMinimum, PersonalMaximum, true},
-		{"Too High Personal Deduction", Personal, 100001, PersonalMinimum, PersonalMaximum, true},
-		{"Valid K-Receipt Deduction", KReceipt, 50000, KReceiptMinimum, KReceiptMaximum, false},
-		{"Too Low K-Receipt Deduction", KReceipt, -1, KReceiptMinimum, KReceiptMaximum, true},
-		{"Too High K-Receipt Deduction", KReceipt, 100001, KReceiptMinimum, KReceiptMaximum, true},
+		{"Valid Personal Deduction", Personal, 50000 * money.Baht, PersonalMinimum, PersonalMaximum, false},
+		{"Too Low Personal Deduction", Personal, 9999 * money.Baht, PersonalMinimum, PersonalMaximum, true},
+		{"Too High Personal Deduction", Personal, 100001 * money.Baht, PersonalMinimum, PersonalMaximum, true},
+		{"Valid K-Receipt Deduction", KReceipt, 50000 * money.Baht, KReceiptMinimum, KReceiptMaximum, false},
+		{"Too Low K-Receipt Deduction", KReceipt, -1 * money.Baht, KReceiptMinimum, KReceiptMaximum, true},
+		{"Too High K-Receipt Deduction", KReceipt, 100001 * money.Baht, KReceiptMinimum, KReceiptMaximum, true},
+		{"K-Receipt Deduction a Satang Above Maximum", KReceipt, KReceiptMaximum + money.Satang, KReceiptMinimum, KReceiptMaximum, true},
 	}
 
 	for _, tc := range tests {
@@ -62,22 +67,30 @@ func TestLimiter(t *testing.T) {
 	}
 }
 
-func TestSanitizeType(t *testing.T) {
+func TestFindDeductionRule(t *testing.T) {
 	tests := []struct {
-		name      string
-		dtype     DeductionType
-		wantValue string
+		name     string
+		dtype    DeductionType
+		expected DeductionRule
+		found    bool
 	}{
-		{"Map personal", Personal, "personal"},
-		{"Map k-receipt", KReceipt, "k_receipt"},
-		{"Map k-receipt as string", "k-receipt", "k_receipt"},
-		{"Map anything else", "unknown", "unknown"},
-		{"Map anything else with hyphen", "un-known", "un_known"},
+		{"Personal", Personal, DeductionRule{Personal, UnitAmount, PersonalMinimum, PersonalMaximum, PersonalDefault}, true},
+		{"Donation", Donation, DeductionRule{Donation, UnitPercent, DonationMinimum, DonationMaximum, DonationDefault}, true},
+		{"K-Receipt", KReceipt, DeductionRule{KReceipt, UnitAmount, KReceiptMinimum, KReceiptMaximum, KReceiptDefault}, true},
+		{"Unknown", "unknown", DeductionRule{}, false},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			assert.Equal(t, tc.wantValue, sanitizeType(tc.dtype))
+			rule, found := findDeductionRule(tc.dtype)
+			assert.Equal(t, tc.found, found)
+			assert.Equal(t, tc.expected, rule)
 		})
 	}
 }
+
+func TestDeductionRulesHaveDefaultsWithinLimits(t *testing.T) {
+	for _, rule := range deductionRules {
+		assert.NoError(t, limiter(rule.Type, rule.Default, rule.Minimum, rule.Maximum), rule.Type)
+	}
+}
@@ -0,0 +1,123 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+)
+
+const (
+	DefaultHistoryPageSize = 20
+	MaximumHistoryPageSize = 100
+	MaximumHistoryPage     = 100000
+)
+
+type ListDeductionHistoryRequest struct {
+	TaxYear   int           `json:"taxYear"`
+	Type      DeductionType `json:"type"`
+	ChangedBy string        `json:"changedBy"`
+	From      time.Time     `json:"from"`
+	To        time.Time     `json:"to"`
+	Page      int           `json:"page"`
+	PageSize  int           `json:"pageSize"`
+}
+
+type DeductionHistory struct {
+	Changes  []DeductionChange
+	Total    int
+	Page     int
+	PageSize int
+}
+
+// ListDeductionHistory returns a page of deduction changes, newest first.
+func (s *service) ListDeductionHistory(ctx context.Context, request ListDeductionHistoryRequest) (*DeductionHistory, error) {
+	page, pageSize := request.pagination()
+	where, args := request.filter()
+
+	row, err := s.db.QueryOne("SELECT COUNT(*) FROM deduction_audits"+where, args...)
+	if err != nil {
+		s.log.Err(err).Fields(logger.Fields{"request": request}).E("Failed to count deduction history in database")
+		return nil, ErrQueryDatabase
+	}
+
+	var total int
+	if err := row.Scan(&total); err != nil {
+		s.log.Err(err).Fields(logger.Fields{"request": request}).E("Failed to count deduction history in database")
+		return nil, ErrQueryDatabase
+	}
+
+	query := fmt.Sprintf(`SELECT id, tax_year, type, action, old_amount, new_amount, changed_by, changed_at FROM deduction_audits%s
+		ORDER BY changed_at DESC, id DESC LIMIT $%d OFFSET $%d`, where, len(args)+1, len(args)+2)
+	rows, err := s.db.Query(query, append(args, pageSize, (page-1)*pageSize)...)
+	if err != nil {
+		s.log.Err(err).Fields(logger.Fields{"request": request}).E("Failed to get deduction history from database")
+		return nil, ErrQueryDatabase
+	}
+	defer rows.Close()
+
+	changes := make([]DeductionChange, 0, pageSize)
+	for rows.Next() {
+		var c DeductionChange
+		if err := rows.Scan(&c.ID, &c.TaxYear, &c.Type, &c.Action, &c.OldAmount, &c.NewAmount, &c.ChangedBy, &c.ChangedAt); err != nil {
+			s.log.Err(err).E("Failed to scan deduction history")
+			return nil, ErrQueryDatabase
+		}
+		changes = append(changes, c)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.log.Err(err).E("Failed to iterate deduction history")
+		return nil, ErrQueryDatabase
+	}
+
+	return &DeductionHistory{
+		Changes:  changes,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+func (r ListDeductionHistoryRequest) pagination() (int, int) {
+	page := min(max(r.Page, 1), MaximumHistoryPage)
+	pageSize := r.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultHistoryPageSize
+	}
+
+	return page, min(pageSize, MaximumHistoryPageSize)
+}
+
+func (r ListDeductionHistoryRequest) filter() (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if r.TaxYear != 0 {
+		where("tax_year = $%d", r.TaxYear)
+	}
+	if r.Type != "" {
+		where("type = $%d", r.Type)
+	}
+	if r.ChangedBy != "" {
+		where("changed_by = $%d", r.ChangedBy)
+	}
+	if !r.From.IsZero() {
+		where("changed_at >= $%d", r.From)
+	}
+	if !r.To.IsZero() {
+		where("changed_at < $%d", r.To)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,140 @@
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestListDeductionHistory(t *testing.T) {
+	s, mock, err := setup()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer s.db.Close()
+
+	changedAt := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "tax_year", "type", "action", "old_amount", "new_amount", "changed_by", "changed_at"}
+
+	tests := []struct {
+		name          string
+		request       ListDeductionHistoryRequest
+		mockBehaviour func()
+		expected      *DeductionHistory
+		expectedError error
+	}{
+		{
+			name:    "Successful without filters",
+			request: ListDeductionHistoryRequest{},
+			mockBehaviour: func() {
+				mock.ExpectPrepare("SELECT COUNT\\(\\*\\) FROM deduction_audits$").
+					ExpectQuery().
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				rows := sqlmock.NewRows(columns).AddRow(1, 2024, "personal", "set", 60000, 70000, "adminTax", changedAt)
+				mock.ExpectPrepare("SELECT id, tax_year, type, action, old_amount, new_amount, changed_by, changed_at FROM deduction_audits ORDER BY changed_at DESC, id DESC LIMIT \\$1 OFFSET \\$2").
+					ExpectQuery().
+					WithArgs(DefaultHistoryPageSize, 0).
+					WillReturnRows(rows)
+			},
+			expected: &DeductionHistory{
+				Changes: []DeductionChange{
+					{ID: 1, TaxYear: 2024, Type: Personal, Action: ActionSet, OldAmount: 60000 * money.Baht, NewAmount: 70000 * money.Baht, ChangedBy: "adminTax", ChangedAt: changedAt},
+				},
+				Total:    1,
+				Page:     1,
+				PageSize: DefaultHistoryPageSize,
+			},
+		},
+		{
+			name: "Successful with filters and pagination",
+			request: ListDeductionHistoryRequest{
+				TaxYear:   2024,
+				Type:      KReceipt,
+				ChangedBy: "adminTax",
+				From:      changedAt,
+				To:        changedAt.AddDate(0, 1, 0),
+				Page:      3,
+				PageSize:  10,
+			},
+			mockBehaviour: func() {
+				where := "WHERE tax_year = \\$1 AND type = \\$2 AND changed_by = \\$3 AND changed_at >= \\$4 AND changed_at < \\$5"
+				mock.ExpectPrepare("SELECT COUNT\\(\\*\\) FROM deduction_audits "+where).
+					ExpectQuery().
+					WithArgs(2024, KReceipt, "adminTax", changedAt, changedAt.AddDate(0, 1, 0)).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(25))
+				mock.ExpectPrepare("FROM deduction_audits "+where+" ORDER BY changed_at DESC, id DESC LIMIT \\$6 OFFSET \\$7").
+					ExpectQuery().
+					WithArgs(2024, KReceipt, "adminTax", changedAt, changedAt.AddDate(0, 1, 0), 10, 20).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expected: &DeductionHistory{
+				Changes:  []DeductionChange{},
+				Total:    25,
+				Page:     3,
+				PageSize: 10,
+			},
+		},
+		{
+			name:    "Database error on count",
+			request: ListDeductionHistoryRequest{},
+			mockBehaviour: func() {
+				mock.ExpectPrepare("SELECT COUNT\\(\\*\\) FROM deduction_audits").
+					ExpectQuery().
+					WillReturnError(assert.AnError)
+			},
+			expectedError: ErrQueryDatabase,
+		},
+		{
+			name:    "Database error on list",
+			request: ListDeductionHistoryRequest{},
+			mockBehaviour: func() {
+				mock.ExpectPrepare("SELECT COUNT\\(\\*\\) FROM deduction_audits").
+					ExpectQuery().
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+				mock.ExpectPrepare("SELECT id, tax_year, type, action").
+					ExpectQuery().
+					WillReturnError(assert.AnError)
+			},
+			expectedError: ErrQueryDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour()
+
+			result, err := s.ListDeductionHistory(context.Background(), tt.request)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expected, result)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListDeductionHistoryRequestPagination(t *testing.T) {
+	tests := []struct {
+		name             string
+		request          ListDeductionHistoryRequest
+		expectedPage     int
+		expectedPageSize int
+	}{
+		{"Defaults", ListDeductionHistoryRequest{}, 1, DefaultHistoryPageSize},
+		{"Custom", ListDeductionHistoryRequest{Page: 2, PageSize: 50}, 2, 50},
+		{"Negative values", ListDeductionHistoryRequest{Page: -1, PageSize: -1}, 1, DefaultHistoryPageSize},
+		{"Page size above maximum", ListDeductionHistoryRequest{PageSize: 1000}, 1, MaximumHistoryPageSize},
+		{"Page above maximum", ListDeductionHistoryRequest{Page: 1 << 62}, MaximumHistoryPage, DefaultHistoryPageSize},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			page, pageSize := tc.request.pagination()
+			assert.Equal(t, tc.expectedPage, page)
+			assert.Equal(t, tc.expectedPageSize, pageSize)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/utils/taxyear"
+)
+
+type ListDeductionsRequest struct {
+	TaxYear int `json:"taxYear"`
+}
+
+func (s *service) ListDeductions(ctx context.Context, request ListDeductionsRequest) ([]Deduction, error) {
+	taxYear := taxyear.Resolve(request.TaxYear)
+	allowances, err := getAllowances(s.db, taxYear, time.Now())
+	if err != nil {
+		s.log.Err(err).
+			Fields(logger.Fields{"taxYear": taxYear}).
+			E("Failed to get deductions from deductions table in database")
+		if errors.Is(err, ErrNoAllowances) {
+			return nil, err
+		}
+		return nil, ErrQueryDatabase
+	}
+
+	deductions := make([]Deduction, len(deductionRules))
+	for i, rule := range deductionRules {
+		deductions[i] = Deduction{
+			DeductionRule: rule,
+			TaxYear:       taxYear,
+			Amount:        allowances[rule.Type],
+		}
+	}
+
+	return deductions, nil
+}
@@ -0,0 +1,78 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestListDeductions(t *testing.T) {
+	s, mock, err := setup()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer s.db.Close()
+
+	tests := []struct {
+		name          string
+		request       ListDeductionsRequest
+		mockBehaviour func()
+		expected      []Deduction
+		expectedError error
+	}{
+		{
+			name:    "Successful to list deductions",
+			request: ListDeductionsRequest{TaxYear: 2024},
+			mockBehaviour: func() {
+				rows := sqlmock.NewRows([]string{"type", "amount"}).AddRow("donation", 10).AddRow("k-receipt", 50000).AddRow("personal", 70000)
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").
+					ExpectQuery().
+					WithArgs(2024, sqlmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			expected: []Deduction{
+				{DeductionRule: deductionRules[0], TaxYear: 2024, Amount: 70000 * money.Baht},
+				{DeductionRule: deductionRules[1], TaxYear: 2024, Amount: 10 * money.Baht},
+				{DeductionRule: deductionRules[2], TaxYear: 2024, Amount: 50000 * money.Baht},
+			},
+		},
+		{
+			name:    "No allowances for the tax year",
+			request: ListDeductionsRequest{TaxYear: 2000},
+			mockBehaviour: func() {
+				rows := sqlmock.NewRows([]string{"type", "amount"})
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").
+					ExpectQuery().
+					WithArgs(2000, sqlmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			expectedError: ErrTaxYearNotFound(2000),
+		},
+		{
+			name:    "Database error",
+			request: ListDeductionsRequest{TaxYear: 2024},
+			mockBehaviour: func() {
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").
+					ExpectQuery().
+					WithArgs(2024, sqlmock.AnyArg()).
+					WillReturnError(assert.AnError)
+			},
+			expectedError: ErrQueryDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour()
+
+			result, err := s.ListDeductions(context.Background(), tt.request)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expected, result)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/utils/taxyear"
+)
+
+type ListExchangeRatesRequest struct {
+	TaxYear int `json:"taxYear"`
+}
+
+// ListExchangeRates returns the exchange rates of the tax year, by currency.
+func (s *service) ListExchangeRates(ctx context.Context, request ListExchangeRatesRequest) ([]ExchangeRate, error) {
+	taxYear := taxyear.Resolve(request.TaxYear)
+	rows, err := s.db.Query(`SELECT currency, rate FROM exchange_rates
+		WHERE tax_year = $1 AND deleted_at IS NULL ORDER BY currency`, taxYear)
+	if err != nil {
+		s.log.Err(err).Fields(logger.Fields{"taxYear": taxYear}).E("Failed to get exchange rates from exchange_rates table in database")
+		return nil, ErrQueryDatabase
+	}
+	defer rows.Close()
+
+	rates := []ExchangeRate{}
+	for rows.Next() {
+		rate := ExchangeRate{TaxYear: taxYear}
+		if err := rows.Scan(&rate.Currency, &rate.Rate); err != nil {
+			s.log.Err(err).E("Failed to scan exchange rate")
+			return nil, ErrQueryDatabase
+		}
+		rates = append(rates, rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.log.Err(err).E("Failed to iterate exchange rates")
+		return nil, ErrQueryDatabase
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,72 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListExchangeRates(t *testing.T) {
+	s, mock, err := setup()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer s.db.Close()
+
+	tests := []struct {
+		name          string
+		request       ListExchangeRatesRequest
+		mockBehaviour func()
+		expected      []ExchangeRate
+		expectedError error
+	}{
+		{
+			name:    "Successful to list the rates of the tax year",
+			request: ListExchangeRatesRequest{TaxYear: 2024},
+			mockBehaviour: func() {
+				rows := sqlmock.NewRows([]string{"currency", "rate"}).AddRow("JPY", 0.2333).AddRow("USD", 35.2859)
+				mock.ExpectPrepare("SELECT currency, rate FROM exchange_rates").
+					ExpectQuery().
+					WithArgs(2024).
+					WillReturnRows(rows)
+			},
+			expected: []ExchangeRate{{TaxYear: 2024, Currency: "JPY", Rate: 0.2333}, {TaxYear: 2024, Currency: "USD", Rate: 35.2859}},
+		},
+		{
+			name:    "No rates for the tax year",
+			request: ListExchangeRatesRequest{TaxYear: 2000},
+			mockBehaviour: func() {
+				mock.ExpectPrepare("SELECT currency, rate FROM exchange_rates").
+					ExpectQuery().
+					WithArgs(2000).
+					WillReturnRows(sqlmock.NewRows([]string{"currency", "rate"}))
+			},
+			expected: []ExchangeRate{},
+		},
+		{
+			name:    "Database error",
+			request: ListExchangeRatesRequest{TaxYear: 2024},
+			mockBehaviour: func() {
+				mock.ExpectPrepare("SELECT currency, rate FROM exchange_rates").
+					ExpectQuery().
+					WithArgs(2024).
+					WillReturnError(assert.AnError)
+			},
+			expectedError: ErrQueryDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour()
+
+			result, err := s.ListExchangeRates(context.Background(), tt.request)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expected, result)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
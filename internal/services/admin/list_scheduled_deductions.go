@@ -0,0 +1,61 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+)
+
+type ListScheduledDeductionsRequest struct {
+	TaxYear int           `json:"taxYear"`
+	Type    DeductionType `json:"type"`
+}
+
+// ListScheduledDeductions returns the deductions that have not taken effect yet, soonest first.
+func (s *service) ListScheduledDeductions(ctx context.Context, request ListScheduledDeductionsRequest) ([]ScheduledDeduction, error) {
+	where, args := request.filter(time.Now())
+	rows, err := s.db.Query("SELECT id, tax_year, type, amount, effective_from FROM deductions"+where+" ORDER BY effective_from, id", args...)
+	if err != nil {
+		s.log.Err(err).Fields(logger.Fields{"request": request}).E("Failed to get scheduled deductions from deductions table in database")
+		return nil, ErrQueryDatabase
+	}
+	defer rows.Close()
+
+	scheduled := []ScheduledDeduction{}
+	for rows.Next() {
+		var deduction ScheduledDeduction
+		if err := rows.Scan(&deduction.ID, &deduction.TaxYear, &deduction.Type, &deduction.Amount, &deduction.EffectiveFrom); err != nil {
+			s.log.Err(err).E("Failed to scan scheduled deduction")
+			return nil, ErrQueryDatabase
+		}
+		scheduled = append(scheduled, deduction)
+	}
+
+	if err := rows.Err(); err != nil {
+		s.log.Err(err).E("Failed to iterate scheduled deductions")
+		return nil, ErrQueryDatabase
+	}
+
+	return scheduled, nil
+}
+
+func (r ListScheduledDeductionsRequest) filter(now time.Time) (string, []interface{}) {
+	conditions := []string{"effective_from > $1", "deleted_at IS NULL"}
+	args := []interface{}{now}
+	where := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if r.TaxYear != 0 {
+		where("tax_year = $%d", r.TaxYear)
+	}
+	if r.Type != "" {
+		where("type = $%d", r.Type)
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,78 @@
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestListScheduledDeductions(t *testing.T) {
+	s, mock, err := setup()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer s.db.Close()
+
+	effectiveFrom := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "tax_year", "type", "amount", "effective_from"}
+
+	tests := []struct {
+		name          string
+		request       ListScheduledDeductionsRequest
+		mockBehaviour func()
+		expected      []ScheduledDeduction
+		expectedError error
+	}{
+		{
+			name:    "Successful without filters",
+			request: ListScheduledDeductionsRequest{},
+			mockBehaviour: func() {
+				rows := sqlmock.NewRows(columns).AddRow(7, 2030, "personal", 70000, effectiveFrom)
+				mock.ExpectPrepare("SELECT id, tax_year, type, amount, effective_from FROM deductions WHERE effective_from > \\$1 AND deleted_at IS NULL ORDER BY effective_from, id").
+					ExpectQuery().
+					WithArgs(sqlmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			expected: []ScheduledDeduction{
+				{ID: 7, TaxYear: 2030, Type: Personal, Amount: 70000 * money.Baht, EffectiveFrom: effectiveFrom},
+			},
+		},
+		{
+			name:    "Successful with filters",
+			request: ListScheduledDeductionsRequest{TaxYear: 2030, Type: KReceipt},
+			mockBehaviour: func() {
+				mock.ExpectPrepare("WHERE effective_from > \\$1 AND deleted_at IS NULL AND tax_year = \\$2 AND type = \\$3 ORDER BY").
+					ExpectQuery().
+					WithArgs(sqlmock.AnyArg(), 2030, KReceipt).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+			expected: []ScheduledDeduction{},
+		},
+		{
+			name:    "Database error",
+			request: ListScheduledDeductionsRequest{},
+			mockBehaviour: func() {
+				mock.ExpectPrepare("SELECT id, tax_year, type, amount, effective_from FROM deductions").
+					ExpectQuery().
+					WillReturnError(assert.AnError)
+			},
+			expectedError: ErrQueryDatabase,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour()
+
+			result, err := s.ListScheduledDeductions(context.Background(), tt.request)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expected, result)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
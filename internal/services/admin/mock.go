@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/stretchr/testify/mock"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
 var _ Servicer = (*MockService)(nil)
@@ -12,7 +13,102 @@ type MockService struct {
 	mock.Mock
 }
 
-func (m *MockService) SetDeduction(ctx context.Context, req SetDeductionRequest) (float64, error) {
+func (m *MockService) SetDeduction(ctx context.Context, req SetDeductionRequest) (money.Money, error) {
 	args := m.Called(ctx, req)
-	return args.Get(0).(float64), args.Error(1)
+	return args.Get(0).(money.Money), args.Error(1)
+}
+
+func (m *MockService) ListDeductions(ctx context.Context, req ListDeductionsRequest) ([]Deduction, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]Deduction), args.Error(1)
+}
+
+func (m *MockService) GetDeduction(ctx context.Context, req DeductionRequest) (*Deduction, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*Deduction), args.Error(1)
+}
+
+func (m *MockService) ResetDeduction(ctx context.Context, req DeductionRequest) (money.Money, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(money.Money), args.Error(1)
+}
+
+func (m *MockService) ListDeductionHistory(ctx context.Context, req ListDeductionHistoryRequest) (*DeductionHistory, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*DeductionHistory), args.Error(1)
+}
+
+func (m *MockService) ScheduleDeduction(ctx context.Context, req ScheduleDeductionRequest) (*ScheduledDeduction, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ScheduledDeduction), args.Error(1)
+}
+
+func (m *MockService) ListScheduledDeductions(ctx context.Context, req ListScheduledDeductionsRequest) ([]ScheduledDeduction, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]ScheduledDeduction), args.Error(1)
+}
+
+func (m *MockService) CancelScheduledDeduction(ctx context.Context, req CancelScheduledDeductionRequest) (*ScheduledDeduction, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ScheduledDeduction), args.Error(1)
+}
+
+func (m *MockService) ListExchangeRates(ctx context.Context, req ListExchangeRatesRequest) ([]ExchangeRate, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]ExchangeRate), args.Error(1)
+}
+
+func (m *MockService) SetExchangeRates(ctx context.Context, req SetExchangeRatesRequest) ([]ExchangeRate, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]ExchangeRate), args.Error(1)
+}
+
+func (m *MockService) GetLateFilingRules(ctx context.Context, req LateFilingRulesRequest) (*LateFilingRules, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*LateFilingRules), args.Error(1)
+}
+
+func (m *MockService) SetLateFilingRules(ctx context.Context, req SetLateFilingRulesRequest) (*LateFilingRules, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*LateFilingRules), args.Error(1)
 }
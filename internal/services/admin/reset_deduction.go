@@ -0,0 +1,24 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+// ResetDeduction sets a deduction of the tax year back to its default amount.
+func (s *service) ResetDeduction(ctx context.Context, request DeductionRequest) (money.Money, error) {
+	rule, ok := findDeductionRule(request.Type)
+	if !ok {
+		s.log.Fields(logger.Fields{"type": request.Type}).E("Invalid request to reset %s deduction", request.Type)
+		return 0, ErrInvalidDeductionType
+	}
+
+	return s.setDeduction(SetDeductionRequest{
+		TaxYear:   request.TaxYear,
+		Type:      rule.Type,
+		Amount:    rule.Default,
+		ChangedBy: request.ChangedBy,
+	}, ActionReset)
+}
@@ -0,0 +1,97 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestResetDeduction(t *testing.T) {
+	s, mock, err := setup()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer s.db.Close()
+
+	tests := []struct {
+		name          string
+		request       DeductionRequest
+		mockBehaviour func()
+		expected      money.Money
+		expectedError error
+	}{
+		{
+			name:    "Successful to reset personal deduction",
+			request: DeductionRequest{TaxYear: 2024, Type: Personal, ChangedBy: "adminTax"},
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mockAllowances(mock, 2024)
+				mock.ExpectPrepare("INSERT INTO deductions").
+					ExpectQuery().
+					WithArgs(2024, Personal, PersonalDefault, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectPrepare("INSERT INTO deduction_audits").
+					ExpectExec().
+					WithArgs(2024, Personal, ActionReset, 60000*money.Baht, PersonalDefault, "adminTax").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			expected: PersonalDefault,
+		},
+		{
+			name:    "Successful to reset donation deduction",
+			request: DeductionRequest{TaxYear: 2024, Type: Donation},
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mockAllowances(mock, 2024)
+				mock.ExpectPrepare("INSERT INTO deductions").
+					ExpectQuery().
+					WithArgs(2024, Donation, DonationDefault, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectPrepare("INSERT INTO deduction_audits").
+					ExpectExec().
+					WithArgs(2024, Donation, ActionReset, 10*money.Baht, DonationDefault, "").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			expected: DonationDefault,
+		},
+		{
+			name:    "Database error",
+			request: DeductionRequest{TaxYear: 2024, Type: KReceipt},
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mockAllowances(mock, 2024)
+				mock.ExpectPrepare("INSERT INTO deductions").
+					ExpectQuery().
+					WithArgs(2024, KReceipt, KReceiptDefault, sqlmock.AnyArg()).
+					WillReturnError(assert.AnError)
+				mock.ExpectRollback()
+			},
+			expectedError: ErrUpdateDatabase(KReceipt),
+		},
+		{
+			name:    "Unknown Type",
+			request: DeductionRequest{TaxYear: 2024, Type: "unknown"},
+			mockBehaviour: func() {
+				// Do nothing
+			},
+			expectedError: ErrInvalidDeductionType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour()
+
+			result, err := s.ResetDeduction(context.Background(), tt.request)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expected, result)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/database"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+type ScheduleDeductionRequest struct {
+	// TaxYear is the year of EffectiveFrom when it is not set.
+	TaxYear       int           `json:"taxYear"`
+	Type          DeductionType `json:"type"`
+	Amount        money.Money   `json:"amount"`
+	EffectiveFrom time.Time     `json:"effectiveFrom"`
+	ChangedBy     string        `json:"changedBy"`
+}
+
+// ScheduleDeduction sets a deduction from a future time on and records the change in the audit trail.
+// Scheduling the same deduction at the same time again replaces the pending amount.
+func (s *service) ScheduleDeduction(ctx context.Context, request ScheduleDeductionRequest) (*ScheduledDeduction, error) {
+	if err := request.validate(); err != nil {
+		s.log.Err(err).
+			Fields(logger.Fields{"type": request.Type, "amount": request.Amount, "effectiveFrom": request.EffectiveFrom}).
+			E("Invalid request to schedule %s deduction", request.Type)
+		return nil, err
+	}
+
+	scheduled := ScheduledDeduction{
+		TaxYear:       request.taxYear(),
+		Type:          request.Type,
+		Amount:        request.Amount,
+		EffectiveFrom: request.EffectiveFrom,
+	}
+	err := s.db.Transaction(func(tx database.Executor) error {
+		allowances, err := getAllowances(tx, scheduled.TaxYear, scheduled.EffectiveFrom)
+		if err != nil {
+			return err
+		}
+
+		scheduled.ID, err = insertDeduction(tx, scheduled.TaxYear, scheduled.Type, scheduled.Amount, scheduled.EffectiveFrom)
+		if err != nil {
+			return err
+		}
+
+		return recordDeductionChange(tx, DeductionChange{
+			TaxYear:   scheduled.TaxYear,
+			Type:      scheduled.Type,
+			Action:    ActionSchedule,
+			OldAmount: allowances[scheduled.Type],
+			NewAmount: scheduled.Amount,
+			ChangedBy: request.ChangedBy,
+		})
+	})
+
+	if errors.Is(err, ErrNoAllowances) {
+		s.log.Err(err).
+			Fields(logger.Fields{"taxYear": scheduled.TaxYear}).
+			E("No allowances to base the %d tax year on", scheduled.TaxYear)
+		return nil, err
+	}
+
+	if err != nil {
+		s.log.Err(err).
+			Fields(logger.Fields{"taxYear": scheduled.TaxYear, "amount": scheduled.Amount, "effectiveFrom": scheduled.EffectiveFrom}).
+			E("Failed to schedule %s deduction to deductions table in database", request.Type)
+		return nil, ErrUpdateDatabase(request.Type)
+	}
+
+	return &scheduled, nil
+}
+
+// taxYear defaults an unset tax year to the one the change takes effect in.
+func (r ScheduleDeductionRequest) taxYear() int {
+	if r.TaxYear == 0 {
+		return r.EffectiveFrom.Year()
+	}
+
+	return r.TaxYear
+}
+
+func (r ScheduleDeductionRequest) validate() error {
+	if err := (SetDeductionRequest{Type: r.Type, Amount: r.Amount}).validate(); err != nil {
+		return err
+	}
+
+	if !r.EffectiveFrom.After(time.Now()) {
+		return ErrNotInFuture
+	}
+
+	return nil
+}
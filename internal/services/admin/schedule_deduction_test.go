@@ -0,0 +1,136 @@
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestScheduleDeduction(t *testing.T) {
+	s, mock, err := setup()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer s.db.Close()
+
+	effectiveFrom := time.Now().AddDate(1, 0, 0).Truncate(24 * time.Hour)
+
+	tests := []struct {
+		name          string
+		request       ScheduleDeductionRequest
+		mockBehaviour func()
+		expected      *ScheduledDeduction
+		expectedError error
+	}{
+		{
+			name:    "Successful to schedule personal deduction",
+			request: ScheduleDeductionRequest{TaxYear: 2024, Type: Personal, Amount: 70000 * money.Baht, EffectiveFrom: effectiveFrom, ChangedBy: "adminTax"},
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows([]string{"type", "amount"}).AddRow("personal", 60000)
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").
+					ExpectQuery().
+					WithArgs(2024, effectiveFrom).
+					WillReturnRows(rows)
+				mock.ExpectPrepare("INSERT INTO deductions").
+					ExpectQuery().
+					WithArgs(2024, Personal, 70000*money.Baht, effectiveFrom).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectPrepare("INSERT INTO deduction_audits").
+					ExpectExec().
+					WithArgs(2024, Personal, ActionSchedule, 60000*money.Baht, 70000*money.Baht, "adminTax").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			expected: &ScheduledDeduction{ID: 7, TaxYear: 2024, Type: Personal, Amount: 70000 * money.Baht, EffectiveFrom: effectiveFrom},
+		},
+		{
+			name:    "Tax year of the effective date",
+			request: ScheduleDeductionRequest{Type: Personal, Amount: 70000 * money.Baht, EffectiveFrom: effectiveFrom, ChangedBy: "adminTax"},
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows([]string{"type", "amount"}).AddRow("personal", 60000)
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").
+					ExpectQuery().
+					WithArgs(effectiveFrom.Year(), effectiveFrom).
+					WillReturnRows(rows)
+				mock.ExpectPrepare("INSERT INTO deductions").
+					ExpectQuery().
+					WithArgs(effectiveFrom.Year(), Personal, 70000*money.Baht, effectiveFrom).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(8))
+				mock.ExpectPrepare("INSERT INTO deduction_audits").
+					ExpectExec().
+					WithArgs(effectiveFrom.Year(), Personal, ActionSchedule, 60000*money.Baht, 70000*money.Baht, "adminTax").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			expected: &ScheduledDeduction{ID: 8, TaxYear: effectiveFrom.Year(), Type: Personal, Amount: 70000 * money.Baht, EffectiveFrom: effectiveFrom},
+		},
+		{
+			name:    "Effective date in the past",
+			request: ScheduleDeductionRequest{TaxYear: 2024, Type: Personal, Amount: 70000 * money.Baht, EffectiveFrom: time.Now().AddDate(0, 0, -1)},
+			mockBehaviour: func() {
+				// Do nothing
+			},
+			expectedError: ErrNotInFuture,
+		},
+		{
+			name:    "Amount out of limit",
+			request: ScheduleDeductionRequest{TaxYear: 2024, Type: KReceipt, Amount: 100001 * money.Baht, EffectiveFrom: effectiveFrom},
+			mockBehaviour: func() {
+				// Do nothing
+			},
+			expectedError: ErrMoreThanLimit(KReceipt, KReceiptMaximum),
+		},
+		{
+			name:    "Unknown Type",
+			request: ScheduleDeductionRequest{TaxYear: 2024, Type: "unknown", Amount: 70000 * money.Baht, EffectiveFrom: effectiveFrom},
+			mockBehaviour: func() {
+				// Do nothing
+			},
+			expectedError: ErrInvalidDeductionType,
+		},
+		{
+			name:    "No allowances to base the tax year on",
+			request: ScheduleDeductionRequest{TaxYear: 2000, Type: Personal, Amount: 70000 * money.Baht, EffectiveFrom: effectiveFrom},
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").
+					ExpectQuery().
+					WithArgs(2000, effectiveFrom).
+					WillReturnRows(sqlmock.NewRows([]string{"type", "amount"}))
+				mock.ExpectRollback()
+			},
+			expectedError: ErrTaxYearNotFound(2000),
+		},
+		{
+			name:    "Database error",
+			request: ScheduleDeductionRequest{TaxYear: 2024, Type: Personal, Amount: 70000 * money.Baht, EffectiveFrom: effectiveFrom},
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mockAllowances(mock, 2024)
+				mock.ExpectPrepare("INSERT INTO deductions").
+					ExpectQuery().
+					WillReturnError(assert.AnError)
+				mock.ExpectRollback()
+			},
+			expectedError: ErrUpdateDatabase(Personal),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour()
+
+			result, err := s.ScheduleDeduction(context.Background(), tt.request)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expected, result)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
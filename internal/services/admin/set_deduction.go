@@ -2,17 +2,28 @@ package admin
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"time"
 
+	"github.com/ztrixack/assessment-tax/internal/modules/database"
 	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+	"github.com/ztrixack/assessment-tax/internal/utils/taxyear"
 )
 
 type SetDeductionRequest struct {
-	Type   DeductionType `json:"type"`
-	Amount float64       `json:"amount"`
+	TaxYear   int           `json:"taxYear"`
+	Type      DeductionType `json:"type"`
+	Amount    money.Money   `json:"amount"`
+	ChangedBy string        `json:"changedBy"`
 }
 
-func (s *service) SetDeduction(ctx context.Context, request SetDeductionRequest) (float64, error) {
+func (s *service) SetDeduction(ctx context.Context, request SetDeductionRequest) (money.Money, error) {
+	return s.setDeduction(request, ActionSet)
+}
+
+// setDeduction updates the deduction from now on and records the change in the audit trail within one transaction.
+func (s *service) setDeduction(request SetDeductionRequest, action DeductionAction) (money.Money, error) {
 	if err := request.validate(); err != nil {
 		s.log.Err(err).
 			Fields(logger.Fields{"type": request.Type, "amount": request.Amount}).
@@ -20,12 +31,39 @@ func (s *service) SetDeduction(ctx context.Context, request SetDeductionRequest)
 		return 0, err
 	}
 
-	query := fmt.Sprintf("UPDATE allowances SET %s = $1", sanitizeType(request.Type))
-	_, err := s.db.Execute(query, request.Amount)
+	taxYear := taxyear.Resolve(request.TaxYear)
+	now := time.Now()
+	err := s.db.Transaction(func(tx database.Executor) error {
+		allowances, err := getAllowances(tx, taxYear, now)
+		if err != nil {
+			return err
+		}
+
+		if _, err := insertDeduction(tx, taxYear, request.Type, request.Amount, now); err != nil {
+			return err
+		}
+
+		return recordDeductionChange(tx, DeductionChange{
+			TaxYear:   taxYear,
+			Type:      request.Type,
+			Action:    action,
+			OldAmount: allowances[request.Type],
+			NewAmount: request.Amount,
+			ChangedBy: request.ChangedBy,
+		})
+	})
+
+	if errors.Is(err, ErrNoAllowances) {
+		s.log.Err(err).
+			Fields(logger.Fields{"taxYear": taxYear}).
+			E("No allowances to base the %d tax year on", taxYear)
+		return 0, err
+	}
+
 	if err != nil {
 		s.log.Err(err).
-			Fields(logger.Fields{"query": query, "amount": request.Amount}).
-			E("Failed to update %s deduction to allowances table in database", request.Type)
+			Fields(logger.Fields{"taxYear": taxYear, "amount": request.Amount}).
+			E("Failed to update %s deduction to deductions table in database", request.Type)
 		return 0, ErrUpdateDatabase(request.Type)
 	}
 
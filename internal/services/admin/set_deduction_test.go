@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/ztrixack/assessment-tax/internal/modules/database"
 	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
 func setup() (*service, sqlmock.Sqlmock, error) {
@@ -16,6 +17,17 @@ func setup() (*service, sqlmock.Sqlmock, error) {
 	return &service{log, db}, mock, err
 }
 
+func mockAllowances(mock sqlmock.Sqlmock, taxYear int) {
+	rows := sqlmock.NewRows([]string{"type", "amount"}).
+		AddRow("donation", 10).
+		AddRow("k-receipt", 50000).
+		AddRow("personal", 60000)
+	mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").
+		ExpectQuery().
+		WithArgs(taxYear, sqlmock.AnyArg()).
+		WillReturnRows(rows)
+}
+
 func TestSetDeduction(t *testing.T) {
 	s, mock, err := setup()
 	if err != nil {
@@ -31,29 +43,43 @@ func TestSetDeduction(t *testing.T) {
 	}{
 		{
 			name:    "Successful to set personal update",
-			request: SetDeductionRequest{Type: Personal, Amount: 60000.0},
+			request: SetDeductionRequest{TaxYear: 2024, Type: Personal, Amount: 70000 * money.Baht, ChangedBy: "adminTax"},
 			mockBehaviour: func() {
-				mock.ExpectPrepare("UPDATE allowances SET personal = \\$1").
+				mock.ExpectBegin()
+				mockAllowances(mock, 2024)
+				mock.ExpectPrepare("INSERT INTO deductions \\(tax_year, type, amount, effective_from\\)").
+					ExpectQuery().
+					WithArgs(2024, Personal, 70000*money.Baht, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectPrepare("INSERT INTO deduction_audits").
 					ExpectExec().
-					WithArgs(60000.0).
+					WithArgs(2024, Personal, ActionSet, 60000*money.Baht, 70000*money.Baht, "adminTax").
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 			},
 			expectedError: nil,
 		},
 		{
 			name:    "Successful to set k-receipt update",
-			request: SetDeductionRequest{Type: KReceipt, Amount: 60000.0},
+			request: SetDeductionRequest{TaxYear: 2024, Type: KReceipt, Amount: 60000 * money.Baht, ChangedBy: "adminTax"},
 			mockBehaviour: func() {
-				mock.ExpectPrepare("UPDATE allowances SET k_receipt = \\$1").
+				mock.ExpectBegin()
+				mockAllowances(mock, 2024)
+				mock.ExpectPrepare("INSERT INTO deductions \\(tax_year, type, amount, effective_from\\)").
+					ExpectQuery().
+					WithArgs(2024, KReceipt, 60000*money.Baht, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectPrepare("INSERT INTO deduction_audits").
 					ExpectExec().
-					WithArgs(60000.0).
+					WithArgs(2024, KReceipt, ActionSet, 50000*money.Baht, 60000*money.Baht, "adminTax").
 					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
 			},
 			expectedError: nil,
 		},
 		{
 			name:    "Set Personal deduction less than 10,000",
-			request: SetDeductionRequest{Type: Personal, Amount: 9999.0},
+			request: SetDeductionRequest{TaxYear: 2024, Type: Personal, Amount: 9999 * money.Baht},
 			mockBehaviour: func() {
 				// Do nothing
 			},
@@ -61,7 +87,7 @@ func TestSetDeduction(t *testing.T) {
 		},
 		{
 			name:    "Set Personal deduction more than 100,000",
-			request: SetDeductionRequest{Type: Personal, Amount: 100001.0},
+			request: SetDeductionRequest{TaxYear: 2024, Type: Personal, Amount: 100001 * money.Baht},
 			mockBehaviour: func() {
 				// Do nothing
 			},
@@ -69,7 +95,7 @@ func TestSetDeduction(t *testing.T) {
 		},
 		{
 			name:    "Set K-Receipt deduction less than 0",
-			request: SetDeductionRequest{Type: KReceipt, Amount: -1.0},
+			request: SetDeductionRequest{TaxYear: 2024, Type: KReceipt, Amount: -1 * money.Baht},
 			mockBehaviour: func() {
 				// Do nothing
 			},
@@ -77,7 +103,7 @@ func TestSetDeduction(t *testing.T) {
 		},
 		{
 			name:    "Set K-Receipt deduction more than 100,000",
-			request: SetDeductionRequest{Type: KReceipt, Amount: 100001.0},
+			request: SetDeductionRequest{TaxYear: 2024, Type: KReceipt, Amount: 100001 * money.Baht},
 			mockBehaviour: func() {
 				// Do nothing
 			},
@@ -85,18 +111,52 @@ func TestSetDeduction(t *testing.T) {
 		},
 		{
 			name:    "Database error",
-			request: SetDeductionRequest{Type: Personal, Amount: 60000.0},
+			request: SetDeductionRequest{TaxYear: 2024, Type: Personal, Amount: 60000 * money.Baht},
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mockAllowances(mock, 2024)
+				mock.ExpectPrepare("INSERT INTO deductions").
+					ExpectQuery().
+					WithArgs(2024, Personal, 60000*money.Baht, sqlmock.AnyArg()).
+					WillReturnError(assert.AnError)
+				mock.ExpectRollback()
+			},
+			expectedError: ErrUpdateDatabase(Personal),
+		},
+		{
+			name:    "Audit trail error",
+			request: SetDeductionRequest{TaxYear: 2024, Type: Personal, Amount: 60000 * money.Baht},
 			mockBehaviour: func() {
-				mock.ExpectPrepare("UPDATE allowances SET personal = \\$1").
+				mock.ExpectBegin()
+				mockAllowances(mock, 2024)
+				mock.ExpectPrepare("INSERT INTO deductions").
+					ExpectQuery().
+					WithArgs(2024, Personal, 60000*money.Baht, sqlmock.AnyArg()).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mock.ExpectPrepare("INSERT INTO deduction_audits").
 					ExpectExec().
-					WithArgs(60000.0).
 					WillReturnError(assert.AnError)
+				mock.ExpectRollback()
 			},
 			expectedError: ErrUpdateDatabase(Personal),
 		},
+		{
+			name:    "No allowances to base the tax year on",
+			request: SetDeductionRequest{TaxYear: 2000, Type: Personal, Amount: 60000 * money.Baht},
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows([]string{"type", "amount"})
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").
+					ExpectQuery().
+					WithArgs(2000, sqlmock.AnyArg()).
+					WillReturnRows(rows)
+				mock.ExpectRollback()
+			},
+			expectedError: ErrTaxYearNotFound(2000),
+		},
 		{
 			name:    "Unknown Type",
-			request: SetDeductionRequest{Type: "unknown", Amount: 60000.0},
+			request: SetDeductionRequest{TaxYear: 2024, Type: "unknown", Amount: 60000 * money.Baht},
 			mockBehaviour: func() {
 				// Do nothing
 			},
@@ -0,0 +1,92 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/database"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/utils/taxyear"
+)
+
+type SetExchangeRatesRequest struct {
+	TaxYear int `json:"taxYear"`
+	// Rates are in baht per unit of the currency. Currencies without a rate keep the rate they have.
+	Rates []CurrencyRate `json:"rates"`
+}
+
+type CurrencyRate struct {
+	Currency string  `json:"currency"`
+	Rate     float64 `json:"rate"`
+}
+
+// SetExchangeRates uploads the exchange rates of the tax year within one transaction, replacing the rate
+// of every currency that already has one.
+func (s *service) SetExchangeRates(ctx context.Context, request SetExchangeRatesRequest) ([]ExchangeRate, error) {
+	if err := request.validate(); err != nil {
+		s.log.Err(err).Fields(logger.Fields{"rates": request.Rates}).E("Invalid request to set exchange rates")
+		return nil, err
+	}
+
+	taxYear := taxyear.Resolve(request.TaxYear)
+	err := s.db.Transaction(func(tx database.Executor) error {
+		for _, rate := range request.Rates {
+			if _, err := tx.Execute(`INSERT INTO exchange_rates (tax_year, currency, rate) VALUES ($1, $2, $3)
+				ON CONFLICT (tax_year, currency) WHERE deleted_at IS NULL DO UPDATE SET rate = EXCLUDED.rate, updated_at = NOW()`,
+				taxYear, rate.Currency, rate.Rate); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		s.log.Err(err).Fields(logger.Fields{"taxYear": taxYear}).E("Failed to set exchange rates to exchange_rates table in database")
+		return nil, ErrUpdateExchangeRates
+	}
+
+	rates := make([]ExchangeRate, len(request.Rates))
+	for i, rate := range request.Rates {
+		rates[i] = ExchangeRate{TaxYear: taxYear, Currency: rate.Currency, Rate: rate.Rate}
+	}
+
+	return rates, nil
+}
+
+func (r SetExchangeRatesRequest) validate() error {
+	if len(r.Rates) == 0 {
+		return ErrNoExchangeRates
+	}
+
+	currencies := make(map[string]bool, len(r.Rates))
+	for _, rate := range r.Rates {
+		if !isCurrency(rate.Currency) {
+			return fmt.Errorf("%w: %q", ErrInvalidCurrency, rate.Currency)
+		}
+		if currencies[rate.Currency] {
+			return fmt.Errorf("%w: %s", ErrDuplicateCurrency, rate.Currency)
+		}
+		if rate.Rate <= 0 {
+			return fmt.Errorf("%w: %s", ErrInvalidExchangeRate, rate.Currency)
+		}
+		currencies[rate.Currency] = true
+	}
+
+	return nil
+}
+
+// isCurrency reports whether the code is an ISO 4217 code of three upper case letters other than the baht,
+// which needs no rate.
+func isCurrency(code string) bool {
+	if len(code) != 3 || code == "THB" {
+		return false
+	}
+
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,102 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetExchangeRates(t *testing.T) {
+	s, mock, err := setup()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer s.db.Close()
+
+	tests := []struct {
+		name          string
+		request       SetExchangeRatesRequest
+		mockBehaviour func()
+		expected      []ExchangeRate
+		expectedError error
+	}{
+		{
+			name:    "Successful to upload the rates of the tax year",
+			request: SetExchangeRatesRequest{TaxYear: 2024, Rates: []CurrencyRate{{Currency: "USD", Rate: 35.2859}, {Currency: "JPY", Rate: 0.2333}}},
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mock.ExpectPrepare("INSERT INTO exchange_rates \\(tax_year, currency, rate\\)").
+					ExpectExec().
+					WithArgs(2024, "USD", 35.2859).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectPrepare("INSERT INTO exchange_rates \\(tax_year, currency, rate\\)").
+					ExpectExec().
+					WithArgs(2024, "JPY", 0.2333).
+					WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+			},
+			expected: []ExchangeRate{{TaxYear: 2024, Currency: "USD", Rate: 35.2859}, {TaxYear: 2024, Currency: "JPY", Rate: 0.2333}},
+		},
+		{
+			name:          "No rates",
+			request:       SetExchangeRatesRequest{TaxYear: 2024},
+			mockBehaviour: func() {},
+			expectedError: ErrNoExchangeRates,
+		},
+		{
+			name:          "Lower case currency",
+			request:       SetExchangeRatesRequest{TaxYear: 2024, Rates: []CurrencyRate{{Currency: "usd", Rate: 35.2859}}},
+			mockBehaviour: func() {},
+			expectedError: ErrInvalidCurrency,
+		},
+		{
+			name:          "Rate of the baht",
+			request:       SetExchangeRatesRequest{TaxYear: 2024, Rates: []CurrencyRate{{Currency: "THB", Rate: 1}}},
+			mockBehaviour: func() {},
+			expectedError: ErrInvalidCurrency,
+		},
+		{
+			name:          "Currency set twice",
+			request:       SetExchangeRatesRequest{TaxYear: 2024, Rates: []CurrencyRate{{Currency: "USD", Rate: 35.2859}, {Currency: "USD", Rate: 36}}},
+			mockBehaviour: func() {},
+			expectedError: ErrDuplicateCurrency,
+		},
+		{
+			name:          "Zero rate",
+			request:       SetExchangeRatesRequest{TaxYear: 2024, Rates: []CurrencyRate{{Currency: "USD"}}},
+			mockBehaviour: func() {},
+			expectedError: ErrInvalidExchangeRate,
+		},
+		{
+			name:    "Database error rolls back every rate",
+			request: SetExchangeRatesRequest{TaxYear: 2024, Rates: []CurrencyRate{{Currency: "USD", Rate: 35.2859}, {Currency: "JPY", Rate: 0.2333}}},
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mock.ExpectPrepare("INSERT INTO exchange_rates").
+					ExpectExec().
+					WithArgs(2024, "USD", 35.2859).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectPrepare("INSERT INTO exchange_rates").
+					ExpectExec().
+					WithArgs(2024, "JPY", 0.2333).
+					WillReturnError(assert.AnError)
+				mock.ExpectRollback()
+			},
+			expectedError: ErrUpdateExchangeRates,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour()
+
+			result, err := s.SetExchangeRates(context.Background(), tt.request)
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expected, result)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package admin
+
+import (
+	"context"
+	"math"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+	"github.com/ztrixack/assessment-tax/internal/utils/taxyear"
+)
+
+type SetLateFilingRulesRequest struct {
+	TaxYear       int         `json:"taxYear"`
+	SurchargeRate float64     `json:"surchargeRate"`
+	Penalty       money.Money `json:"penalty"`
+}
+
+// SetLateFilingRules sets the late filing rules of the tax year, replacing the ones it has.
+func (s *service) SetLateFilingRules(ctx context.Context, request SetLateFilingRulesRequest) (*LateFilingRules, error) {
+	if err := request.validate(); err != nil {
+		s.log.Err(err).
+			Fields(logger.Fields{"surchargeRate": request.SurchargeRate, "penalty": request.Penalty}).
+			E("Invalid request to set late filing rules")
+		return nil, err
+	}
+
+	rules := LateFilingRules{TaxYear: taxyear.Resolve(request.TaxYear), SurchargeRate: request.SurchargeRate, Penalty: request.Penalty}
+	if _, err := s.db.Execute(`INSERT INTO late_filing_rules (tax_year, surcharge_rate, penalty) VALUES ($1, $2, $3)
+		ON CONFLICT (tax_year) WHERE deleted_at IS NULL DO UPDATE SET surcharge_rate = EXCLUDED.surcharge_rate, penalty = EXCLUDED.penalty, updated_at = NOW()`,
+		rules.TaxYear, rules.SurchargeRate, rules.Penalty); err != nil {
+		s.log.Err(err).Fields(logger.Fields{"taxYear": rules.TaxYear}).E("Failed to set late filing rules to late_filing_rules table in database")
+		return nil, ErrUpdateLateFilingRules
+	}
+
+	return &rules, nil
+}
+
+// validate checks the rate is a percentage with up to two decimals, as it is stored, and the penalty is in range.
+func (r SetLateFilingRulesRequest) validate() error {
+	hundredths := r.SurchargeRate * 100
+	if r.SurchargeRate < SurchargeRateMinimum || r.SurchargeRate > SurchargeRateMaximum || math.Abs(hundredths-math.Round(hundredths)) > 1e-9 {
+		return ErrInvalidSurchargeRate
+	}
+
+	if r.Penalty < LateFilingPenaltyMinimum || r.Penalty > LateFilingPenaltyMaximum {
+		return ErrInvalidLateFilingPenalty
+	}
+
+	return nil
+}
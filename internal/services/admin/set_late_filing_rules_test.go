@@ -0,0 +1,84 @@
+package admin
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestSetLateFilingRules(t *testing.T) {
+	s, mock, err := setup()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer s.db.Close()
+
+	tests := []struct {
+		name          string
+		request       SetLateFilingRulesRequest
+		mockBehaviour func()
+		expected      *LateFilingRules
+		expectedError error
+	}{
+		{
+			name:    "Successful to set the rules of the tax year",
+			request: SetLateFilingRulesRequest{TaxYear: 2024, SurchargeRate: 1.5, Penalty: 200 * money.Baht},
+			mockBehaviour: func() {
+				mock.ExpectPrepare("INSERT INTO late_filing_rules \\(tax_year, surcharge_rate, penalty\\)").
+					ExpectExec().
+					WithArgs(2024, 1.5, 200*money.Baht).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+			expected: &LateFilingRules{TaxYear: 2024, SurchargeRate: 1.5, Penalty: 200 * money.Baht},
+		},
+		{
+			name:          "Surcharge rate above 100 percent",
+			request:       SetLateFilingRulesRequest{TaxYear: 2024, SurchargeRate: 100.5, Penalty: 200 * money.Baht},
+			mockBehaviour: func() {},
+			expectedError: ErrInvalidSurchargeRate,
+		},
+		{
+			name:          "Surcharge rate with more than two decimals",
+			request:       SetLateFilingRulesRequest{TaxYear: 2024, SurchargeRate: 1.505, Penalty: 200 * money.Baht},
+			mockBehaviour: func() {},
+			expectedError: ErrInvalidSurchargeRate,
+		},
+		{
+			name:          "Negative surcharge rate",
+			request:       SetLateFilingRulesRequest{TaxYear: 2024, SurchargeRate: -1, Penalty: 200 * money.Baht},
+			mockBehaviour: func() {},
+			expectedError: ErrInvalidSurchargeRate,
+		},
+		{
+			name:          "Penalty above the maximum",
+			request:       SetLateFilingRulesRequest{TaxYear: 2024, SurchargeRate: 1.5, Penalty: 2001 * money.Baht},
+			mockBehaviour: func() {},
+			expectedError: ErrInvalidLateFilingPenalty,
+		},
+		{
+			name:    "Database error",
+			request: SetLateFilingRulesRequest{TaxYear: 2024, SurchargeRate: 1.5, Penalty: 200 * money.Baht},
+			mockBehaviour: func() {
+				mock.ExpectPrepare("INSERT INTO late_filing_rules").
+					ExpectExec().
+					WillReturnError(assert.AnError)
+			},
+			expectedError: ErrUpdateLateFilingRules,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour()
+
+			result, err := s.SetLateFilingRules(context.Background(), tt.request)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expected, result)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
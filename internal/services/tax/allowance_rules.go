@@ -0,0 +1,189 @@
+package tax
+
+import "github.com/ztrixack/assessment-tax/internal/utils/money"
+
+const (
+	EducationDonation AllowanceType = "donation-education"
+	HospitalDonation  AllowanceType = "donation-hospital"
+	Spouse            AllowanceType = "spouse"
+	Child             AllowanceType = "child"
+	ParentalCare      AllowanceType = "parental-care"
+	LifeInsurance     AllowanceType = "life-insurance"
+	HealthInsurance   AllowanceType = "health-insurance"
+	ProvidentFund     AllowanceType = "provident-fund"
+	RMF               AllowanceType = "rmf"
+	SSF               AllowanceType = "ssf"
+	ThaiESG           AllowanceType = "thai-esg"
+	HomeLoanInterest  AllowanceType = "home-loan-interest"
+	SocialSecurity    AllowanceType = "social-security"
+)
+
+// Unlimited is the maximum of an allowance without a cap.
+const Unlimited = money.Max
+
+// AllowanceGroup is a cap shared by the combined amount of several allowance types.
+type AllowanceGroup struct {
+	Name    string
+	Maximum money.Money
+	// NetIncomePercentage caps the group at the percentage configured in the database for the type
+	// of the income left after the expenses and every other allowance, when set. Such groups are
+	// deducted after every other allowance.
+	NetIncomePercentage AllowanceType
+}
+
+// AllowanceRule describes how much of a claimed allowance type can be deducted.
+type AllowanceRule struct {
+	Type AllowanceType
+	// Maximum caps the total claimed for the type.
+	Maximum money.Money
+	// Configured takes the maximum from the allowances configured in the database instead.
+	Configured bool
+	// PerClaim caps every claimed allowance on its own, such as one per child, when set.
+	PerClaim money.Money
+	// Multiplier counts every claimed baht that many times, such as double for education donations, when set.
+	Multiplier float64
+	// IncomeRate caps the total claimed at a share of the income, when set.
+	IncomeRate float64
+	// Group shares its maximum with the other types of the group, when set.
+	Group *AllowanceGroup
+	// FilingStatuses restricts the type to filers of these statuses, who are granted its maximum without
+	// a claim, when set.
+	FilingStatuses []FilingStatus
+	// ResidentOnly restricts the type to residents, so that it is neither claimed by nor granted to non-residents.
+	ResidentOnly bool
+}
+
+// AllowanceLimit is the cap a claimed allowance was clamped to.
+type AllowanceLimit string
+
+const (
+	LimitPerClaim   AllowanceLimit = "per-claim"
+	LimitMaximum    AllowanceLimit = "maximum"
+	LimitConfigured AllowanceLimit = "configured"
+	LimitIncomeRate AllowanceLimit = "income-rate"
+	LimitGroup      AllowanceLimit = "group"
+)
+
+// AllowanceDeduction is how much of the claimed allowances of a type was deducted.
+type AllowanceDeduction struct {
+	Type AllowanceType
+	// Claimed is the total claimed for the type, before any rule is applied.
+	Claimed  money.Money
+	Deducted money.Money
+	// Limit is the cap the deduction was clamped to, empty when the claim was deducted in full.
+	Limit AllowanceLimit
+	// Group is the name of the group the type shares its maximum with, if any.
+	Group string
+}
+
+var (
+	donationGroup   = &AllowanceGroup{Name: "donation", Maximum: Unlimited, NetIncomePercentage: Donation}
+	insuranceGroup  = &AllowanceGroup{Name: "insurance", Maximum: 100000 * money.Baht}
+	retirementGroup = &AllowanceGroup{Name: "retirement", Maximum: 500000 * money.Baht}
+)
+
+// allowanceRules is the registry of allowance types a filer can claim. The personal allowance
+// is granted to everyone and cannot be claimed. Types sharing a group are deducted in the order
+// they are listed until the group maximum is reached. The family allowances and the Thai savings
+// and spending schemes are restricted to residents.
+var allowanceRules = []AllowanceRule{
+	{Type: Donation, Maximum: Unlimited, Group: donationGroup},
+	{Type: EducationDonation, Maximum: Unlimited, Multiplier: 2, Group: donationGroup},
+	{Type: HospitalDonation, Maximum: Unlimited, Multiplier: 2, Group: donationGroup},
+	{Type: KReceipt, Configured: true, ResidentOnly: true},
+	{Type: Spouse, Maximum: 60000 * money.Baht, FilingStatuses: []FilingStatus{SpouseNoIncome, Joint}, ResidentOnly: true},
+	{Type: Child, Maximum: Unlimited, PerClaim: 30000 * money.Baht, ResidentOnly: true},
+	{Type: ParentalCare, Maximum: 120000 * money.Baht, PerClaim: 30000 * money.Baht, ResidentOnly: true},
+	{Type: LifeInsurance, Maximum: 100000 * money.Baht, Group: insuranceGroup},
+	{Type: HealthInsurance, Maximum: 25000 * money.Baht, Group: insuranceGroup},
+	{Type: ProvidentFund, Maximum: 500000 * money.Baht, IncomeRate: 0.15, Group: retirementGroup},
+	{Type: RMF, Maximum: 500000 * money.Baht, IncomeRate: 0.30, Group: retirementGroup, ResidentOnly: true},
+	{Type: SSF, Maximum: 200000 * money.Baht, IncomeRate: 0.30, Group: retirementGroup, ResidentOnly: true},
+	{Type: ThaiESG, Maximum: 300000 * money.Baht, IncomeRate: 0.30, ResidentOnly: true},
+	{Type: HomeLoanInterest, Maximum: 100000 * money.Baht},
+	{Type: SocialSecurity, Maximum: 9000 * money.Baht},
+}
+
+// AllowanceTypes returns every allowance type a filer can claim, in the order they are deducted.
+func AllowanceTypes() []AllowanceType {
+	types := make([]AllowanceType, len(allowanceRules))
+	for i, rule := range allowanceRules {
+		types[i] = rule.Type
+	}
+
+	return types
+}
+
+// IsClaimable reports whether a filer can claim the allowance type.
+func IsClaimable(atype AllowanceType) bool {
+	_, ok := findAllowanceRule(atype)
+	return ok
+}
+
+func findAllowanceRule(atype AllowanceType) (AllowanceRule, bool) {
+	for _, rule := range allowanceRules {
+		if rule.Type == atype {
+			return rule, true
+		}
+	}
+
+	return AllowanceRule{}, false
+}
+
+// claim returns the part of a single claimed amount that counts towards the type.
+func (r AllowanceRule) claim(amount money.Money) money.Money {
+	if r.PerClaim > 0 {
+		amount = min(amount, r.PerClaim)
+	}
+
+	if r.Multiplier > 0 {
+		amount = amount.Mul(r.Multiplier)
+	}
+
+	return amount
+}
+
+// appliesTo reports whether a filer of the status can claim the type.
+func (r AllowanceRule) appliesTo(status FilingStatus) bool {
+	return len(r.FilingStatuses) == 0 || r.grantedTo(status)
+}
+
+// grantedTo reports whether the type is deducted in full for a filer of the status without a claim.
+func (r AllowanceRule) grantedTo(status FilingStatus) bool {
+	for _, s := range r.FilingStatuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deductedLast reports whether the type is capped by the income left after every other allowance.
+func (r AllowanceRule) deductedLast() bool {
+	return r.Group != nil && r.Group.NetIncomePercentage != ""
+}
+
+// limit returns the maximum deductible for the group given the configured allowances and the income left.
+func (g AllowanceGroup) limit(configured AllowanceList, netIncome money.Money) money.Money {
+	if g.NetIncomePercentage != "" {
+		return min(g.Maximum, max(netIncome, 0).MulRatio(configured[g.NetIncomePercentage], 100*money.Baht))
+	}
+
+	return g.Maximum
+}
+
+// limit returns the maximum deductible for the type given the configured allowances and the income,
+// and which cap it comes from.
+func (r AllowanceRule) limit(configured AllowanceList, income money.Money) (money.Money, AllowanceLimit) {
+	limit, cap := r.Maximum, LimitMaximum
+	if r.Configured {
+		limit, cap = configured[r.Type], LimitConfigured
+	}
+
+	if r.IncomeRate > 0 && income.Mul(r.IncomeRate) < limit {
+		limit, cap = income.Mul(r.IncomeRate), LimitIncomeRate
+	}
+
+	return limit, cap
+}
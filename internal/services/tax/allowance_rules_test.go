@@ -0,0 +1,69 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestAllowanceTypes(t *testing.T) {
+	types := AllowanceTypes()
+
+	assert.Len(t, types, len(allowanceRules))
+	assert.Contains(t, types, Donation)
+	assert.Contains(t, types, KReceipt)
+	assert.NotContains(t, types, Personal)
+}
+
+func TestIsClaimable(t *testing.T) {
+	tests := []struct {
+		name     string
+		atype    AllowanceType
+		expected bool
+	}{
+		{"Donation", Donation, true},
+		{"Life insurance", LifeInsurance, true},
+		{"Personal is granted, not claimed", Personal, false},
+		{"Unknown", "unknown", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsClaimable(tc.atype))
+		})
+	}
+}
+
+func TestAllowanceRuleLimit(t *testing.T) {
+	configured := AllowanceList{Personal: 60000 * money.Baht, Donation: 10 * money.Baht, KReceipt: 50000 * money.Baht}
+
+	tests := []struct {
+		name     string
+		rule     AllowanceRule
+		income   money.Money
+		expected money.Money
+		cap      AllowanceLimit
+	}{
+		{"Fixed maximum", AllowanceRule{Type: Spouse, Maximum: 60000 * money.Baht}, 500000 * money.Baht, 60000 * money.Baht, LimitMaximum},
+		{"Configured maximum", AllowanceRule{Type: KReceipt, Configured: true}, 500000 * money.Baht, 50000 * money.Baht, LimitConfigured},
+		{"Income rate below maximum", AllowanceRule{Type: RMF, Maximum: 500000 * money.Baht, IncomeRate: 0.30}, 500000 * money.Baht, 150000 * money.Baht, LimitIncomeRate},
+		{"Income rate above maximum", AllowanceRule{Type: RMF, Maximum: 500000 * money.Baht, IncomeRate: 0.30}, 5000000 * money.Baht, 500000 * money.Baht, LimitMaximum},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			limit, cap := tc.rule.limit(configured, tc.income)
+			assert.Equal(t, tc.expected, limit)
+			assert.Equal(t, tc.cap, cap)
+		})
+	}
+}
+
+func TestAllowanceGroupsAreWithinTheirMembersMaximum(t *testing.T) {
+	for _, rule := range allowanceRules {
+		if rule.Group != nil {
+			assert.LessOrEqual(t, rule.Maximum, rule.Group.Maximum, rule.Type)
+		}
+	}
+}
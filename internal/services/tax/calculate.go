@@ -2,18 +2,81 @@ package tax
 
 import (
 	"context"
+
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+	"github.com/ztrixack/assessment-tax/internal/utils/taxyear"
 )
 
 type CalculateRequest struct {
-	Income     float64
-	WHT        float64
-	Allowances []Allowance
+	TaxYear int
+	// Income is assessable income without a type, from which no expense is deducted.
+	Income  money.Money
+	Incomes []Income
+	WHT     money.Money
+	// Withholdings are amounts of withholding tax in their currency, added to WHT once converted to baht.
+	Withholdings []Withholding
+	Allowances   []Allowance
+	// FilingStatus is single when it is empty.
+	FilingStatus FilingStatus
+	// Residency is resident when it is empty.
+	Residency Residency
+	// Spouse is the income of a spouse with income, required to file jointly or separately.
+	Spouse *SpouseIncome
+	// LumpSums are taxed apart from the other income, and their tax is added to that of the return.
+	LumpSums []LumpSum
+	// Explain asks for the trace of every step of the calculation in the response.
+	Explain bool
 }
 
 type CalculateResponse struct {
-	Tax      float64
-	Refund   float64
-	TaxLevel []float64
+	TaxYear      int
+	FilingStatus FilingStatus
+	Residency    Residency
+	Tax          money.Money
+	Refund       money.Money
+	TaxLevel     []BracketTax
+	Expenses     []IncomeExpense
+	// Method is the method the tax was worked out by, the one with the higher tax.
+	Method         TaxMethod
+	ProgressiveTax money.Money
+	// GrossIncomeTax is zero unless the income other than salary is above GrossIncomeTaxThreshold.
+	GrossIncomeTax money.Money
+	// DividendCredit is the dividend tax credit, credited like the withholding tax.
+	DividendCredit money.Money
+	// ForeignTaxCredit is the foreign tax paid on the foreign-sourced income, credited up to the tax on that income.
+	ForeignTaxCredit money.Money
+	// ExemptForeignIncome is the foreign-sourced income left out of the return of a non-resident.
+	ExemptForeignIncome money.Money
+	// LumpSum is the separate tax of the lump sums, included in the tax, only set when there are any.
+	LumpSum *LumpSumTax
+	// Rounding is the policy the amounts were rounded by.
+	Rounding RoundingPolicy
+	// Filing is the comparison of the joint and separate returns, only set for a filer with a spouse with income.
+	Filing *FilingComparison
+	// FinalWithholding is the comparison of including and leaving out the income taxed at source at a
+	// final rate, only set when there is such income.
+	FinalWithholding *FinalWithholdingComparison
+	// Conversions are the amounts in a foreign currency converted to baht, only set when there are any.
+	Conversions []Conversion
+	// Explanation is only set when the request asks for it.
+	Explanation *Explanation
+}
+
+// Explanation is the trace of every step of a calculation, from the income down to the tax payable.
+type Explanation struct {
+	Income          money.Money
+	TotalExpenses   money.Money
+	Allowances      []AllowanceDeduction
+	TotalAllowances money.Money
+	NetIncome       money.Money
+	// TaxBeforeWHT is the tax of the method used with the tax of the lump sums, before the withholding
+	// tax is credited.
+	TaxBeforeWHT     money.Money
+	WHT              money.Money
+	DividendCredit   money.Money
+	ForeignTaxCredit money.Money
+	// EffectiveRate is the share of the income paid as tax by the method used, apart from the lump sums.
+	EffectiveRate float64
 }
 
 func (s *service) Calculate(ctx context.Context, req CalculateRequest) (*CalculateResponse, error) {
@@ -22,25 +85,162 @@ func (s *service) Calculate(ctx context.Context, req CalculateRequest) (*Calcula
 		return nil, ErrNegativeIncome
 	}
 
-	totalAllowances, err := s.calculateAllowances(req.Allowances)
+	taxYear := taxyear.Resolve(req.TaxYear)
+	configured, brackets, err := s.getSettings(taxYear)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := s.getExchangeRates(taxYear, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.calculateConverted(req, taxYear, configured, brackets, rates)
+}
+
+// calculateConverted works out the tax of the request once its amounts in a foreign currency are converted
+// to baht by the exchange rates of the tax year.
+func (s *service) calculateConverted(req CalculateRequest, taxYear int, configured AllowanceList, brackets []Bracket, rates ExchangeRates) (*CalculateResponse, error) {
+	converted, conversions, err := convertCurrencies(req, rates, taxYear)
+	if err != nil {
+		s.log.Err(err).E("Failed to convert the amounts to baht")
+		return nil, err
+	}
+
+	res, err := s.calculate(converted, taxYear, configured, brackets)
+	if err != nil {
+		return nil, err
+	}
+	res.Conversions = conversions
+
+	return res, nil
+}
+
+// calculate works out the tax of the request with the allowances and brackets configured for the tax year.
+// A filer with a spouse with income has both the joint and the separate returns worked out.
+func (s *service) calculate(req CalculateRequest, taxYear int, configured AllowanceList, brackets []Bracket) (*CalculateResponse, error) {
+	status := resolveFilingStatus(req.FilingStatus)
+	if err := validateFilingStatus(status, req.Spouse); err != nil {
+		s.log.Err(err).Fields(map[string]interface{}{"filingStatus": req.FilingStatus}).E("Invalid filing status")
+		return nil, err
+	}
+
+	if err := validateResidency(resolveResidency(req.Residency), req.Incomes, req.Spouse); err != nil {
+		s.log.Err(err).Fields(map[string]interface{}{"residency": req.Residency}).E("Invalid residency")
+		return nil, err
+	}
+
+	if status.withSpouseIncome() {
+		return s.calculateFilings(req, status, taxYear, configured, brackets)
+	}
+
+	return s.calculateReturn(req, status, taxYear, configured, brackets)
+}
+
+// calculateReturn works out the tax of a single return filed by the status.
+func (s *service) calculateReturn(req CalculateRequest, status FilingStatus, taxYear int, configured AllowanceList, brackets []Bracket) (*CalculateResponse, error) {
+	if hasFinalWithholding(req.Incomes) {
+		return s.calculateFinalWithholding(req, status, taxYear, configured, brackets)
+	}
+
+	residency := resolveResidency(req.Residency)
+	incomes, exemptForeignIncome := excludeForeignIncome(req.Incomes, residency)
+	incomes, dividendCredit, err := grossUpDividends(incomes)
+	if err != nil {
+		s.log.Err(err).Fields(map[string]interface{}{"incomes": req.Incomes}).E("Invalid dividend")
+		return nil, err
+	}
+
+	expenses, err := s.calculateExpenses(incomes)
+	if err != nil {
+		return nil, err
+	}
+
+	income, totalExpenses := req.Income, money.Money(0)
+	for _, expense := range expenses {
+		income += expense.Amount
+		totalExpenses += expense.Expense
+	}
+
+	allowances, err := s.calculateAllowances(configured, status, residency, income, totalExpenses, req.Allowances)
 	if err != nil {
 		return nil, err
 	}
+	totalAllowances := totalAllowances(allowances)
 
-	netIncome := max(req.Income-totalAllowances, 0)
-	totalTax, stepTax, err := calculateProgressiveTax(netIncome)
+	netIncome := max(income-totalExpenses-totalAllowances, 0).Round(s.rounding.NetIncome)
+	totalTax, taxLevels, err := calculateProgressiveTax(netIncome, brackets, s.rounding.TaxLevel)
 	if err != nil {
 		s.log.Fields(map[string]interface{}{
 			"netIncome":       netIncome,
-			"income":          req.Income,
+			"income":          income,
+			"totalExpenses":   totalExpenses,
 			"totalAllowances": totalAllowances,
 		}).Err(err).E("Failed to calculate progressive tax")
 		return nil, err
 	}
 
-	return &CalculateResponse{
-		Tax:      max(totalTax-req.WHT, 0),
-		Refund:   max(req.WHT-totalTax, 0),
-		TaxLevel: stepTax,
-	}, nil
+	totalTax = totalTax.Round(s.rounding.Tax)
+	progressiveTax, method := totalTax, MethodProgressive
+	grossTax, ok := grossIncomeTax(expenses, s.rounding.Tax)
+	if ok && grossTax > totalTax {
+		totalTax, method = grossTax, MethodGrossIncome
+	}
+
+	lumpSum, err := s.calculateLumpSums(req.LumpSums, brackets)
+	if err != nil {
+		return nil, err
+	}
+	taxDue := totalTax
+	if lumpSum != nil {
+		taxDue += lumpSum.Tax
+	}
+
+	// The dividend tax credit is credited against the tax like the withholding tax, and refunded when above it.
+	// The foreign tax credit never is, as it is capped at the tax on the foreign-sourced income.
+	foreignCredit := foreignTaxCredit(incomes, totalTax, income)
+	credits := req.WHT + dividendCredit + foreignCredit
+	res := &CalculateResponse{
+		TaxYear:             taxYear,
+		FilingStatus:        status,
+		Residency:           residency,
+		Tax:                 max(taxDue-credits, 0).Round(s.rounding.Tax),
+		Refund:              max(credits-taxDue, 0).Round(s.rounding.Refund),
+		TaxLevel:            taxLevels,
+		Expenses:            expenses,
+		Method:              method,
+		ProgressiveTax:      progressiveTax,
+		GrossIncomeTax:      grossTax,
+		DividendCredit:      dividendCredit,
+		ForeignTaxCredit:    foreignCredit,
+		ExemptForeignIncome: exemptForeignIncome,
+		LumpSum:             lumpSum,
+		Rounding:            s.rounding,
+	}
+
+	if req.Explain {
+		res.Explanation = &Explanation{
+			Income:           income,
+			TotalExpenses:    totalExpenses,
+			Allowances:       allowances,
+			TotalAllowances:  totalAllowances,
+			NetIncome:        netIncome,
+			TaxBeforeWHT:     taxDue,
+			WHT:              req.WHT,
+			DividendCredit:   dividendCredit,
+			ForeignTaxCredit: foreignCredit,
+			EffectiveRate:    effectiveRate(totalTax, income),
+		}
+	}
+
+	return res, nil
+}
+
+func effectiveRate(tax, income money.Money) float64 {
+	if income == 0 {
+		return 0
+	}
+
+	return float64(tax) / float64(income)
 }
@@ -0,0 +1,35 @@
+//go:build integration
+
+package tax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/modules/database"
+	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestCalculateDonationOfPastTaxYear(t *testing.T) {
+	t.Setenv("DATABASE_URL", "host=postgres-test port=5432 user=test password=test dbname=testdb sslmode=disable")
+	db, err := database.NewPostgresDB(database.Config())
+	if err != nil {
+		t.Fatalf("failed to connect to the database: %v", err)
+	}
+	defer db.Close()
+
+	s := New(logger.NewMockLogger(), db, &config{Rounding: SatangRounding})
+
+	// The 2020 tax year was configured with a fixed donation amount before the donation became a percentage.
+	res, err := s.Calculate(context.Background(), CalculateRequest{
+		TaxYear:    2020,
+		Income:     500000 * money.Baht,
+		Allowances: []Allowance{{Type: Donation, Amount: 100000 * money.Baht}},
+	})
+
+	assert.NoError(t, err)
+	// The donation is capped at 10 percent of the 440,000 baht left after the personal allowance.
+	assert.Equal(t, 24600*money.Baht, res.Tax)
+}
@@ -4,20 +4,23 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
 func TestCalculate(t *testing.T) {
 	defaultMockBehavior := func(mock sqlmock.Sqlmock) {
-		rows := sqlmock.NewRows([]string{"personal", "donation", "k_receipt"}).AddRow(60000, 100000, 50000)
-		mock.ExpectPrepare("SELECT personal, donation, k_receipt FROM allowances").ExpectQuery().WillReturnRows(rows)
+		mockAllowances(mock, 2024)
+		mockBrackets(mock, 2024)
 	}
 
 	tests := []struct {
 		name           string
 		mockBehavior   func(mock sqlmock.Sqlmock)
+		rounding       RoundingPolicy
 		request        CalculateRequest
 		expectedResult *CalculateResponse
 		wantErr        bool
@@ -25,131 +28,381 @@ func TestCalculate(t *testing.T) {
 		{
 			name: "Story: EXP01",
 			request: CalculateRequest{
-				Income:     500000.0,
+				TaxYear:    2024,
+				Income:     500000 * money.Baht,
 				Allowances: []Allowance{},
 			},
 			mockBehavior: defaultMockBehavior,
 			expectedResult: &CalculateResponse{
-				Tax:      29000.0,
-				TaxLevel: []float64{0, 29000, 0, 0, 0},
+				TaxYear:        2024,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Tax:            29000 * money.Baht,
+				TaxLevel:       toTaxLevels(0, 29000, 0, 0, 0),
+				Method:         MethodProgressive,
+				ProgressiveTax: 29000 * money.Baht,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Salary with expense deduction",
+			request: CalculateRequest{
+				TaxYear: 2024,
+				Incomes: []Income{{Type: Salary, Amount: 600000 * money.Baht}},
+			},
+			mockBehavior: defaultMockBehavior,
+			expectedResult: &CalculateResponse{
+				TaxYear:        2024,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Tax:            29000 * money.Baht,
+				TaxLevel:       toTaxLevels(0, 29000, 0, 0, 0),
+				Method:         MethodProgressive,
+				ProgressiveTax: 29000 * money.Baht,
+				Expenses:       []IncomeExpense{{Income: Income{Type: Salary, Amount: 600000 * money.Baht}, Expense: 100000 * money.Baht}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Untyped income with typed incomes",
+			request: CalculateRequest{
+				TaxYear: 2024,
+				Income:  100000 * money.Baht,
+				Incomes: []Income{{Type: Business, Amount: 1000000 * money.Baht}},
+			},
+			mockBehavior: defaultMockBehavior,
+			expectedResult: &CalculateResponse{
+				TaxYear:        2024,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Tax:            29000 * money.Baht,
+				TaxLevel:       toTaxLevels(0, 29000, 0, 0, 0),
+				Method:         MethodProgressive,
+				ProgressiveTax: 29000 * money.Baht,
+				GrossIncomeTax: 5000 * money.Baht,
+				Expenses:       []IncomeExpense{{Income: Income{Type: Business, Amount: 1000000 * money.Baht}, Expense: 600000 * money.Baht}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Gross income tax is higher than the progressive tax",
+			request: CalculateRequest{
+				TaxYear:    2024,
+				Incomes:    []Income{{Type: Salary, Amount: 200000 * money.Baht}, {Type: Business, Amount: 1000000 * money.Baht}},
+				WHT:        1000 * money.Baht,
+				Allowances: []Allowance{{Type: RMF, Amount: 360000 * money.Baht}},
+			},
+			mockBehavior: defaultMockBehavior,
+			expectedResult: &CalculateResponse{
+				TaxYear:        2024,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Tax:            4000 * money.Baht,
+				TaxLevel:       toTaxLevels(0, 0, 0, 0, 0),
+				Method:         MethodGrossIncome,
+				ProgressiveTax: 0,
+				GrossIncomeTax: 5000 * money.Baht,
+				Expenses: []IncomeExpense{
+					{Income: Income{Type: Salary, Amount: 200000 * money.Baht}, Expense: 100000 * money.Baht},
+					{Income: Income{Type: Business, Amount: 1000000 * money.Baht}, Expense: 600000 * money.Baht},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Gross income tax does not apply up to the threshold",
+			request: CalculateRequest{
+				TaxYear: 2024,
+				Incomes: []Income{{Type: Salary, Amount: 100000 * money.Baht}, {Type: Fee, Amount: 120000 * money.Baht}},
+			},
+			mockBehavior: defaultMockBehavior,
+			expectedResult: &CalculateResponse{
+				TaxYear:        2024,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Tax:            0,
+				TaxLevel:       toTaxLevels(0, 0, 0, 0, 0),
+				Method:         MethodProgressive,
+				ProgressiveTax: 0,
+				Expenses: []IncomeExpense{
+					{Income: Income{Type: Salary, Amount: 100000 * money.Baht}, Expense: 50000 * money.Baht},
+					{Income: Income{Type: Fee, Amount: 120000 * money.Baht}, Expense: 50000 * money.Baht},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Explain the calculation",
+			request: CalculateRequest{
+				TaxYear:    2024,
+				Incomes:    []Income{{Type: Salary, Amount: 600000 * money.Baht}},
+				WHT:        25000 * money.Baht,
+				Allowances: []Allowance{{Type: KReceipt, Amount: 80000 * money.Baht}},
+				Explain:    true,
+			},
+			mockBehavior: defaultMockBehavior,
+			expectedResult: &CalculateResponse{
+				TaxYear:        2024,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Tax:            0,
+				Refund:         1000 * money.Baht,
+				TaxLevel:       toTaxLevels(0, 24000, 0, 0, 0),
+				Method:         MethodProgressive,
+				ProgressiveTax: 24000 * money.Baht,
+				Expenses:       []IncomeExpense{{Income: Income{Type: Salary, Amount: 600000 * money.Baht}, Expense: 100000 * money.Baht}},
+				Explanation: &Explanation{
+					Income:        600000 * money.Baht,
+					TotalExpenses: 100000 * money.Baht,
+					Allowances: []AllowanceDeduction{
+						{Type: Personal, Claimed: 60000 * money.Baht, Deducted: 60000 * money.Baht},
+						{Type: KReceipt, Claimed: 80000 * money.Baht, Deducted: 50000 * money.Baht, Limit: LimitConfigured},
+					},
+					TotalAllowances: 110000 * money.Baht,
+					NetIncome:       390000 * money.Baht,
+					TaxBeforeWHT:    24000 * money.Baht,
+					WHT:             25000 * money.Baht,
+					EffectiveRate:   0.04,
+				},
 			},
 			wantErr: false,
 		},
 		{
 			name: "Story: EXP02",
 			request: CalculateRequest{
-				Income:     500000.0,
-				WHT:        25000.0,
+				TaxYear:    2024,
+				Income:     500000 * money.Baht,
+				WHT:        25000 * money.Baht,
 				Allowances: []Allowance{},
 			},
 			mockBehavior: defaultMockBehavior,
 			expectedResult: &CalculateResponse{
-				Tax:      4000.0,
-				TaxLevel: []float64{0, 29000, 0, 0, 0},
+				TaxYear:        2024,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Tax:            4000 * money.Baht,
+				TaxLevel:       toTaxLevels(0, 29000, 0, 0, 0),
+				Method:         MethodProgressive,
+				ProgressiveTax: 29000 * money.Baht,
 			},
 			wantErr: false,
 		},
 		{
 			name: "Story: EXP03",
 			request: CalculateRequest{
-				Income:     500000.0,
-				WHT:        0.0,
-				Allowances: []Allowance{{Type: Donation, Amount: 200000.0}},
+				TaxYear:    2024,
+				Income:     500000 * money.Baht,
+				WHT:        0,
+				Allowances: []Allowance{{Type: Donation, Amount: 200000 * money.Baht}},
 			},
 			mockBehavior: defaultMockBehavior,
 			expectedResult: &CalculateResponse{
-				Tax:      19000.0,
-				TaxLevel: []float64{0, 19000, 0, 0, 0},
+				TaxYear:        2024,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Tax:            24600 * money.Baht,
+				TaxLevel:       toTaxLevels(0, 24600, 0, 0, 0),
+				Method:         MethodProgressive,
+				ProgressiveTax: 24600 * money.Baht,
 			},
 			wantErr: false,
 		},
 		{
 			name: "Story: EXP07",
 			request: CalculateRequest{
-				Income:     500000.0,
-				WHT:        0.0,
-				Allowances: []Allowance{{Type: KReceipt, Amount: 200000.0}, {Type: Donation, Amount: 100000.0}},
+				TaxYear:    2024,
+				Income:     500000 * money.Baht,
+				WHT:        0,
+				Allowances: []Allowance{{Type: KReceipt, Amount: 200000 * money.Baht}, {Type: Donation, Amount: 100000 * money.Baht}},
 			},
 			mockBehavior: defaultMockBehavior,
 			expectedResult: &CalculateResponse{
-				Tax:      14000.0,
-				TaxLevel: []float64{0, 14000, 0, 0, 0},
+				TaxYear:        2024,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Tax:            20100 * money.Baht,
+				TaxLevel:       toTaxLevels(0, 20100, 0, 0, 0),
+				Method:         MethodProgressive,
+				ProgressiveTax: 20100 * money.Baht,
 			},
 			wantErr: false,
 		},
 		{
 			name: "Normal case",
 			request: CalculateRequest{
-				Income:     1000000.0,
+				TaxYear:    2024,
+				Income:     1000000 * money.Baht,
 				Allowances: []Allowance{},
 			},
 			mockBehavior: defaultMockBehavior,
 			expectedResult: &CalculateResponse{
-				Tax:      101000.0,
-				TaxLevel: []float64{0, 35000, 66000, 0, 0},
+				TaxYear:        2024,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Tax:            101000 * money.Baht,
+				TaxLevel:       toTaxLevels(0, 35000, 66000, 0, 0),
+				Method:         MethodProgressive,
+				ProgressiveTax: 101000 * money.Baht,
 			},
 			wantErr: false,
 		},
 		{
 			name: "WHT more than tax (Refund)",
 			request: CalculateRequest{
-				Income:     500000.0,
-				WHT:        30000.0,
+				TaxYear:    2024,
+				Income:     500000 * money.Baht,
+				WHT:        30000 * money.Baht,
 				Allowances: []Allowance{},
 			},
 			mockBehavior: defaultMockBehavior,
 			expectedResult: &CalculateResponse{
-				Tax:      0.0,
-				Refund:   1000.0,
-				TaxLevel: []float64{0, 29000, 0, 0, 0},
+				TaxYear:        2024,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Tax:            0,
+				Refund:         1000 * money.Baht,
+				TaxLevel:       toTaxLevels(0, 29000, 0, 0, 0),
+				Method:         MethodProgressive,
+				ProgressiveTax: 29000 * money.Baht,
 			},
 			wantErr: false,
 		},
 		{
 			name: "WHT less than tax",
 			request: CalculateRequest{
-				Income:     500000.0,
-				WHT:        20000.0,
+				TaxYear:    2024,
+				Income:     500000 * money.Baht,
+				WHT:        20000 * money.Baht,
 				Allowances: []Allowance{},
 			},
 			mockBehavior: defaultMockBehavior,
 			expectedResult: &CalculateResponse{
-				Tax:      9000.0,
-				Refund:   0.0,
-				TaxLevel: []float64{0, 29000, 0, 0, 0},
+				TaxYear:        2024,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Tax:            9000 * money.Baht,
+				Refund:         0,
+				TaxLevel:       toTaxLevels(0, 29000, 0, 0, 0),
+				Method:         MethodProgressive,
+				ProgressiveTax: 29000 * money.Baht,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Satang are rounded half up by default",
+			request: CalculateRequest{
+				TaxYear: 2024,
+				Income:  500000*money.Baht + 15*money.Satang,
+			},
+			mockBehavior: defaultMockBehavior,
+			expectedResult: &CalculateResponse{
+				TaxYear:        2024,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Tax:            money.FromBaht(29000.02),
+				TaxLevel:       toTaxLevels(0, 29000.02, 0, 0, 0),
+				Method:         MethodProgressive,
+				ProgressiveTax: money.FromBaht(29000.02),
+			},
+			wantErr: false,
+		},
+		{
+			name:     "Revenue department rounding truncates the net income",
+			rounding: RevenueDepartmentRounding,
+			request: CalculateRequest{
+				TaxYear: 2024,
+				Income:  500000*money.Baht + 99*money.Satang,
+				WHT:     money.FromBaht(29500.55),
+			},
+			mockBehavior: defaultMockBehavior,
+			expectedResult: &CalculateResponse{
+				TaxYear:        2024,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Refund:         money.FromBaht(500.55),
+				TaxLevel:       toTaxLevels(0, 29000, 0, 0, 0),
+				Method:         MethodProgressive,
+				ProgressiveTax: 29000 * money.Baht,
 			},
 			wantErr: false,
 		},
 		{
 			name: "Income more than Allowance",
 			request: CalculateRequest{
-				Income:     500000.0,
-				Allowances: []Allowance{{Type: Donation, Amount: 1000.0}},
+				TaxYear:    2024,
+				Income:     500000 * money.Baht,
+				Allowances: []Allowance{{Type: Donation, Amount: 1000 * money.Baht}},
 			},
 			mockBehavior: defaultMockBehavior,
 			expectedResult: &CalculateResponse{
-				Tax:      28900.0,
-				TaxLevel: []float64{0, 28900, 0, 0, 0},
+				TaxYear:        2024,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Tax:            28900 * money.Baht,
+				TaxLevel:       toTaxLevels(0, 28900, 0, 0, 0),
+				Method:         MethodProgressive,
+				ProgressiveTax: 28900 * money.Baht,
 			},
 			wantErr: false,
 		},
 		{
 			name: "Income is lower than all allowances",
 			request: CalculateRequest{
-				Income:     50000.0,
+				TaxYear:    2024,
+				Income:     50000 * money.Baht,
 				Allowances: []Allowance{},
 			},
 			mockBehavior: defaultMockBehavior,
 			expectedResult: &CalculateResponse{
-				Tax:      0.0,
-				TaxLevel: []float64{0, 0, 0, 0, 0},
+				TaxYear:        2024,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Tax:            0,
+				TaxLevel:       toTaxLevels(0, 0, 0, 0, 0),
+				Method:         MethodProgressive,
+				ProgressiveTax: 0,
 			},
 			wantErr: false,
 		},
+		{
+			name: "Past tax year",
+			request: CalculateRequest{
+				TaxYear: 2023,
+				Income:  500000 * money.Baht,
+			},
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mockAllowances(mock, 2023)
+				mockBrackets(mock, 2023)
+			},
+			expectedResult: &CalculateResponse{
+				TaxYear:        2023,
+				FilingStatus:   Single,
+				Residency:      Resident,
+				Tax:            29000 * money.Baht,
+				TaxLevel:       toTaxLevels(0, 29000, 0, 0, 0),
+				Method:         MethodProgressive,
+				ProgressiveTax: 29000 * money.Baht,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Tax year without allowances",
+			request: CalculateRequest{
+				TaxYear: 2016,
+				Income:  500000 * money.Baht,
+			},
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"type", "amount"})
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").ExpectQuery().WithArgs(2016, sqlmock.AnyArg()).WillReturnRows(rows)
+			},
+			expectedResult: nil,
+			wantErr:        true,
+		},
 		{
 			name: "error in tax calculation",
 			request: CalculateRequest{
-				Income:     -1,
+				TaxYear:    2024,
+				Income:     -1 * money.Baht,
 				Allowances: []Allowance{},
 			},
 			mockBehavior: func(mock sqlmock.Sqlmock) {
@@ -158,26 +411,79 @@ func TestCalculate(t *testing.T) {
 			expectedResult: nil,
 			wantErr:        true,
 		},
+		{
+			name: "error in tax brackets",
+			request: CalculateRequest{
+				TaxYear:    2024,
+				Income:     500000 * money.Baht,
+				Allowances: []Allowance{},
+			},
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mockAllowances(mock, 2024)
+				mock.ExpectPrepare("SELECT lower_bound, upper_bound, rate FROM tax_brackets").ExpectQuery().WithArgs(2024).WillReturnError(errors.New("some error"))
+			},
+			expectedResult: nil,
+			wantErr:        true,
+		},
+		{
+			name: "Negative typed income",
+			request: CalculateRequest{
+				TaxYear: 2024,
+				Incomes: []Income{{Type: Salary, Amount: -1 * money.Baht}},
+			},
+			mockBehavior:   defaultMockBehavior,
+			expectedResult: nil,
+			wantErr:        true,
+		},
+		{
+			name: "Unsupported income type",
+			request: CalculateRequest{
+				TaxYear: 2024,
+				Incomes: []Income{{Type: "40(9)", Amount: 100000 * money.Baht}},
+			},
+			mockBehavior:   defaultMockBehavior,
+			expectedResult: nil,
+			wantErr:        true,
+		},
 		{
 			name: "error in database",
 			request: CalculateRequest{
-				Income:     500000.0,
+				TaxYear:    2024,
+				Income:     500000 * money.Baht,
 				Allowances: []Allowance{},
 			},
 			mockBehavior: func(mock sqlmock.Sqlmock) {
-				mock.ExpectPrepare("SELECT personal, donation, k_receipt FROM allowances").ExpectQuery().WillReturnError(errors.New("some error"))
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").ExpectQuery().WithArgs(2024, sqlmock.AnyArg()).WillReturnError(errors.New("some error"))
 			},
 			expectedResult: nil,
 			wantErr:        true,
 		},
 	}
 
+	t.Run("Default to the current tax year", func(t *testing.T) {
+		svr, mock, close := setup(t)
+		defer close()
+
+		taxYear := time.Now().Year()
+		mockAllowances(mock, taxYear)
+		mockBrackets(mock, taxYear)
+
+		result, err := svr.Calculate(context.Background(), CalculateRequest{Income: 500000 * money.Baht})
+
+		assert.NoError(t, err)
+		assert.Equal(t, taxYear, result.TaxYear)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := context.Background()
 			svr, mock, close := setup(t)
 			defer close()
 
+			if tt.rounding.Name != "" {
+				svr.rounding = tt.rounding
+			}
 			tt.mockBehavior(mock)
 
 			result, err := svr.Calculate(ctx, tt.request)
@@ -186,6 +492,7 @@ func TestCalculate(t *testing.T) {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
+				tt.expectedResult.Rounding = svr.rounding
 				assert.Equal(t, tt.expectedResult, result)
 			}
 
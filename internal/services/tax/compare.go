@@ -0,0 +1,157 @@
+package tax
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+	"github.com/ztrixack/assessment-tax/internal/utils/taxyear"
+)
+
+var (
+	ErrNoScenarios          = fmt.Errorf("no scenarios to compare")
+	ErrUnnamedScenario      = fmt.Errorf("scenario must have a name")
+	ErrDuplicateScenario    = fmt.Errorf("scenario names must be unique")
+	ErrInvalidScenarioRaise = fmt.Errorf("scenario raise must be above -1")
+)
+
+// Scenario is a change to the base of a comparison. Its incomes, withholding tax and allowances
+// are added to those of the base.
+type Scenario struct {
+	Name       string
+	Income     money.Money
+	Incomes    []Income
+	WHT        money.Money
+	Allowances []Allowance
+	// Raise changes every income of the base by a share, such as 0.1 for 10% more, before the
+	// incomes of the scenario are added.
+	Raise float64
+}
+
+type CompareRequest struct {
+	Base      CalculateRequest
+	Scenarios []Scenario
+}
+
+type CompareResponse struct {
+	Base      CalculateResponse
+	Scenarios []ScenarioResult
+}
+
+// ScenarioResult is the calculation of a scenario, with the changes from the base.
+type ScenarioResult struct {
+	Name        string
+	Calculation CalculateResponse
+	TaxDelta    money.Money
+	RefundDelta money.Money
+	// TotalTaxDelta is the change of the tax before the withholding tax is credited.
+	TotalTaxDelta money.Money
+}
+
+// Compare calculates the base and every scenario against the same allowances and brackets,
+// loaded once, so that a change of the settings cannot land between two of them.
+func (s *service) Compare(ctx context.Context, req CompareRequest) (*CompareResponse, error) {
+	if err := validateScenarios(req.Scenarios); err != nil {
+		s.log.Err(err).E("Invalid scenarios")
+		return nil, err
+	}
+
+	if req.Base.Income < 0 {
+		s.log.Fields(map[string]interface{}{"income": req.Base.Income}).E("Income cannot be negative")
+		return nil, ErrNegativeIncome
+	}
+
+	taxYear := taxyear.Resolve(req.Base.TaxYear)
+	configured, brackets, err := s.getSettings(taxYear)
+	if err != nil {
+		return nil, err
+	}
+
+	sreqs := make([]CalculateRequest, len(req.Scenarios))
+	for i, scenario := range req.Scenarios {
+		sreqs[i] = scenario.apply(req.Base)
+	}
+
+	rates, err := s.getExchangeRates(taxYear, append([]CalculateRequest{req.Base}, sreqs...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := s.calculateConverted(req.Base, taxYear, configured, brackets, rates)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ScenarioResult, len(req.Scenarios))
+	for i, scenario := range req.Scenarios {
+		sreq := sreqs[i]
+		if sreq.Income < 0 {
+			s.log.Fields(map[string]interface{}{"scenario": scenario.Name, "income": sreq.Income}).E("Income cannot be negative")
+			return nil, ErrNegativeIncome
+		}
+
+		res, err := s.calculateConverted(sreq, taxYear, configured, brackets, rates)
+		if err != nil {
+			s.log.Err(err).Fields(map[string]interface{}{"scenario": scenario.Name}).E("Failed to calculate scenario")
+			return nil, err
+		}
+
+		results[i] = ScenarioResult{
+			Name:          scenario.Name,
+			Calculation:   *res,
+			TaxDelta:      res.Tax - base.Tax,
+			RefundDelta:   res.Refund - base.Refund,
+			TotalTaxDelta: res.totalTax() - base.totalTax(),
+		}
+	}
+
+	return &CompareResponse{Base: *base, Scenarios: results}, nil
+}
+
+func validateScenarios(scenarios []Scenario) error {
+	if len(scenarios) == 0 {
+		return ErrNoScenarios
+	}
+
+	names := make(map[string]bool, len(scenarios))
+	for _, scenario := range scenarios {
+		if scenario.Name == "" {
+			return ErrUnnamedScenario
+		}
+		if names[scenario.Name] {
+			return fmt.Errorf("%w: %q", ErrDuplicateScenario, scenario.Name)
+		}
+		if scenario.Raise <= -1 {
+			return fmt.Errorf("%w: %q", ErrInvalidScenarioRaise, scenario.Name)
+		}
+		names[scenario.Name] = true
+	}
+
+	return nil
+}
+
+// apply returns the request of the base changed by the scenario.
+func (sc Scenario) apply(base CalculateRequest) CalculateRequest {
+	req := CalculateRequest{
+		TaxYear: base.TaxYear,
+		Income:  base.Income.Mul(1+sc.Raise) + sc.Income,
+		WHT:     base.WHT + sc.WHT,
+		Explain: base.Explain,
+		// The spouse, the lump sums and the withholding tax in foreign currencies are kept as in the base,
+		// as scenarios only change the income of the return.
+		FilingStatus: base.FilingStatus,
+		Residency:    base.Residency,
+		Spouse:       base.Spouse,
+		LumpSums:     base.LumpSums,
+		Withholdings: base.Withholdings,
+	}
+
+	for _, income := range base.Incomes {
+		income.Amount = income.Amount.Mul(1 + sc.Raise)
+		req.Incomes = append(req.Incomes, income)
+	}
+	req.Incomes = append(req.Incomes, sc.Incomes...)
+	req.Allowances = append(append(req.Allowances, base.Allowances...), sc.Allowances...)
+
+	return req
+}
@@ -0,0 +1,180 @@
+package tax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestCompare(t *testing.T) {
+	defaultMockBehavior := func(mock sqlmock.Sqlmock) {
+		mockAllowances(mock, 2024)
+		mockBrackets(mock, 2024)
+	}
+
+	base := CalculateRequest{TaxYear: 2024, Income: 500000 * money.Baht}
+
+	type delta struct {
+		name     string
+		tax      money.Money
+		refund   money.Money
+		totalTax money.Money
+	}
+
+	tests := []struct {
+		name            string
+		mockBehavior    func(mock sqlmock.Sqlmock)
+		request         CompareRequest
+		expectedBaseTax money.Money
+		expectedDeltas  []delta
+		expectedErr     error
+	}{
+		{
+			name:         "What-if scenarios against the base",
+			mockBehavior: defaultMockBehavior,
+			request: CompareRequest{
+				Base: base,
+				Scenarios: []Scenario{
+					{Name: "+50k donation", Allowances: []Allowance{{Type: Donation, Amount: 50000 * money.Baht}}},
+					{Name: "+k-receipt 50k", Allowances: []Allowance{{Type: KReceipt, Amount: 50000 * money.Baht}}},
+					{Name: "raise 10%", Raise: 0.1},
+				},
+			},
+			expectedBaseTax: 29000 * money.Baht,
+			expectedDeltas: []delta{
+				{"+50k donation", -4400 * money.Baht, 0, -4400 * money.Baht},
+				{"+k-receipt 50k", -5000 * money.Baht, 0, -5000 * money.Baht},
+				{"raise 10%", 5000 * money.Baht, 0, 5000 * money.Baht},
+			},
+		},
+		{
+			name:         "Scenarios with withholding tax and typed incomes",
+			mockBehavior: defaultMockBehavior,
+			request: CompareRequest{
+				Base: CalculateRequest{TaxYear: 2024, Incomes: []Income{{Type: Salary, Amount: 600000 * money.Baht}}, WHT: 30000 * money.Baht},
+				Scenarios: []Scenario{
+					{Name: "raise 10%", Raise: 0.1},
+					{Name: "side job", Incomes: []Income{{Type: Fee, Amount: 100000 * money.Baht}}, WHT: 3000 * money.Baht},
+				},
+			},
+			expectedDeltas: []delta{
+				{"raise 10%", 5000 * money.Baht, -1000 * money.Baht, 6000 * money.Baht},
+				{"side job", 8000 * money.Baht, -1000 * money.Baht, 12000 * money.Baht},
+			},
+		},
+		{
+			name:         "Scenarios keep the income taxed at source and the lump sums of the base",
+			mockBehavior: defaultMockBehavior,
+			request: CompareRequest{
+				Base: CalculateRequest{
+					TaxYear:  2024,
+					Income:   3000000 * money.Baht,
+					Incomes:  []Income{{Type: Interest, Amount: 100000 * money.Baht, FinalWithholding: true}},
+					LumpSums: []LumpSum{{Type: Severance, Amount: 1000000 * money.Baht, YearsOfService: 10}},
+				},
+				Scenarios: []Scenario{
+					{Name: "+k-receipt 50k", Allowances: []Allowance{{Type: KReceipt, Amount: 50000 * money.Baht}}},
+				},
+			},
+			expectedBaseTax: (639000 + 31500) * money.Baht,
+			expectedDeltas: []delta{
+				{"+k-receipt 50k", -17500 * money.Baht, 0, -17500 * money.Baht},
+			},
+		},
+		{
+			name: "Scenarios keep the withholding tax in a foreign currency of the base",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				defaultMockBehavior(mock)
+				mockExchangeRates(mock, 2024)
+			},
+			request: CompareRequest{
+				Base: CalculateRequest{
+					TaxYear:      2024,
+					Income:       500000 * money.Baht,
+					Withholdings: []Withholding{{Amount: 1000 * money.Baht, Currency: "USD"}},
+				},
+				Scenarios: []Scenario{
+					{Name: "+k-receipt 50k", Allowances: []Allowance{{Type: KReceipt, Amount: 50000 * money.Baht}}},
+				},
+			},
+			expectedBaseTax: 0,
+			expectedDeltas: []delta{
+				{"+k-receipt 50k", 0, 5000 * money.Baht, -5000 * money.Baht},
+			},
+		},
+		{
+			name:         "No scenarios",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      CompareRequest{Base: base},
+			expectedErr:  ErrNoScenarios,
+		},
+		{
+			name:         "Unnamed scenario",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      CompareRequest{Base: base, Scenarios: []Scenario{{Raise: 0.1}}},
+			expectedErr:  ErrUnnamedScenario,
+		},
+		{
+			name:         "Duplicate scenario names",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      CompareRequest{Base: base, Scenarios: []Scenario{{Name: "raise", Raise: 0.1}, {Name: "raise", Raise: 0.2}}},
+			expectedErr:  ErrDuplicateScenario,
+		},
+		{
+			name:         "Raise removes the whole income",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      CompareRequest{Base: base, Scenarios: []Scenario{{Name: "unpaid", Raise: -1}}},
+			expectedErr:  ErrInvalidScenarioRaise,
+		},
+		{
+			name:         "Negative base income",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      CompareRequest{Base: CalculateRequest{Income: -1 * money.Baht}, Scenarios: []Scenario{{Name: "raise", Raise: 0.1}}},
+			expectedErr:  ErrNegativeIncome,
+		},
+		{
+			name:         "Unsupported allowance in a scenario",
+			mockBehavior: defaultMockBehavior,
+			request:      CompareRequest{Base: base, Scenarios: []Scenario{{Name: "lottery", Allowances: []Allowance{{Type: "lottery", Amount: 1000 * money.Baht}}}}},
+			expectedErr:  ErrUnsupportedAllowanceType,
+		},
+		{
+			name: "Error in database",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").ExpectQuery().WithArgs(2024, sqlmock.AnyArg()).WillReturnError(assert.AnError)
+			},
+			request:     CompareRequest{Base: base, Scenarios: []Scenario{{Name: "raise", Raise: 0.1}}},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr, mock, close := setup(t)
+			defer close()
+
+			tt.mockBehavior(mock)
+
+			result, err := svr.Compare(context.Background(), tt.request)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedBaseTax, result.Base.Tax)
+
+				deltas := make([]delta, len(result.Scenarios))
+				for i, s := range result.Scenarios {
+					deltas[i] = delta{s.Name, s.TaxDelta, s.RefundDelta, s.TotalTaxDelta}
+				}
+				assert.Equal(t, tt.expectedDeltas, deltas)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
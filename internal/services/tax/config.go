@@ -0,0 +1,20 @@
+package tax
+
+import "os"
+
+const DEFAULT_ROUNDING_POLICY = "satang"
+
+type config struct {
+	Rounding RoundingPolicy
+}
+
+func Config() *config {
+	rounding, ok := findRoundingPolicy(os.Getenv("TAX_ROUNDING_POLICY"))
+	if !ok {
+		rounding, _ = findRoundingPolicy(DEFAULT_ROUNDING_POLICY)
+	}
+
+	return &config{
+		Rounding: rounding,
+	}
+}
@@ -0,0 +1,45 @@
+package tax
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected RoundingPolicy
+	}{
+		{
+			name:     "Revenue department rounding",
+			env:      map[string]string{"TAX_ROUNDING_POLICY": "revenue-department"},
+			expected: RevenueDepartmentRounding,
+		},
+		{
+			name:     "No rounding policy set",
+			env:      map[string]string{},
+			expected: SatangRounding,
+		},
+		{
+			name:     "Unknown rounding policy",
+			env:      map[string]string{"TAX_ROUNDING_POLICY": "bankers"},
+			expected: SatangRounding,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				os.Setenv(key, value)
+				defer os.Unsetenv(key)
+			}
+
+			c := Config()
+
+			assert.Equal(t, tt.expected, c.Rounding)
+		})
+	}
+}
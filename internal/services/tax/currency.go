@@ -0,0 +1,229 @@
+package tax
+
+import (
+	"fmt"
+
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+// Currency is the ISO 4217 code of the currency of an amount.
+type Currency string
+
+// THB is the currency of every amount without a currency.
+const THB Currency = "THB"
+
+// ExchangeRates are the Bank of Thailand average rates of a tax year in baht per unit, by currency.
+type ExchangeRates map[Currency]float64
+
+// ConvertedItem is the kind of amount converted to baht.
+type ConvertedItem string
+
+const (
+	ConvertedIncome     ConvertedItem = "income"
+	ConvertedForeignTax ConvertedItem = "foreign-tax"
+	ConvertedWHT        ConvertedItem = "wht"
+)
+
+var (
+	ErrInvalidCurrency = fmt.Errorf("currency must be an ISO 4217 code")
+	ErrNoExchangeRate  = fmt.Errorf("no exchange rate configured")
+)
+
+// Withholding is an amount of withholding tax in its currency, added to the withholding tax in baht.
+type Withholding struct {
+	Amount   money.Money
+	Currency Currency
+}
+
+// Conversion is an amount in a foreign currency converted to baht by the exchange rate of the tax year.
+type Conversion struct {
+	Item ConvertedItem
+	// IncomeType is the type of the income converted, or the income the foreign tax was paid on.
+	IncomeType IncomeType
+	// Spouse marks an amount of the spouse with income.
+	Spouse    bool
+	Currency  Currency
+	Amount    money.Money
+	Rate      float64
+	Converted money.Money
+}
+
+// IsCurrency reports whether the code is an ISO 4217 code of three upper case letters.
+func IsCurrency(code Currency) bool {
+	if len(code) != 3 {
+		return false
+	}
+
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isForeign reports whether the amount is in a currency other than the baht.
+func (c Currency) isForeign() bool {
+	return c != "" && c != THB
+}
+
+// currencies returns the foreign currencies of the amounts of the requests.
+func currencies(reqs ...CalculateRequest) []Currency {
+	var found []Currency
+	seen := make(map[Currency]bool)
+	add := func(currency Currency) {
+		if currency.isForeign() && !seen[currency] {
+			seen[currency] = true
+			found = append(found, currency)
+		}
+	}
+
+	for _, req := range reqs {
+		for _, income := range req.Incomes {
+			add(income.Currency)
+		}
+		for _, wht := range req.Withholdings {
+			add(wht.Currency)
+		}
+		if req.Spouse != nil {
+			for _, income := range req.Spouse.Incomes {
+				add(income.Currency)
+			}
+		}
+	}
+
+	return found
+}
+
+// getExchangeRates returns the exchange rates of the tax year when any of the requests has an amount
+// in a foreign currency, and none otherwise, so that requests in baht never read them.
+func (s *service) getExchangeRates(taxYear int, reqs ...CalculateRequest) (ExchangeRates, error) {
+	if len(currencies(reqs...)) == 0 {
+		return nil, nil
+	}
+
+	rates, err := s.queryExchangeRates(taxYear)
+	if err != nil {
+		s.log.Err(err).E("Failed to get exchange rates from database.")
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+func (s *service) queryExchangeRates(taxYear int) (ExchangeRates, error) {
+	rows, err := s.db.Query(`SELECT currency, rate FROM exchange_rates
+		WHERE tax_year = $1 AND deleted_at IS NULL`, taxYear)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates := ExchangeRates{}
+	for rows.Next() {
+		var currency Currency
+		var rate float64
+		if err := rows.Scan(&currency, &rate); err != nil {
+			return nil, err
+		}
+		rates[currency] = rate
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// convertCurrencies returns the request with every amount in a foreign currency converted to baht, rounded
+// to the satang, and the conversions. The foreign tax of an income is in the currency of the income, and
+// the withholding tax items are added to the withholding tax.
+func convertCurrencies(req CalculateRequest, rates ExchangeRates, taxYear int) (CalculateRequest, []Conversion, error) {
+	var conversions []Conversion
+	convert := func(item ConvertedItem, itype IncomeType, spouse bool, currency Currency, amount money.Money) (money.Money, error) {
+		if !currency.isForeign() {
+			if currency != "" && !IsCurrency(currency) {
+				return 0, fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+			}
+			return amount, nil
+		}
+
+		if !IsCurrency(currency) {
+			return 0, fmt.Errorf("%w: %q", ErrInvalidCurrency, currency)
+		}
+
+		rate, ok := rates[currency]
+		if !ok {
+			return 0, fmt.Errorf("%w: %s for the %d tax year", ErrNoExchangeRate, currency, taxYear)
+		}
+
+		converted := amount.Mul(rate)
+		conversions = append(conversions, Conversion{
+			Item:       item,
+			IncomeType: itype,
+			Spouse:     spouse,
+			Currency:   currency,
+			Amount:     amount,
+			Rate:       rate,
+			Converted:  converted,
+		})
+
+		return converted, nil
+	}
+
+	convertIncomes := func(incomes []Income, spouse bool) ([]Income, error) {
+		if incomes == nil {
+			return nil, nil
+		}
+
+		result := make([]Income, len(incomes))
+		for i, income := range incomes {
+			amount, err := convert(ConvertedIncome, income.Type, spouse, income.Currency, income.Amount)
+			if err != nil {
+				return nil, err
+			}
+
+			if income.ForeignTax != 0 {
+				if income.ForeignTax, err = convert(ConvertedForeignTax, income.Type, spouse, income.Currency, income.ForeignTax); err != nil {
+					return nil, err
+				}
+			}
+
+			income.Amount, income.Currency = amount, ""
+			result[i] = income
+		}
+
+		return result, nil
+	}
+
+	converted := req
+	incomes, err := convertIncomes(req.Incomes, false)
+	if err != nil {
+		return req, nil, err
+	}
+	converted.Incomes = incomes
+
+	for _, wht := range req.Withholdings {
+		if wht.Amount < 0 {
+			return req, nil, ErrNegativeWithholding
+		}
+
+		amount, err := convert(ConvertedWHT, "", false, wht.Currency, wht.Amount)
+		if err != nil {
+			return req, nil, err
+		}
+		converted.WHT += amount
+	}
+	converted.Withholdings = nil
+
+	if req.Spouse != nil {
+		spouse := *req.Spouse
+		if spouse.Incomes, err = convertIncomes(req.Spouse.Incomes, true); err != nil {
+			return req, nil, err
+		}
+		converted.Spouse = &spouse
+	}
+
+	return converted, conversions, nil
+}
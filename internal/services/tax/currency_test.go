@@ -0,0 +1,155 @@
+package tax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func mockExchangeRates(mock sqlmock.Sqlmock, taxYear int) {
+	rows := sqlmock.NewRows([]string{"currency", "rate"}).
+		AddRow("JPY", 0.2436).
+		AddRow("USD", 35.5)
+	mock.ExpectPrepare("SELECT currency, rate FROM exchange_rates").ExpectQuery().WithArgs(taxYear).WillReturnRows(rows)
+}
+
+func TestConvertCurrencies(t *testing.T) {
+	rates := ExchangeRates{"JPY": 0.2436, "USD": 35.5}
+
+	tests := []struct {
+		name                string
+		request             CalculateRequest
+		expected            CalculateRequest
+		expectedConversions []Conversion
+		expectedErr         error
+	}{
+		{
+			name:     "Amounts in baht are left as they are",
+			request:  CalculateRequest{Incomes: []Income{{Type: Salary, Amount: 500000 * money.Baht, Currency: THB}}, WHT: 1000 * money.Baht, Withholdings: []Withholding{{Amount: 500 * money.Baht}}},
+			expected: CalculateRequest{Incomes: []Income{{Type: Salary, Amount: 500000 * money.Baht}}, WHT: 1500 * money.Baht},
+		},
+		{
+			name: "Foreign income with its foreign tax",
+			request: CalculateRequest{Incomes: []Income{
+				{Type: Salary, Amount: 1000000 * money.Baht, Currency: "JPY", Foreign: true, ForeignTax: 100000 * money.Baht},
+			}},
+			expected: CalculateRequest{Incomes: []Income{
+				{Type: Salary, Amount: 243600 * money.Baht, Foreign: true, ForeignTax: 24360 * money.Baht},
+			}},
+			expectedConversions: []Conversion{
+				{Item: ConvertedIncome, IncomeType: Salary, Currency: "JPY", Amount: 1000000 * money.Baht, Rate: 0.2436, Converted: 243600 * money.Baht},
+				{Item: ConvertedForeignTax, IncomeType: Salary, Currency: "JPY", Amount: 100000 * money.Baht, Rate: 0.2436, Converted: 24360 * money.Baht},
+			},
+		},
+		{
+			name: "Withholding and spouse income in dollars",
+			request: CalculateRequest{
+				WHT:          1000 * money.Baht,
+				Withholdings: []Withholding{{Amount: money.FromBaht(100.01), Currency: "USD"}},
+				Spouse:       &SpouseIncome{Incomes: []Income{{Type: Fee, Amount: 2000 * money.Baht, Currency: "USD"}}},
+			},
+			expected: CalculateRequest{
+				WHT:    money.FromBaht(4550.36),
+				Spouse: &SpouseIncome{Incomes: []Income{{Type: Fee, Amount: 71000 * money.Baht}}},
+			},
+			expectedConversions: []Conversion{
+				{Item: ConvertedWHT, Currency: "USD", Amount: money.FromBaht(100.01), Rate: 35.5, Converted: money.FromBaht(3550.36)},
+				{Item: ConvertedIncome, IncomeType: Fee, Spouse: true, Currency: "USD", Amount: 2000 * money.Baht, Rate: 35.5, Converted: 71000 * money.Baht},
+			},
+		},
+		{
+			name:        "No exchange rate for the currency",
+			request:     CalculateRequest{Incomes: []Income{{Type: Salary, Amount: 1000 * money.Baht, Currency: "EUR"}}},
+			expectedErr: ErrNoExchangeRate,
+		},
+		{
+			name:        "Invalid currency",
+			request:     CalculateRequest{Incomes: []Income{{Type: Salary, Amount: 1000 * money.Baht, Currency: "usd"}}},
+			expectedErr: ErrInvalidCurrency,
+		},
+		{
+			name:        "Negative withholding",
+			request:     CalculateRequest{Withholdings: []Withholding{{Amount: -money.Baht, Currency: "USD"}}},
+			expectedErr: ErrNegativeWithholding,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, conversions, err := convertCurrencies(tt.request, rates, 2024)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				assert.Equal(t, tt.expected, result)
+				assert.Equal(t, tt.expectedConversions, conversions)
+			}
+		})
+	}
+}
+
+func TestCalculateCurrencies(t *testing.T) {
+	tests := []struct {
+		name                string
+		request             CalculateRequest
+		mockBehavior        func(mock sqlmock.Sqlmock)
+		expectedTax         money.Money
+		expectedConversions int
+		expectedErr         error
+	}{
+		{
+			name:    "Income in baht does not read the exchange rates",
+			request: CalculateRequest{TaxYear: 2024, Incomes: []Income{{Type: Salary, Amount: 710000 * money.Baht}}, WHT: 35500 * money.Baht},
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mockAllowances(mock, 2024)
+				mockBrackets(mock, 2024)
+			},
+			expectedTax: 7000 * money.Baht,
+		},
+		{
+			name: "Salary and withholding in dollars",
+			request: CalculateRequest{
+				TaxYear:      2024,
+				Incomes:      []Income{{Type: Salary, Amount: 20000 * money.Baht, Currency: "USD"}},
+				Withholdings: []Withholding{{Amount: 1000 * money.Baht, Currency: "USD"}},
+			},
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mockAllowances(mock, 2024)
+				mockBrackets(mock, 2024)
+				mockExchangeRates(mock, 2024)
+			},
+			expectedTax:         7000 * money.Baht,
+			expectedConversions: 2,
+		},
+		{
+			name:    "No exchange rate for the tax year",
+			request: CalculateRequest{TaxYear: 2024, Incomes: []Income{{Type: Salary, Amount: 20000 * money.Baht, Currency: "USD"}}},
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mockAllowances(mock, 2024)
+				mockBrackets(mock, 2024)
+				mock.ExpectPrepare("SELECT currency, rate FROM exchange_rates").ExpectQuery().WithArgs(2024).WillReturnRows(sqlmock.NewRows([]string{"currency", "rate"}))
+			},
+			expectedErr: ErrNoExchangeRate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr, mock, close := setup(t)
+			defer close()
+
+			tt.mockBehavior(mock)
+
+			result, err := svr.Calculate(context.Background(), tt.request)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				assert.Equal(t, tt.expectedTax, result.Tax)
+				assert.Len(t, result.Conversions, tt.expectedConversions)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
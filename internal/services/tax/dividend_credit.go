@@ -0,0 +1,41 @@
+package tax
+
+import (
+	"fmt"
+
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+var (
+	ErrCorporateRateNotDividend = fmt.Errorf("corporate rate only applies to dividends")
+	ErrInvalidCorporateRate     = fmt.Errorf("corporate rate must be from 0 to below 1")
+)
+
+// grossUpDividends returns the incomes with the dividend tax credit of every dividend added to the
+// dividends, and the credit. The credit of a dividend paid out of profit taxed at the corporate rate
+// is the corporate tax paid on it, the dividend times rate/(1-rate), and is credited like the
+// withholding tax.
+func grossUpDividends(incomes []Income) ([]Income, money.Money, error) {
+	var credit money.Money
+	for _, income := range incomes {
+		if income.CorporateRate == 0 {
+			continue
+		}
+
+		if income.Type != Dividend {
+			return nil, 0, fmt.Errorf("%w: %s", ErrCorporateRateNotDividend, income.Type)
+		}
+
+		if income.CorporateRate < 0 || income.CorporateRate >= 1 {
+			return nil, 0, fmt.Errorf("%w: %v", ErrInvalidCorporateRate, income.CorporateRate)
+		}
+
+		credit += income.Amount.MulRound(income.CorporateRate/(1-income.CorporateRate), money.ToSatang)
+	}
+
+	if credit == 0 {
+		return incomes, 0, nil
+	}
+
+	return append(append([]Income{}, incomes...), Income{Type: Dividend, Amount: credit}), credit, nil
+}
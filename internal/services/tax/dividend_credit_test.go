@@ -0,0 +1,144 @@
+package tax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestGrossUpDividends(t *testing.T) {
+	tests := []struct {
+		name            string
+		incomes         []Income
+		expectedIncomes []Income
+		expectedCredit  money.Money
+		expectedErr     error
+	}{
+		{
+			name:            "Dividends without a corporate rate",
+			incomes:         []Income{{Type: Dividend, Amount: 80000 * money.Baht}},
+			expectedIncomes: []Income{{Type: Dividend, Amount: 80000 * money.Baht}},
+		},
+		{
+			name:    "Credits of every corporate rate are added up",
+			incomes: []Income{{Type: Dividend, Amount: 80000 * money.Baht, CorporateRate: 0.20}, {Type: Dividend, Amount: 70000 * money.Baht, CorporateRate: 0.30}, {Type: Salary, Amount: 100000 * money.Baht}},
+			expectedIncomes: []Income{
+				{Type: Dividend, Amount: 80000 * money.Baht, CorporateRate: 0.20},
+				{Type: Dividend, Amount: 70000 * money.Baht, CorporateRate: 0.30},
+				{Type: Salary, Amount: 100000 * money.Baht},
+				{Type: Dividend, Amount: 50000 * money.Baht},
+			},
+			expectedCredit: 50000 * money.Baht,
+		},
+		{
+			name:           "Credits are rounded to the satang",
+			incomes:        []Income{{Type: Dividend, Amount: 100 * money.Baht, CorporateRate: 0.10}},
+			expectedCredit: money.FromBaht(11.11),
+			expectedIncomes: []Income{
+				{Type: Dividend, Amount: 100 * money.Baht, CorporateRate: 0.10},
+				{Type: Dividend, Amount: money.FromBaht(11.11)},
+			},
+		},
+		{
+			name:        "Corporate rate of interest",
+			incomes:     []Income{{Type: Interest, Amount: 80000 * money.Baht, CorporateRate: 0.20}},
+			expectedErr: ErrCorporateRateNotDividend,
+		},
+		{
+			name:        "Corporate rate of the whole profit",
+			incomes:     []Income{{Type: Dividend, Amount: 80000 * money.Baht, CorporateRate: 1}},
+			expectedErr: ErrInvalidCorporateRate,
+		},
+		{
+			name:        "Negative corporate rate",
+			incomes:     []Income{{Type: Dividend, Amount: 80000 * money.Baht, CorporateRate: -0.20}},
+			expectedErr: ErrInvalidCorporateRate,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incomes, credit, err := grossUpDividends(tt.incomes)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedIncomes, incomes)
+				assert.Equal(t, tt.expectedCredit, credit)
+			}
+		})
+	}
+}
+
+func TestCalculateDividendCredit(t *testing.T) {
+	defaultMockBehavior := func(mock sqlmock.Sqlmock) {
+		mockAllowances(mock, 2024)
+		mockBrackets(mock, 2024)
+	}
+
+	tests := []struct {
+		name             string
+		mockBehavior     func(mock sqlmock.Sqlmock)
+		request          CalculateRequest
+		expectedTax      money.Money
+		expectedRefund   money.Money
+		expectedCredit   money.Money
+		expectedExpenses []IncomeExpense
+		expectedFinal    *FinalWithholdingComparison
+	}{
+		{
+			name:         "Grossed up dividend credited as a refund",
+			mockBehavior: defaultMockBehavior,
+			request: CalculateRequest{
+				TaxYear: 2024,
+				Income:  200000 * money.Baht,
+				Incomes: []Income{{Type: Dividend, Amount: 80000 * money.Baht, CorporateRate: 0.20}},
+			},
+			expectedRefund:   11000 * money.Baht,
+			expectedCredit:   20000 * money.Baht,
+			expectedExpenses: []IncomeExpense{{Income: Income{Type: Dividend, Amount: 100000 * money.Baht}}},
+		},
+		{
+			name:         "Dividend credit is only claimed by including the dividend",
+			mockBehavior: defaultMockBehavior,
+			request: CalculateRequest{
+				TaxYear: 2024,
+				Income:  200000 * money.Baht,
+				Incomes: []Income{{Type: Dividend, Amount: 80000 * money.Baht, CorporateRate: 0.20, FinalWithholding: true}},
+			},
+			expectedRefund:   19000 * money.Baht,
+			expectedCredit:   20000 * money.Baht,
+			expectedExpenses: []IncomeExpense{{Income: Income{Type: Dividend, Amount: 100000 * money.Baht}}},
+			expectedFinal: &FinalWithholdingComparison{
+				Income:   80000 * money.Baht,
+				Withheld: 8000 * money.Baht,
+				Included: FinalWithholdingOption{Refund: 19000 * money.Baht, TotalTax: -11000 * money.Baht},
+				Excluded: FinalWithholdingOption{TotalTax: 8000 * money.Baht},
+				Include:  true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr, mock, close := setup(t)
+			defer close()
+
+			tt.mockBehavior(mock)
+
+			result, err := svr.Calculate(context.Background(), tt.request)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTax, result.Tax)
+			assert.Equal(t, tt.expectedRefund, result.Refund)
+			assert.Equal(t, tt.expectedCredit, result.DividendCredit)
+			assert.Equal(t, tt.expectedExpenses, result.Expenses)
+			assert.Equal(t, tt.expectedFinal, result.FinalWithholding)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package tax
+
+import (
+	"fmt"
+
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+// FilingStatus is how a filer files their return with regard to a spouse.
+type FilingStatus string
+
+const (
+	Single FilingStatus = "single"
+	// SpouseNoIncome files for a spouse with no income, for whom the spouse allowance is deducted.
+	SpouseNoIncome FilingStatus = "spouse-no-income"
+	// Joint files the income of a spouse with income in the same return, with the spouse allowance.
+	Joint FilingStatus = "joint"
+	// Separate files the income of each spouse in a return of their own.
+	Separate FilingStatus = "separate"
+)
+
+var filingStatuses = []FilingStatus{Single, SpouseNoIncome, Joint, Separate}
+
+var (
+	ErrUnsupportedFilingStatus = fmt.Errorf("filing status not supported")
+	ErrMissingSpouseIncome     = fmt.Errorf("spouse income is required to file jointly or separately")
+	ErrUnexpectedSpouseIncome  = fmt.Errorf("spouse income is only filed jointly or separately")
+	ErrAllowanceNotApplicable  = fmt.Errorf("allowance type does not apply to the filing status")
+)
+
+// SpouseIncome is the income of a spouse with income, with the withholding tax and the allowances of their own.
+type SpouseIncome struct {
+	Income     money.Money
+	Incomes    []Income
+	WHT        money.Money
+	Allowances []Allowance
+}
+
+// FilingComparison is the tax of the income of both spouses filed jointly and separately.
+type FilingComparison struct {
+	Joint    FilingOption
+	Separate FilingOption
+	// Cheaper is the status with the lower tax, joint when both are the same.
+	Cheaper FilingStatus
+}
+
+// FilingOption is the tax of both spouses together when they file by the status.
+type FilingOption struct {
+	Status FilingStatus
+	Tax    money.Money
+	Refund money.Money
+	// TotalTax is the tax before the withholding tax is credited.
+	TotalTax money.Money
+}
+
+// IsFilingStatus reports whether the filing status is supported.
+func IsFilingStatus(status FilingStatus) bool {
+	for _, s := range filingStatuses {
+		if s == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveFilingStatus defaults an unset filing status to single.
+func resolveFilingStatus(status FilingStatus) FilingStatus {
+	if status == "" {
+		return Single
+	}
+
+	return status
+}
+
+// withSpouseIncome reports whether the status files for a spouse with income.
+func (s FilingStatus) withSpouseIncome() bool {
+	return s == Joint || s == Separate
+}
+
+func validateFilingStatus(status FilingStatus, spouse *SpouseIncome) error {
+	if !IsFilingStatus(status) {
+		return ErrUnsupportedFilingStatus
+	}
+
+	if status.withSpouseIncome() && spouse == nil {
+		return ErrMissingSpouseIncome
+	}
+
+	if !status.withSpouseIncome() && spouse != nil {
+		return ErrUnexpectedSpouseIncome
+	}
+
+	if spouse != nil && spouse.Income < 0 {
+		return ErrNegativeIncome
+	}
+
+	return nil
+}
+
+// calculateFilings works out the joint return of both spouses, and the separate returns of each, and
+// returns the return of the status filed with the comparison of both options.
+func (s *service) calculateFilings(req CalculateRequest, status FilingStatus, taxYear int, configured AllowanceList, brackets []Bracket) (*CalculateResponse, error) {
+	joint, err := s.calculateReturn(req.joint(), Joint, taxYear, configured, brackets)
+	if err != nil {
+		return nil, err
+	}
+
+	filer, err := s.calculateReturn(req.alone(), Separate, taxYear, configured, brackets)
+	if err != nil {
+		return nil, err
+	}
+
+	spouse, err := s.calculateReturn(req.spouse(), Separate, taxYear, configured, brackets)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &FilingComparison{
+		Joint: FilingOption{Status: Joint, Tax: joint.Tax, Refund: joint.Refund, TotalTax: joint.totalTax()},
+		Separate: FilingOption{
+			Status:   Separate,
+			Tax:      filer.Tax + spouse.Tax,
+			Refund:   filer.Refund + spouse.Refund,
+			TotalTax: filer.totalTax() + spouse.totalTax(),
+		},
+		Cheaper: Joint,
+	}
+	// Both options credit the same withholding tax, so the one with the lower tax before it is cheaper.
+	if comparison.Separate.TotalTax < comparison.Joint.TotalTax {
+		comparison.Cheaper = Separate
+	}
+
+	res := joint
+	if status == Separate {
+		res = filer
+	}
+	res.Filing = comparison
+
+	return res, nil
+}
+
+// joint returns the request of the return of both spouses, whose incomes, withholding tax and
+// allowances are added up within the caps of a single return.
+func (r CalculateRequest) joint() CalculateRequest {
+	req := r.alone()
+	req.Income += r.Spouse.Income
+	req.Incomes = append(append([]Income{}, r.Incomes...), r.Spouse.Incomes...)
+	req.WHT += r.Spouse.WHT
+	req.Allowances = append(append([]Allowance{}, r.Allowances...), r.Spouse.Allowances...)
+
+	return req
+}
+
+// alone returns the request of the return of the filer without the spouse.
+func (r CalculateRequest) alone() CalculateRequest {
+	req := r
+	req.Spouse = nil
+
+	return req
+}
+
+// spouse returns the request of the separate return of the spouse.
+func (r CalculateRequest) spouse() CalculateRequest {
+	return CalculateRequest{
+		TaxYear:    r.TaxYear,
+		Income:     r.Spouse.Income,
+		Incomes:    r.Spouse.Incomes,
+		WHT:        r.Spouse.WHT,
+		Allowances: r.Spouse.Allowances,
+		// The spouse is taken to have the residency of the filer.
+		Residency: r.Residency,
+		Explain:   r.Explain,
+	}
+}
@@ -0,0 +1,154 @@
+package tax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestCalculateFilingStatus(t *testing.T) {
+	defaultMockBehavior := func(mock sqlmock.Sqlmock) {
+		mockAllowances(mock, 2024)
+		mockBrackets(mock, 2024)
+	}
+
+	salaries := func(filer, spouse money.Money) CalculateRequest {
+		return CalculateRequest{
+			TaxYear: 2024,
+			Incomes: []Income{{Type: Salary, Amount: filer}},
+			Spouse:  &SpouseIncome{Incomes: []Income{{Type: Salary, Amount: spouse}}},
+		}
+	}
+
+	// Joint: 1,000,000 - 100,000 expense - 60,000 personal - 60,000 spouse = 780,000 taxed 77,000.
+	// Separate: 440,000 taxed 29,000 and 240,000 taxed 9,000.
+	bothEarning := &FilingComparison{
+		Joint:    FilingOption{Status: Joint, Tax: 77000 * money.Baht, TotalTax: 77000 * money.Baht},
+		Separate: FilingOption{Status: Separate, Tax: 38000 * money.Baht, TotalTax: 38000 * money.Baht},
+		Cheaper:  Separate,
+	}
+
+	tests := []struct {
+		name           string
+		mockBehavior   func(mock sqlmock.Sqlmock)
+		request        CalculateRequest
+		expectedStatus FilingStatus
+		expectedTax    money.Money
+		expectedRefund money.Money
+		expectedFiling *FilingComparison
+		expectedErr    error
+	}{
+		{
+			name:           "Single by default",
+			mockBehavior:   defaultMockBehavior,
+			request:        CalculateRequest{TaxYear: 2024, Income: 500000 * money.Baht},
+			expectedStatus: Single,
+			expectedTax:    29000 * money.Baht,
+		},
+		{
+			name:           "Spouse with no income is granted the spouse allowance",
+			mockBehavior:   defaultMockBehavior,
+			request:        CalculateRequest{TaxYear: 2024, Income: 500000 * money.Baht, FilingStatus: SpouseNoIncome},
+			expectedStatus: SpouseNoIncome,
+			expectedTax:    23000 * money.Baht,
+		},
+		{
+			name:         "Joint return of both spouses",
+			mockBehavior: defaultMockBehavior,
+			request: func() CalculateRequest {
+				req := salaries(600000*money.Baht, 400000*money.Baht)
+				req.FilingStatus = Joint
+				return req
+			}(),
+			expectedStatus: Joint,
+			expectedTax:    77000 * money.Baht,
+			expectedFiling: bothEarning,
+		},
+		{
+			name:         "Separate return of the filer",
+			mockBehavior: defaultMockBehavior,
+			request: func() CalculateRequest {
+				req := salaries(600000*money.Baht, 400000*money.Baht)
+				req.FilingStatus = Separate
+				return req
+			}(),
+			expectedStatus: Separate,
+			expectedTax:    29000 * money.Baht,
+			expectedFiling: bothEarning,
+		},
+		{
+			name:         "Joint is cheaper for a spouse with little income",
+			mockBehavior: defaultMockBehavior,
+			request: CalculateRequest{
+				TaxYear:      2024,
+				Incomes:      []Income{{Type: Salary, Amount: 600000 * money.Baht}},
+				WHT:          30000 * money.Baht,
+				FilingStatus: Separate,
+				Spouse:       &SpouseIncome{Income: 50000 * money.Baht},
+			},
+			expectedStatus: Separate,
+			expectedRefund: 1000 * money.Baht,
+			expectedFiling: &FilingComparison{
+				Joint:    FilingOption{Status: Joint, Refund: 2000 * money.Baht, TotalTax: 28000 * money.Baht},
+				Separate: FilingOption{Status: Separate, Refund: 1000 * money.Baht, TotalTax: 29000 * money.Baht},
+				Cheaper:  Joint,
+			},
+		},
+		{
+			name:         "Joint without the spouse income",
+			mockBehavior: defaultMockBehavior,
+			request:      CalculateRequest{TaxYear: 2024, Income: 500000 * money.Baht, FilingStatus: Joint},
+			expectedErr:  ErrMissingSpouseIncome,
+		},
+		{
+			name:         "Single with the spouse income",
+			mockBehavior: defaultMockBehavior,
+			request:      CalculateRequest{TaxYear: 2024, Income: 500000 * money.Baht, Spouse: &SpouseIncome{Income: 100000 * money.Baht}},
+			expectedErr:  ErrUnexpectedSpouseIncome,
+		},
+		{
+			name:         "Negative spouse income",
+			mockBehavior: defaultMockBehavior,
+			request:      CalculateRequest{TaxYear: 2024, Income: 500000 * money.Baht, FilingStatus: Joint, Spouse: &SpouseIncome{Income: -1 * money.Baht}},
+			expectedErr:  ErrNegativeIncome,
+		},
+		{
+			name:         "Unsupported filing status",
+			mockBehavior: defaultMockBehavior,
+			request:      CalculateRequest{TaxYear: 2024, Income: 500000 * money.Baht, FilingStatus: "widowed"},
+			expectedErr:  ErrUnsupportedFilingStatus,
+		},
+		{
+			name:         "Spouse allowance claimed by a single filer",
+			mockBehavior: defaultMockBehavior,
+			request:      CalculateRequest{TaxYear: 2024, Income: 500000 * money.Baht, Allowances: []Allowance{{Type: Spouse, Amount: 60000 * money.Baht}}},
+			expectedErr:  ErrAllowanceNotApplicable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr, mock, close := setup(t)
+			defer close()
+
+			tt.mockBehavior(mock)
+
+			result, err := svr.Calculate(context.Background(), tt.request)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedStatus, result.FilingStatus)
+				assert.Equal(t, tt.expectedTax, result.Tax)
+				assert.Equal(t, tt.expectedRefund, result.Refund)
+				assert.Equal(t, tt.expectedFiling, result.Filing)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package tax
+
+import (
+	"fmt"
+
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+var ErrNotFinalWithholding = fmt.Errorf("income type cannot be taxed at a final withholding rate")
+
+// FinalWithholdingComparison is the tax of the return with the income taxed at source at a final
+// rate included and left out.
+type FinalWithholdingComparison struct {
+	// Income is the income taxed at source, and Withheld the tax withheld from it.
+	Income   money.Money
+	Withheld money.Money
+	Included FinalWithholdingOption
+	Excluded FinalWithholdingOption
+	// Include recommends including the income in the return, when it yields the lower tax. The response
+	// is the return of the option recommended.
+	Include bool
+}
+
+// FinalWithholdingOption is the tax of the return with the income taxed at source included or left out.
+type FinalWithholdingOption struct {
+	Tax    money.Money
+	Refund money.Money
+	// TotalTax is the tax of the return less the dividend tax credit, before the withholding tax is
+	// credited, with the tax withheld at source when the income is left out, as it is then final.
+	TotalTax money.Money
+}
+
+// calculateFinalWithholding works out the return with the income taxed at source at a final rate both
+// included, when the tax withheld from it is credited, and left out, when it is final. It returns the
+// return with the lower tax and the comparison of both.
+func (s *service) calculateFinalWithholding(req CalculateRequest, status FilingStatus, taxYear int, configured AllowanceList, brackets []Bracket) (*CalculateResponse, error) {
+	others, final, withheld, err := splitFinalWithholding(req.Incomes)
+	if err != nil {
+		s.log.Err(err).Fields(map[string]interface{}{"incomes": req.Incomes}).E("Invalid final withholding income")
+		return nil, err
+	}
+
+	ereq := req
+	ereq.Incomes = others
+	excluded, err := s.calculateReturn(ereq, status, taxYear, configured, brackets)
+	if err != nil {
+		return nil, err
+	}
+
+	ireq := req
+	ireq.Incomes = append(append([]Income{}, others...), final...)
+	ireq.WHT += withheld
+	included, err := s.calculateReturn(ireq, status, taxYear, configured, brackets)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &FinalWithholdingComparison{
+		Withheld: withheld,
+		Included: FinalWithholdingOption{Tax: included.Tax, Refund: included.Refund, TotalTax: included.totalTax() - included.DividendCredit},
+		Excluded: FinalWithholdingOption{Tax: excluded.Tax, Refund: excluded.Refund, TotalTax: excluded.totalTax() + withheld},
+	}
+	for _, income := range final {
+		comparison.Income += income.Amount
+	}
+
+	res := excluded
+	if comparison.Included.TotalTax < comparison.Excluded.TotalTax {
+		res, comparison.Include = included, true
+	}
+	res.FinalWithholding = comparison
+
+	return res, nil
+}
+
+// hasFinalWithholding reports whether any of the incomes is taxed at source at a final rate.
+func hasFinalWithholding(incomes []Income) bool {
+	for _, income := range incomes {
+		if income.FinalWithholding {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitFinalWithholding returns the incomes apart from those taxed at source at a final rate, those
+// incomes unmarked so that they are assessed as usual when included, and the tax withheld from them.
+func splitFinalWithholding(incomes []Income) ([]Income, []Income, money.Money, error) {
+	var others, final []Income
+	var withheld money.Money
+	for _, income := range incomes {
+		if !income.FinalWithholding {
+			others = append(others, income)
+			continue
+		}
+
+		rule, ok := findExpenseRule(income.Type)
+		if !ok || rule.FinalWithholdingRate == 0 {
+			return nil, nil, 0, fmt.Errorf("%w: %s", ErrNotFinalWithholding, income.Type)
+		}
+
+		if income.Amount < 0 {
+			return nil, nil, 0, ErrNegativeIncome
+		}
+
+		income.FinalWithholding = false
+		final = append(final, income)
+		withheld += income.Amount.MulRound(rule.FinalWithholdingRate, money.ToSatang)
+	}
+
+	return others, final, withheld, nil
+}
@@ -0,0 +1,111 @@
+package tax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestCalculateFinalWithholding(t *testing.T) {
+	defaultMockBehavior := func(mock sqlmock.Sqlmock) {
+		mockAllowances(mock, 2024)
+		mockBrackets(mock, 2024)
+	}
+
+	tests := []struct {
+		name           string
+		mockBehavior   func(mock sqlmock.Sqlmock)
+		request        CalculateRequest
+		expectedTax    money.Money
+		expectedRefund money.Money
+		expected       *FinalWithholdingComparison
+		expectedErr    error
+	}{
+		{
+			name:         "Interest is left out at the top rate",
+			mockBehavior: defaultMockBehavior,
+			request: CalculateRequest{
+				TaxYear: 2024,
+				Income:  3000000 * money.Baht,
+				Incomes: []Income{{Type: Interest, Amount: 100000 * money.Baht, FinalWithholding: true}},
+			},
+			expectedTax: 639000 * money.Baht,
+			expected: &FinalWithholdingComparison{
+				Income:   100000 * money.Baht,
+				Withheld: 15000 * money.Baht,
+				Included: FinalWithholdingOption{Tax: 659000 * money.Baht, TotalTax: 674000 * money.Baht},
+				Excluded: FinalWithholdingOption{Tax: 639000 * money.Baht, TotalTax: 654000 * money.Baht},
+			},
+		},
+		{
+			name:         "Dividends are included below the withholding rate",
+			mockBehavior: defaultMockBehavior,
+			request: CalculateRequest{
+				TaxYear: 2024,
+				Income:  200000 * money.Baht,
+				Incomes: []Income{{Type: Dividend, Amount: 100000 * money.Baht, FinalWithholding: true}},
+			},
+			expectedRefund: 1000 * money.Baht,
+			expected: &FinalWithholdingComparison{
+				Income:   100000 * money.Baht,
+				Withheld: 10000 * money.Baht,
+				Included: FinalWithholdingOption{Refund: 1000 * money.Baht, TotalTax: 9000 * money.Baht},
+				Excluded: FinalWithholdingOption{TotalTax: 10000 * money.Baht},
+				Include:  true,
+			},
+		},
+		{
+			name:         "Interest not withheld at source is assessed as usual",
+			mockBehavior: defaultMockBehavior,
+			request: CalculateRequest{
+				TaxYear: 2024,
+				Income:  500000 * money.Baht,
+				Incomes: []Income{{Type: Interest, Amount: 100000 * money.Baht}},
+			},
+			expectedTax: 41000 * money.Baht,
+		},
+		{
+			name:         "Salary cannot be taxed at a final rate",
+			mockBehavior: defaultMockBehavior,
+			request: CalculateRequest{
+				TaxYear: 2024,
+				Incomes: []Income{{Type: Salary, Amount: 100000 * money.Baht, FinalWithholding: true}},
+			},
+			expectedErr: ErrNotFinalWithholding,
+		},
+		{
+			name:         "Negative income taxed at source",
+			mockBehavior: defaultMockBehavior,
+			request: CalculateRequest{
+				TaxYear: 2024,
+				Incomes: []Income{{Type: Dividend, Amount: -1 * money.Baht, FinalWithholding: true}},
+			},
+			expectedErr: ErrNegativeIncome,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr, mock, close := setup(t)
+			defer close()
+
+			tt.mockBehavior(mock)
+
+			result, err := svr.Calculate(context.Background(), tt.request)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTax, result.Tax)
+				assert.Equal(t, tt.expectedRefund, result.Refund)
+				assert.Equal(t, tt.expected, result.FinalWithholding)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package tax
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+	"github.com/ztrixack/assessment-tax/internal/utils/taxyear"
+)
+
+// GrossUpTarget is the amount of a calculation a gross-up solves the income for.
+type GrossUpTarget string
+
+const (
+	// TargetTax is the tax payable after the withholding tax is credited.
+	TargetTax GrossUpTarget = "tax"
+	// TargetTakeHome is the income left after the tax, before the withholding tax is credited.
+	TargetTakeHome GrossUpTarget = "take-home"
+	// TargetRefund is the withholding tax refunded.
+	TargetRefund GrossUpTarget = "refund"
+)
+
+// MaxGrossUpIncome is the highest income a gross-up searches.
+const MaxGrossUpIncome = 1000000000000 * money.Baht
+
+var (
+	ErrUnsupportedGrossUpTarget = fmt.Errorf("gross-up target not supported")
+	ErrNegativeGrossUpAmount    = fmt.Errorf("gross-up amount cannot be negative")
+	ErrGrossUpUnreachable       = fmt.Errorf("no income reaches the gross-up amount")
+)
+
+type GrossUpRequest struct {
+	TaxYear int
+	Target  GrossUpTarget
+	Amount  money.Money
+	// IncomeType is the type of the income solved for. The income is untyped when it is empty,
+	// so that no expense is deducted from it.
+	IncomeType IncomeType
+	WHT        money.Money
+	Allowances []Allowance
+}
+
+type GrossUpResponse struct {
+	// Income is the lowest income whose calculation reaches the amount.
+	Income   money.Money
+	TakeHome money.Money
+	// Calculation is the calculation of the income, with the same allowances and withholding tax.
+	Calculation CalculateResponse
+}
+
+func (s *service) GrossUp(ctx context.Context, req GrossUpRequest) (*GrossUpResponse, error) {
+	if req.Amount < 0 {
+		s.log.Fields(map[string]interface{}{"amount": req.Amount}).E("Gross-up amount cannot be negative")
+		return nil, ErrNegativeGrossUpAmount
+	}
+
+	if !req.Target.isValid() {
+		s.log.Fields(map[string]interface{}{"target": req.Target}).E("Gross-up target not supported")
+		return nil, ErrUnsupportedGrossUpTarget
+	}
+
+	taxYear := taxyear.Resolve(req.TaxYear)
+	configured, brackets, err := s.getSettings(taxYear)
+	if err != nil {
+		return nil, err
+	}
+
+	calculate := func(income money.Money) (*CalculateResponse, error) {
+		return s.calculate(req.toCalculateRequest(income), taxYear, configured, brackets)
+	}
+
+	// The tax never falls as the income rises, so the lowest income reaching the amount is found by
+	// doubling the income until it does, then bisecting down to the satang.
+	lower, upper := money.Money(-1), money.Baht
+	for {
+		res, err := calculate(upper)
+		if err != nil {
+			return nil, err
+		}
+		if req.reached(upper, res) {
+			break
+		}
+		if upper >= MaxGrossUpIncome {
+			s.log.Fields(map[string]interface{}{"target": req.Target, "amount": req.Amount}).W("No income reaches the gross-up amount")
+			return nil, ErrGrossUpUnreachable
+		}
+		lower, upper = upper, min(upper*2, MaxGrossUpIncome)
+	}
+
+	for upper-lower > money.Satang {
+		middle := lower + (upper-lower)/2
+		res, err := calculate(middle)
+		if err != nil {
+			return nil, err
+		}
+		if req.reached(middle, res) {
+			upper = middle
+		} else {
+			lower = middle
+		}
+	}
+
+	res, err := calculate(upper)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GrossUpResponse{
+		Income:      upper,
+		TakeHome:    upper - res.totalTax(),
+		Calculation: *res,
+	}, nil
+}
+
+func (t GrossUpTarget) isValid() bool {
+	switch t {
+	case TargetTax, TargetTakeHome, TargetRefund:
+		return true
+	}
+
+	return false
+}
+
+func (r GrossUpRequest) toCalculateRequest(income money.Money) CalculateRequest {
+	req := CalculateRequest{TaxYear: r.TaxYear, WHT: r.WHT, Allowances: r.Allowances}
+	if r.IncomeType == "" {
+		req.Income = income
+	} else {
+		req.Incomes = []Income{{Type: r.IncomeType, Amount: income}}
+	}
+
+	return req
+}
+
+// reached reports whether the calculation of the income reaches the amount of the target.
+func (r GrossUpRequest) reached(income money.Money, res *CalculateResponse) bool {
+	switch r.Target {
+	case TargetTax:
+		return res.Tax >= r.Amount
+	case TargetTakeHome:
+		return income-res.totalTax() >= r.Amount
+	default:
+		return res.Refund <= r.Amount
+	}
+}
+
+// totalTax returns the tax of the method used with the separate tax of the lump sums, before the
+// withholding tax is credited.
+func (r *CalculateResponse) totalTax() money.Money {
+	tax := r.ProgressiveTax
+	if r.Method == MethodGrossIncome {
+		tax = r.GrossIncomeTax
+	}
+
+	if r.LumpSum != nil {
+		tax += r.LumpSum.Tax
+	}
+
+	return tax
+}
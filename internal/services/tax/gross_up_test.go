@@ -0,0 +1,153 @@
+package tax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestGrossUp(t *testing.T) {
+	defaultMockBehavior := func(mock sqlmock.Sqlmock) {
+		mockAllowances(mock, 2024)
+		mockBrackets(mock, 2024)
+	}
+
+	tests := []struct {
+		name             string
+		mockBehavior     func(mock sqlmock.Sqlmock)
+		request          GrossUpRequest
+		expectedIncome   money.Money
+		expectedTakeHome money.Money
+		expectedTax      money.Money
+		expectedRefund   money.Money
+		expectedErr      error
+	}{
+		{
+			name:             "Target take-home pay",
+			mockBehavior:     defaultMockBehavior,
+			request:          GrossUpRequest{TaxYear: 2024, Target: TargetTakeHome, Amount: 471000 * money.Baht},
+			expectedIncome:   500000 * money.Baht,
+			expectedTakeHome: 471000 * money.Baht,
+			expectedTax:      29000 * money.Baht,
+		},
+		{
+			name:             "Target take-home pay of a salary",
+			mockBehavior:     defaultMockBehavior,
+			request:          GrossUpRequest{TaxYear: 2024, Target: TargetTakeHome, Amount: 571000 * money.Baht, IncomeType: Salary},
+			expectedIncome:   600000 * money.Baht,
+			expectedTakeHome: 571000 * money.Baht,
+			expectedTax:      29000 * money.Baht,
+		},
+		{
+			name:         "Target take-home pay with allowances",
+			mockBehavior: defaultMockBehavior,
+			request: GrossUpRequest{
+				TaxYear:    2024,
+				Target:     TargetTakeHome,
+				Amount:     476000 * money.Baht,
+				Allowances: []Allowance{{Type: KReceipt, Amount: 50000 * money.Baht}},
+			},
+			expectedIncome:   500000 * money.Baht,
+			expectedTakeHome: 476000 * money.Baht,
+			expectedTax:      24000 * money.Baht,
+		},
+		{
+			name:             "Target tax is reached by the lowest income rounding up to it",
+			mockBehavior:     defaultMockBehavior,
+			request:          GrossUpRequest{TaxYear: 2024, Target: TargetTax, Amount: 29000 * money.Baht},
+			expectedIncome:   money.FromBaht(499999.95),
+			expectedTakeHome: money.FromBaht(470999.95),
+			expectedTax:      29000 * money.Baht,
+		},
+		{
+			name:             "Target tax after the withholding tax",
+			mockBehavior:     defaultMockBehavior,
+			request:          GrossUpRequest{TaxYear: 2024, Target: TargetTax, Amount: 9000 * money.Baht, WHT: 20000 * money.Baht},
+			expectedIncome:   money.FromBaht(499999.95),
+			expectedTakeHome: money.FromBaht(470999.95),
+			expectedTax:      9000 * money.Baht,
+		},
+		{
+			name:             "Target refund",
+			mockBehavior:     defaultMockBehavior,
+			request:          GrossUpRequest{TaxYear: 2024, Target: TargetRefund, Amount: 1000 * money.Baht, WHT: 30000 * money.Baht},
+			expectedIncome:   money.FromBaht(499999.95),
+			expectedTakeHome: money.FromBaht(470999.95),
+			expectedRefund:   1000 * money.Baht,
+		},
+		{
+			name:           "No income is needed for a zero tax",
+			mockBehavior:   defaultMockBehavior,
+			request:        GrossUpRequest{TaxYear: 2024, Target: TargetTax, Amount: 0},
+			expectedIncome: 0,
+		},
+		{
+			name:         "Target tax is out of reach",
+			mockBehavior: defaultMockBehavior,
+			request:      GrossUpRequest{TaxYear: 2024, Target: TargetTax, Amount: MaxGrossUpIncome},
+			expectedErr:  ErrGrossUpUnreachable,
+		},
+		{
+			name:         "Negative amount",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      GrossUpRequest{TaxYear: 2024, Target: TargetTax, Amount: -1 * money.Baht},
+			expectedErr:  ErrNegativeGrossUpAmount,
+		},
+		{
+			name:         "Unsupported target",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      GrossUpRequest{TaxYear: 2024, Target: "net-worth", Amount: 1000 * money.Baht},
+			expectedErr:  ErrUnsupportedGrossUpTarget,
+		},
+		{
+			name:         "Unsupported income type",
+			mockBehavior: defaultMockBehavior,
+			request:      GrossUpRequest{TaxYear: 2024, Target: TargetTax, Amount: 1000 * money.Baht, IncomeType: "40(9)"},
+			expectedErr:  ErrUnsupportedIncomeType,
+		},
+		{
+			name: "Error in tax brackets",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mockAllowances(mock, 2024)
+				mock.ExpectPrepare("SELECT lower_bound, upper_bound, rate FROM tax_brackets").ExpectQuery().WithArgs(2024).WillReturnError(assert.AnError)
+			},
+			request:     GrossUpRequest{TaxYear: 2024, Target: TargetTax, Amount: 1000 * money.Baht},
+			expectedErr: assert.AnError,
+		},
+		{
+			name: "Error in database",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").ExpectQuery().WithArgs(2024, sqlmock.AnyArg()).WillReturnError(assert.AnError)
+			},
+			request:     GrossUpRequest{TaxYear: 2024, Target: TargetTax, Amount: 1000 * money.Baht},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr, mock, close := setup(t)
+			defer close()
+
+			tt.mockBehavior(mock)
+
+			result, err := svr.GrossUp(context.Background(), tt.request)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedIncome, result.Income)
+				assert.Equal(t, tt.expectedTakeHome, result.TakeHome)
+				assert.Equal(t, tt.expectedTax, result.Calculation.Tax)
+				assert.Equal(t, tt.expectedRefund, result.Calculation.Refund)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
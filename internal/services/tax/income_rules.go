@@ -0,0 +1,153 @@
+package tax
+
+import (
+	"fmt"
+
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+// IncomeType is the section 40 category of assessable income in the Revenue Code.
+type IncomeType string
+
+const (
+	Salary       IncomeType = "40(1)"
+	Fee          IncomeType = "40(2)"
+	Royalty      IncomeType = "40(3)"
+	Investment   IncomeType = "40(4)"
+	Rental       IncomeType = "40(5)"
+	Professional IncomeType = "40(6)"
+	Contracting  IncomeType = "40(7)"
+	Business     IncomeType = "40(8)"
+	// Interest and Dividend are the kinds of investment income that can be taxed at source at a final rate.
+	Interest IncomeType = "40(4)(a)"
+	Dividend IncomeType = "40(4)(b)"
+)
+
+type Income struct {
+	Type   IncomeType
+	Amount money.Money
+	// FinalWithholding marks income taxed at source at the final withholding rate of its type, which
+	// can be left out of the return.
+	FinalWithholding bool
+	// CorporateRate is the corporate income tax rate of the company paying a dividend, such as 0.20,
+	// which the dividend tax credit is worked out by, when set.
+	CorporateRate float64
+	// Foreign marks income sourced outside Thailand, of which the amount is the part remitted to Thailand.
+	Foreign bool
+	// ForeignTax is the tax paid abroad on foreign-sourced income, which is credited against the tax.
+	ForeignTax money.Money
+	// Currency is the currency of the amount and the foreign tax, converted to baht by the exchange rate of
+	// the tax year. It is THB when it is empty.
+	Currency Currency
+}
+
+// IncomeExpense is the income of a type with the standard expense deducted from it.
+type IncomeExpense struct {
+	Income
+	Expense money.Money
+}
+
+// ExpenseGroup is a cap shared by the combined expense of several income types.
+type ExpenseGroup struct {
+	Name    string
+	Maximum money.Money
+}
+
+// ExpenseRule describes the standard expense deducted from an income type, and the rate it can be
+// taxed at source at.
+type ExpenseRule struct {
+	Type IncomeType
+	// Rate is the share of the income deducted as expense.
+	Rate float64
+	// Maximum caps the expense of the type.
+	Maximum money.Money
+	// Group shares its maximum with the other types of the group, when set.
+	Group *ExpenseGroup
+	// FinalWithholdingRate is the rate of the tax withheld at source as the final tax of the type, when set.
+	FinalWithholdingRate float64
+}
+
+// TaxMethod is how the tax payable is worked out.
+type TaxMethod string
+
+const (
+	// MethodProgressive taxes the net income by the tax brackets.
+	MethodProgressive TaxMethod = "progressive"
+	// MethodGrossIncome taxes the gross income other than salary at GrossIncomeTaxRate.
+	MethodGrossIncome TaxMethod = "gross-income"
+)
+
+const (
+	// GrossIncomeTaxThreshold is the income other than salary above which the filer pays the higher
+	// of the progressive tax and the gross income tax.
+	GrossIncomeTaxThreshold = 120000 * money.Baht
+	GrossIncomeTaxRate      = 0.005
+)
+
+var ErrUnsupportedIncomeType = fmt.Errorf("income type not supported")
+
+var employmentGroup = &ExpenseGroup{Name: "employment", Maximum: 100000 * money.Baht}
+
+// expenseRules is the registry of income types. Rental, professional and business income use the
+// rate of their most common kind: buildings, non-medical professions and the 60% business list.
+// Types sharing a group are deducted in the order they are listed until the group maximum is reached.
+var expenseRules = []ExpenseRule{
+	{Type: Salary, Rate: 0.50, Maximum: 100000 * money.Baht, Group: employmentGroup},
+	{Type: Fee, Rate: 0.50, Maximum: 100000 * money.Baht, Group: employmentGroup},
+	{Type: Royalty, Rate: 0.50, Maximum: 100000 * money.Baht},
+	{Type: Investment, Rate: 0, Maximum: 0},
+	{Type: Interest, Rate: 0, Maximum: 0, FinalWithholdingRate: 0.15},
+	{Type: Dividend, Rate: 0, Maximum: 0, FinalWithholdingRate: 0.10},
+	{Type: Rental, Rate: 0.30, Maximum: Unlimited},
+	{Type: Professional, Rate: 0.30, Maximum: Unlimited},
+	{Type: Contracting, Rate: 0.60, Maximum: Unlimited},
+	{Type: Business, Rate: 0.60, Maximum: Unlimited},
+}
+
+// IncomeTypes returns every supported income type, in the order their expenses are deducted.
+func IncomeTypes() []IncomeType {
+	types := make([]IncomeType, len(expenseRules))
+	for i, rule := range expenseRules {
+		types[i] = rule.Type
+	}
+
+	return types
+}
+
+// IsIncomeType reports whether the income type is supported.
+func IsIncomeType(itype IncomeType) bool {
+	_, ok := findExpenseRule(itype)
+	return ok
+}
+
+func findExpenseRule(itype IncomeType) (ExpenseRule, bool) {
+	for _, rule := range expenseRules {
+		if rule.Type == itype {
+			return rule, true
+		}
+	}
+
+	return ExpenseRule{}, false
+}
+
+// expense returns the standard expense of the income within the maximum of the rule.
+func (r ExpenseRule) expense(income money.Money) money.Money {
+	return min(income.Mul(r.Rate), r.Maximum)
+}
+
+// grossIncomeTax returns the tax on the gross income other than salary, and whether it applies
+// to the incomes, rounded by the rounding.
+func grossIncomeTax(incomes []IncomeExpense, rounding money.Rounding) (money.Money, bool) {
+	var gross money.Money
+	for _, income := range incomes {
+		if income.Type != Salary {
+			gross += income.Amount
+		}
+	}
+
+	if gross <= GrossIncomeTaxThreshold {
+		return 0, false
+	}
+
+	return gross.MulRound(GrossIncomeTaxRate, rounding), true
+}
@@ -0,0 +1,126 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestIsIncomeType(t *testing.T) {
+	for _, itype := range IncomeTypes() {
+		assert.True(t, IsIncomeType(itype), itype)
+	}
+
+	assert.False(t, IsIncomeType("40(9)"))
+	assert.False(t, IsIncomeType(""))
+}
+
+func TestCalculateExpenses(t *testing.T) {
+	tests := []struct {
+		name           string
+		incomes        []Income
+		expectedResult []IncomeExpense
+		expectedErr    error
+	}{
+		{
+			name:    "Salary below the maximum",
+			incomes: []Income{{Type: Salary, Amount: 100000 * money.Baht}},
+			expectedResult: []IncomeExpense{
+				{Income: Income{Type: Salary, Amount: 100000 * money.Baht}, Expense: 50000 * money.Baht},
+			},
+		},
+		{
+			name:    "Salary and fees share their maximum",
+			incomes: []Income{{Type: Fee, Amount: 100000 * money.Baht}, {Type: Salary, Amount: 150000 * money.Baht}},
+			expectedResult: []IncomeExpense{
+				{Income: Income{Type: Salary, Amount: 150000 * money.Baht}, Expense: 75000 * money.Baht},
+				{Income: Income{Type: Fee, Amount: 100000 * money.Baht}, Expense: 25000 * money.Baht},
+			},
+		},
+		{
+			name:    "Incomes of the same type are combined",
+			incomes: []Income{{Type: Royalty, Amount: 150000 * money.Baht}, {Type: Royalty, Amount: 150000 * money.Baht}},
+			expectedResult: []IncomeExpense{
+				{Income: Income{Type: Royalty, Amount: 300000 * money.Baht}, Expense: 100000 * money.Baht},
+			},
+		},
+		{
+			name:    "Investment income has no expense",
+			incomes: []Income{{Type: Investment, Amount: 100000 * money.Baht}},
+			expectedResult: []IncomeExpense{
+				{Income: Income{Type: Investment, Amount: 100000 * money.Baht}, Expense: 0},
+			},
+		},
+		{
+			name:    "Uncapped rates",
+			incomes: []Income{{Type: Rental, Amount: 1000000 * money.Baht}, {Type: Professional, Amount: 1000000 * money.Baht}, {Type: Contracting, Amount: 1000000 * money.Baht}, {Type: Business, Amount: 1000000 * money.Baht}},
+			expectedResult: []IncomeExpense{
+				{Income: Income{Type: Rental, Amount: 1000000 * money.Baht}, Expense: 300000 * money.Baht},
+				{Income: Income{Type: Professional, Amount: 1000000 * money.Baht}, Expense: 300000 * money.Baht},
+				{Income: Income{Type: Contracting, Amount: 1000000 * money.Baht}, Expense: 600000 * money.Baht},
+				{Income: Income{Type: Business, Amount: 1000000 * money.Baht}, Expense: 600000 * money.Baht},
+			},
+		},
+		{
+			name:           "No incomes",
+			incomes:        []Income{},
+			expectedResult: nil,
+		},
+		{
+			name:        "Negative income",
+			incomes:     []Income{{Type: Salary, Amount: -1 * money.Baht}},
+			expectedErr: ErrNegativeIncome,
+		},
+		{
+			name:        "Unsupported income type",
+			incomes:     []Income{{Type: "40(9)", Amount: 1 * money.Baht}},
+			expectedErr: ErrUnsupportedIncomeType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr, _, close := setup(t)
+			defer close()
+
+			result, err := svr.calculateExpenses(tt.incomes)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestGrossIncomeTax(t *testing.T) {
+	tests := []struct {
+		name            string
+		incomes         []IncomeExpense
+		expectedTax     money.Money
+		expectedApplies bool
+	}{
+		{
+			name:    "Salary only",
+			incomes: []IncomeExpense{{Income: Income{Type: Salary, Amount: 1000000 * money.Baht}}},
+		},
+		{
+			name:    "Income other than salary at the threshold",
+			incomes: []IncomeExpense{{Income: Income{Type: Salary, Amount: 1000000 * money.Baht}}, {Income: Income{Type: Fee, Amount: 120000 * money.Baht}}},
+		},
+		{
+			name:            "Income other than salary above the threshold",
+			incomes:         []IncomeExpense{{Income: Income{Type: Salary, Amount: 1000000 * money.Baht}}, {Income: Income{Type: Fee, Amount: 100000 * money.Baht}}, {Income: Income{Type: Rental, Amount: 100000 * money.Baht}}},
+			expectedTax:     1000 * money.Baht,
+			expectedApplies: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tax, applies := grossIncomeTax(tt.incomes, money.ToSatang)
+
+			assert.Equal(t, tt.expectedTax, tax)
+			assert.Equal(t, tt.expectedApplies, applies)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package tax
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+const (
+	// InstallmentThreshold is the least tax that can be paid in installments.
+	InstallmentThreshold = 3000 * money.Baht
+	// InstallmentCount is the number of monthly installments of a tax from the threshold on.
+	InstallmentCount = 3
+)
+
+var ErrMissingDeadline = fmt.Errorf("deadline is required")
+
+type InstallmentRequest struct {
+	CalculateRequest
+	// Deadline is the due date of the first installment, the filing deadline of the tax year.
+	Deadline time.Time
+}
+
+type InstallmentResponse struct {
+	TaxYear int
+	// Tax is the tax of the calculation, payable after the withholding tax is credited.
+	Tax money.Money
+	// Eligible reports whether the tax reaches the threshold to be paid in installments. The tax below the
+	// threshold is paid in full by the deadline.
+	Eligible     bool
+	Installments []Installment
+}
+
+// Installment is a part of the tax due by a date, one month after the previous one.
+type Installment struct {
+	Number  int
+	DueDate time.Time
+	Amount  money.Money
+}
+
+// PlanInstallments calculates the tax and splits it into monthly installments from the deadline on.
+func (s *service) PlanInstallments(ctx context.Context, req InstallmentRequest) (*InstallmentResponse, error) {
+	if req.Deadline.IsZero() {
+		s.log.E("Deadline is required")
+		return nil, ErrMissingDeadline
+	}
+
+	res, err := s.Calculate(ctx, req.CalculateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InstallmentResponse{
+		TaxYear:      res.TaxYear,
+		Tax:          res.Tax,
+		Eligible:     res.Tax >= InstallmentThreshold,
+		Installments: scheduleInstallments(res.Tax, req.Deadline),
+	}, nil
+}
+
+// scheduleInstallments splits the tax into even installments in whole satang, due a month apart from the
+// deadline. The earlier installments are rounded down, so that the last one makes up the tax. The tax below
+// the threshold is a single installment, and no tax has none.
+func scheduleInstallments(tax money.Money, deadline time.Time) []Installment {
+	if tax <= 0 {
+		return nil
+	}
+
+	count := 1
+	if tax >= InstallmentThreshold {
+		count = InstallmentCount
+	}
+
+	deadline = toDate(deadline)
+	amount := tax / money.Money(count)
+	installments := make([]Installment, count)
+	for i := range installments {
+		installments[i] = Installment{Number: i + 1, DueDate: addMonths(deadline, i), Amount: amount}
+	}
+	installments[count-1].Amount = tax - amount*money.Money(count-1)
+
+	return installments
+}
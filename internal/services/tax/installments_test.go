@@ -0,0 +1,146 @@
+package tax
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestPlanInstallments(t *testing.T) {
+	deadline := time.Date(2025, time.April, 8, 0, 0, 0, 0, time.UTC)
+	defaultMockBehavior := func(mock sqlmock.Sqlmock) {
+		mockAllowances(mock, 2024)
+		mockBrackets(mock, 2024)
+	}
+
+	tests := []struct {
+		name         string
+		mockBehavior func(mock sqlmock.Sqlmock)
+		request      InstallmentRequest
+		expected     *InstallmentResponse
+		expectedErr  error
+	}{
+		{
+			name:         "Tax from the threshold is paid in three installments",
+			mockBehavior: defaultMockBehavior,
+			request:      InstallmentRequest{CalculateRequest: CalculateRequest{TaxYear: 2024, Income: 500000 * money.Baht}, Deadline: deadline},
+			expected: &InstallmentResponse{
+				TaxYear:  2024,
+				Tax:      29000 * money.Baht,
+				Eligible: true,
+				Installments: []Installment{
+					{Number: 1, DueDate: deadline, Amount: money.FromBaht(9666.66)},
+					{Number: 2, DueDate: deadline.AddDate(0, 1, 0), Amount: money.FromBaht(9666.66)},
+					{Number: 3, DueDate: deadline.AddDate(0, 2, 0), Amount: money.FromBaht(9666.68)},
+				},
+			},
+		},
+		{
+			name:         "Withholding tax credited below the threshold",
+			mockBehavior: defaultMockBehavior,
+			request:      InstallmentRequest{CalculateRequest: CalculateRequest{TaxYear: 2024, Income: 500000 * money.Baht, WHT: 27000 * money.Baht}, Deadline: deadline},
+			expected: &InstallmentResponse{
+				TaxYear:      2024,
+				Tax:          2000 * money.Baht,
+				Installments: []Installment{{Number: 1, DueDate: deadline, Amount: 2000 * money.Baht}},
+			},
+		},
+		{
+			name:         "No tax due",
+			mockBehavior: defaultMockBehavior,
+			request:      InstallmentRequest{CalculateRequest: CalculateRequest{TaxYear: 2024, Income: 100000 * money.Baht}, Deadline: deadline},
+			expected:     &InstallmentResponse{TaxYear: 2024},
+		},
+		{
+			name:         "Missing deadline",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      InstallmentRequest{CalculateRequest: CalculateRequest{TaxYear: 2024, Income: 500000 * money.Baht}},
+			expectedErr:  ErrMissingDeadline,
+		},
+		{
+			name:         "Negative income",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      InstallmentRequest{CalculateRequest: CalculateRequest{TaxYear: 2024, Income: -money.Baht}, Deadline: deadline},
+			expectedErr:  ErrNegativeIncome,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mock, teardown := setup(t)
+			defer teardown()
+
+			tt.mockBehavior(mock)
+
+			result, err := s.PlanInstallments(context.Background(), tt.request)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, result)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestScheduleInstallments(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		tax      money.Money
+		deadline time.Time
+		expected []Installment
+	}{
+		{
+			name:     "Threshold splits evenly",
+			tax:      InstallmentThreshold,
+			deadline: date(2025, time.April, 8),
+			expected: []Installment{
+				{Number: 1, DueDate: date(2025, time.April, 8), Amount: 1000 * money.Baht},
+				{Number: 2, DueDate: date(2025, time.May, 8), Amount: 1000 * money.Baht},
+				{Number: 3, DueDate: date(2025, time.June, 8), Amount: 1000 * money.Baht},
+			},
+		},
+		{
+			name:     "Last installment makes up the satang",
+			tax:      money.FromBaht(3000.02),
+			deadline: date(2025, time.April, 8).Add(15 * time.Hour),
+			expected: []Installment{
+				{Number: 1, DueDate: date(2025, time.April, 8), Amount: 1000 * money.Baht},
+				{Number: 2, DueDate: date(2025, time.May, 8), Amount: 1000 * money.Baht},
+				{Number: 3, DueDate: date(2025, time.June, 8), Amount: money.FromBaht(1000.02)},
+			},
+		},
+		{
+			name:     "Due on the last day of a shorter month",
+			tax:      9000 * money.Baht,
+			deadline: date(2025, time.January, 31),
+			expected: []Installment{
+				{Number: 1, DueDate: date(2025, time.January, 31), Amount: 3000 * money.Baht},
+				{Number: 2, DueDate: date(2025, time.February, 28), Amount: 3000 * money.Baht},
+				{Number: 3, DueDate: date(2025, time.March, 31), Amount: 3000 * money.Baht},
+			},
+		},
+		{
+			name:     "Below the threshold",
+			tax:      InstallmentThreshold - money.Satang,
+			deadline: date(2025, time.April, 8),
+			expected: []Installment{{Number: 1, DueDate: date(2025, time.April, 8), Amount: money.FromBaht(2999.99)}},
+		},
+		{
+			name:     "No tax",
+			deadline: date(2025, time.April, 8),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, scheduleInstallments(tt.tax, tt.deadline))
+		})
+	}
+}
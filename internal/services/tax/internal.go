@@ -3,22 +3,43 @@ package tax
 import (
 	"context"
 	"fmt"
-	"math"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/utils/deductions"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
 type Servicer interface {
 	Calculate(ctx context.Context, req CalculateRequest) (*CalculateResponse, error)
+	GrossUp(ctx context.Context, req GrossUpRequest) (*GrossUpResponse, error)
+	Compare(ctx context.Context, req CompareRequest) (*CompareResponse, error)
+	Optimize(ctx context.Context, req OptimizeRequest) (*OptimizeResponse, error)
+	CalculatePayroll(ctx context.Context, req PayrollRequest) (*PayrollResponse, error)
+	ReconcileWithholding(ctx context.Context, req ReconcileRequest) (*ReconcileResponse, error)
+	CalculateSurcharge(ctx context.Context, req SurchargeRequest) (*SurchargeResponse, error)
+	PlanInstallments(ctx context.Context, req InstallmentRequest) (*InstallmentResponse, error)
 }
 
 type Allowance struct {
 	Type   AllowanceType
-	Amount float64
+	Amount money.Money
 }
 
-type AllowanceList map[AllowanceType]float64
+type AllowanceList map[AllowanceType]money.Money
 
 type AllowanceType string
 
+type Bracket struct {
+	Lower money.Money
+	Upper money.Money
+	Rate  float64
+}
+
+type BracketTax struct {
+	Bracket
+	Tax money.Money
+}
+
 const (
 	Personal AllowanceType = "personal"
 	Donation AllowanceType = "donation"
@@ -29,95 +50,242 @@ var (
 	ErrNegativeIncome           = fmt.Errorf("income cannot be negative")
 	ErrNegativeAllowanceAmount  = fmt.Errorf("allowance amount cannot be negative")
 	ErrUnsupportedAllowanceType = fmt.Errorf("allowance type not supported")
+	ErrNoTaxBrackets            = fmt.Errorf("no tax brackets configured")
+	ErrNoAllowances             = fmt.Errorf("no allowances configured")
 )
 
-func (s *service) calculateAllowances(allowanceList []Allowance) (float64, error) {
-	allowances, err := s.getAllowances()
-	if err != nil {
-		s.log.Err(err).E("Failed to get allowances from database.")
-		return 0, err
+// Unbounded reports whether the bracket has no upper boundary.
+func (b Bracket) Unbounded() bool {
+	return b.Upper == money.Max
+}
+
+// calculateExpenses returns the income of every type with its standard expense, in the order of the expense rules.
+func (s *service) calculateExpenses(incomes []Income) ([]IncomeExpense, error) {
+	totals := make(map[IncomeType]money.Money, len(incomes))
+	for _, income := range incomes {
+		if income.Amount < 0 {
+			s.log.Fields(map[string]interface{}{"income": income}).W("Income cannot be negative.")
+			return nil, ErrNegativeIncome
+		}
+
+		if _, ok := findExpenseRule(income.Type); !ok {
+			s.log.Fields(map[string]interface{}{"income": income}).W("Income type not supported.")
+			return nil, ErrUnsupportedIncomeType
+		}
+
+		totals[income.Type] += income.Amount
+	}
+
+	var expenses []IncomeExpense
+	grouped := make(map[*ExpenseGroup]money.Money)
+	for _, rule := range expenseRules {
+		amount, ok := totals[rule.Type]
+		if !ok {
+			continue
+		}
+
+		expense := rule.expense(amount)
+		if rule.Group != nil {
+			expense = min(expense, rule.Group.Maximum-grouped[rule.Group])
+			grouped[rule.Group] += expense
+		}
+
+		expenses = append(expenses, IncomeExpense{Income: Income{Type: rule.Type, Amount: amount}, Expense: expense})
 	}
 
-	personal := allowances[Personal]
-	donation := 0.0
-	kreceipt := 0.0
+	return expenses, nil
+}
 
+// calculateAllowances returns the personal allowance followed by every claimed allowance within the limits of its rule
+// and the configured allowances. Allowances capped by the income left after the other allowances, such as donations,
+// are deducted last. Allowances granted to the filing status, such as the spouse allowance, are deducted in full without a
+// claim, and claims of allowances that do not apply to the filing status or are not available to the residency are rejected.
+func (s *service) calculateAllowances(allowances AllowanceList, status FilingStatus, residency Residency, income, expenses money.Money, allowanceList []Allowance) ([]AllowanceDeduction, error) {
+	claimed := make(map[AllowanceType]money.Money, len(allowanceList))
+	counted := make(map[AllowanceType]money.Money, len(allowanceList))
+	clamped := make(map[AllowanceType]bool)
 	for _, allowance := range allowanceList {
 		if allowance.Amount < 0 {
 			s.log.Fields(map[string]interface{}{"allowance": allowance}).W("Allowance amount cannot be negative.")
-			return 0, ErrNegativeAllowanceAmount
+			return nil, ErrNegativeAllowanceAmount
 		}
 
-		switch allowance.Type {
-		case Donation:
-			donation += allowance.Amount
+		rule, ok := findAllowanceRule(allowance.Type)
+		if !ok {
+			s.log.Fields(map[string]interface{}{"allowance": allowance}).W("Allowance type not supported.")
+			return nil, ErrUnsupportedAllowanceType
+		}
 
-		case KReceipt:
-			kreceipt += allowance.Amount
+		if !rule.availableTo(residency) {
+			s.log.Fields(map[string]interface{}{"allowance": allowance, "residency": residency}).W("Allowance type is not available to non-residents.")
+			return nil, fmt.Errorf("%w: %s", ErrAllowanceNotForNonResident, allowance.Type)
+		}
 
-		default:
-			s.log.Fields(map[string]interface{}{"allowance": allowance}).W("Allowance type not supported.")
-			return 0, ErrUnsupportedAllowanceType
+		if !rule.appliesTo(status) {
+			s.log.Fields(map[string]interface{}{"allowance": allowance, "filingStatus": status}).W("Allowance type does not apply to the filing status.")
+			return nil, fmt.Errorf("%w: %s", ErrAllowanceNotApplicable, allowance.Type)
+		}
+
+		claimed[rule.Type] += allowance.Amount
+		counted[rule.Type] += rule.claim(allowance.Amount)
+		clamped[rule.Type] = clamped[rule.Type] || (rule.PerClaim > 0 && allowance.Amount > rule.PerClaim)
+	}
+
+	for _, rule := range allowanceRules {
+		if rule.grantedTo(status) && rule.availableTo(residency) {
+			claimed[rule.Type] = max(claimed[rule.Type], rule.Maximum)
+			counted[rule.Type] = max(counted[rule.Type], rule.Maximum)
+		}
+	}
+
+	total := allowances[Personal]
+	deductions := []AllowanceDeduction{{Type: Personal, Claimed: total, Deducted: total}}
+	limits := make(map[*AllowanceGroup]money.Money)
+	grouped := make(map[*AllowanceGroup]money.Money)
+	for _, last := range []bool{false, true} {
+		for _, rule := range allowanceRules {
+			amount, ok := counted[rule.Type]
+			if !ok || rule.deductedLast() != last {
+				continue
+			}
+
+			d := AllowanceDeduction{Type: rule.Type, Claimed: claimed[rule.Type]}
+			if clamped[rule.Type] {
+				d.Limit = LimitPerClaim
+			}
+
+			upper, cap := rule.limit(allowances, income)
+			if amount > upper {
+				d.Limit = cap
+			}
+			deduction := calculateAllowance(amount, 0, upper)
+
+			if rule.Group != nil {
+				if _, ok := limits[rule.Group]; !ok {
+					limits[rule.Group] = rule.Group.limit(allowances, income-expenses-total)
+				}
+				if left := limits[rule.Group] - grouped[rule.Group]; deduction > left {
+					deduction, d.Limit = left, LimitGroup
+				}
+				grouped[rule.Group] += deduction
+				d.Group = rule.Group.Name
+			}
+
+			d.Deducted = deduction
+			deductions = append(deductions, d)
+			total += deduction
 		}
 	}
 
-	donation = calculateAllowance(donation, 0, allowances[Donation])
-	kreceipt = calculateAllowance(kreceipt, 0, allowances[KReceipt])
+	return deductions, nil
+}
+
+// totalAllowances returns the sum of the deducted allowances.
+func totalAllowances(deductions []AllowanceDeduction) money.Money {
+	var total money.Money
+	for _, d := range deductions {
+		total += d.Deducted
+	}
 
-	return personal + donation + kreceipt, nil
+	return total
 }
 
-func calculateAllowance(amount, lower, upper float64) float64 {
+func calculateAllowance(amount, lower, upper money.Money) money.Money {
 	return min(max(amount, lower), upper)
 }
 
-func (s *service) getAllowances() (AllowanceList, error) {
-	row, err := s.db.QueryOne("SELECT personal, donation, k_receipt FROM allowances")
+// getSettings returns the allowances and brackets configured for the tax year, so that every
+// calculation of a request is made against the same snapshot of them.
+func (s *service) getSettings(taxYear int) (AllowanceList, []Bracket, error) {
+	allowances, err := s.getAllowances(taxYear, time.Now())
 	if err != nil {
-		return nil, err
+		s.log.Err(err).E("Failed to get allowances from database.")
+		return nil, nil, err
 	}
 
-	var personal, donation, kreceipt float64
-	err = row.Scan(&personal, &donation, &kreceipt)
+	brackets, err := s.getBrackets(taxYear)
+	if err != nil {
+		s.log.Err(err).E("Failed to get tax brackets from database.")
+		return nil, nil, err
+	}
+
+	return allowances, brackets, nil
+}
+
+// getAllowances returns the allowances active at the given time for the latest tax year up to the given one.
+func (s *service) getAllowances(taxYear int, at time.Time) (AllowanceList, error) {
+	allowances, err := deductions.Active[AllowanceType](s.db, taxYear, at)
 	if err != nil {
 		return nil, err
 	}
 
-	return AllowanceList{Personal: personal, Donation: donation, KReceipt: kreceipt}, nil
+	if len(allowances) == 0 {
+		return nil, ErrNoAllowances
+	}
+
+	return allowances, nil
 }
 
-func calculateStepTax(income, lower, upper float64, rate float64) float64 {
+func calculateStepTax(income, lower, upper money.Money, rate float64, rounding money.Rounding) money.Money {
 	taxableIncome := min(income, upper) - lower
-	return taxableIncome * rate
+	return taxableIncome.MulRound(rate, rounding)
+}
+
+// getBrackets returns the brackets of the latest tax year up to the given one.
+func (s *service) getBrackets(taxYear int) ([]Bracket, error) {
+	rows, err := s.db.Query(`SELECT lower_bound, upper_bound, rate FROM tax_brackets
+		WHERE deleted_at IS NULL AND tax_year = (SELECT MAX(tax_year) FROM tax_brackets WHERE tax_year <= $1 AND deleted_at IS NULL)
+		ORDER BY lower_bound`, taxYear)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var brackets []Bracket
+	for rows.Next() {
+		var lower money.Money
+		var upper *money.Money
+		var rate float64
+		if err := rows.Scan(&lower, &upper, &rate); err != nil {
+			return nil, err
+		}
+
+		bracket := Bracket{Lower: lower, Upper: money.Max, Rate: rate}
+		if upper != nil {
+			bracket.Upper = *upper
+		}
+		brackets = append(brackets, bracket)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(brackets) == 0 {
+		return nil, ErrNoTaxBrackets
+	}
+
+	return brackets, nil
 }
 
-func calculateProgressiveTax(income float64) (float64, []float64, error) {
+// calculateProgressiveTax rounds the tax of every bracket by the rounding before adding them up.
+func calculateProgressiveTax(income money.Money, brackets []Bracket, rounding money.Rounding) (money.Money, []BracketTax, error) {
 	if income < 0 {
 		return 0, nil, ErrNegativeIncome
 	}
 
-	total := 0.0
-	brackets := []struct {
-		lower float64
-		upper float64
-		rate  float64
-	}{
-		{0, 150000, 0},                   // 0% for income between 0 - 150,000
-		{150000, 500000, 0.10},           // 10% for income between 150,001 - 500,000
-		{500000, 1000000, 0.15},          // 15% for income between 500,001 - 1,000,000
-		{1000000, 2000000, 0.20},         // 20% for income between 1,000,001 - 2,000,000
-		{2000000, math.MaxFloat64, 0.35}, // 35% for income over 2,000,001
-	}
-	steps := make([]float64, len(brackets))
+	var total money.Money
+	levels := make([]BracketTax, len(brackets))
 
 	for i, bracket := range brackets {
-		if income > bracket.lower {
-			upper := math.Min(income, bracket.upper)
-			tax := calculateStepTax(income, bracket.lower, upper, bracket.rate)
-			steps[i] = tax
+		levels[i] = BracketTax{Bracket: bracket}
+		if income > bracket.Lower {
+			upper := min(income, bracket.Upper)
+			tax := calculateStepTax(income, bracket.Lower, upper, bracket.Rate, rounding)
+			levels[i].Tax = tax
 			total += tax
 		}
 	}
 
-	return total, steps, nil
+	return total, levels, nil
 }
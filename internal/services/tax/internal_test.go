@@ -1,13 +1,14 @@
 package tax
 
 import (
-	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/ztrixack/assessment-tax/internal/modules/database"
 	"github.com/ztrixack/assessment-tax/internal/modules/logger"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
 )
 
 func setup(t *testing.T) (*service, sqlmock.Sqlmock, func()) {
@@ -17,32 +18,68 @@ func setup(t *testing.T) (*service, sqlmock.Sqlmock, func()) {
 		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
 	}
 
-	svr := New(log, db)
+	svr := New(log, db, &config{Rounding: SatangRounding})
 
 	return svr, mock, func() {
 		db.Close()
 	}
 }
 
+var defaultBrackets = []Bracket{
+	{Lower: 0, Upper: 150000 * money.Baht, Rate: 0},
+	{Lower: 150000 * money.Baht, Upper: 500000 * money.Baht, Rate: 0.10},
+	{Lower: 500000 * money.Baht, Upper: 1000000 * money.Baht, Rate: 0.15},
+	{Lower: 1000000 * money.Baht, Upper: 2000000 * money.Baht, Rate: 0.20},
+	{Lower: 2000000 * money.Baht, Upper: money.Max, Rate: 0.35},
+}
+
+var defaultAllowances = AllowanceList{Donation: 10 * money.Baht, KReceipt: 50000 * money.Baht, Personal: 60000 * money.Baht}
+
+func toTaxLevels(taxes ...float64) []BracketTax {
+	levels := make([]BracketTax, len(defaultBrackets))
+	for i, bracket := range defaultBrackets {
+		levels[i] = BracketTax{Bracket: bracket, Tax: money.FromBaht(taxes[i])}
+	}
+	return levels
+}
+
+func mockAllowances(mock sqlmock.Sqlmock, taxYear int) {
+	rows := sqlmock.NewRows([]string{"type", "amount"}).
+		AddRow("donation", 10).
+		AddRow("k-receipt", 50000).
+		AddRow("personal", 60000)
+	mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").ExpectQuery().WithArgs(taxYear, sqlmock.AnyArg()).WillReturnRows(rows)
+}
+
+func mockBrackets(mock sqlmock.Sqlmock, taxYear int) {
+	rows := sqlmock.NewRows([]string{"lower_bound", "upper_bound", "rate"}).
+		AddRow(0, 150000, 0).
+		AddRow(150000, 500000, 0.10).
+		AddRow(500000, 1000000, 0.15).
+		AddRow(1000000, 2000000, 0.20).
+		AddRow(2000000, nil, 0.35)
+	mock.ExpectPrepare("SELECT lower_bound, upper_bound, rate FROM tax_brackets").ExpectQuery().WithArgs(taxYear).WillReturnRows(rows)
+}
+
 func TestCalculateStepTax(t *testing.T) {
 	tests := []struct {
 		name        string
-		income      float64
-		lower       float64
-		upper       float64
+		income      money.Money
+		lower       money.Money
+		upper       money.Money
 		rate        float64
-		expectedTax float64
+		expectedTax money.Money
 	}{
-		{"Zero rate", 100000, 0, 150000, 0, 0},
-		{"Within first bracket", 200000, 150000, 500000, 0.10, 5000},
-		{"Within second bracket", 750000, 500000, 1000000, 0.15, 37500},
-		{"Within third bracket", 1500000, 1000000, 2000000, 0.20, 100000},
-		{"Within fourth bracket", 3000000, 2000000, 3000000, 0.35, 350000},
+		{"Zero rate", 100000 * money.Baht, 0, 150000 * money.Baht, 0, 0},
+		{"Within first bracket", 200000 * money.Baht, 150000 * money.Baht, 500000 * money.Baht, 0.10, 5000 * money.Baht},
+		{"Within second bracket", 750000 * money.Baht, 500000 * money.Baht, 1000000 * money.Baht, 0.15, 37500 * money.Baht},
+		{"Within third bracket", 1500000 * money.Baht, 1000000 * money.Baht, 2000000 * money.Baht, 0.20, 100000 * money.Baht},
+		{"Within fourth bracket", 3000000 * money.Baht, 2000000 * money.Baht, 3000000 * money.Baht, 0.35, 350000 * money.Baht},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotTax := calculateStepTax(tt.income, tt.lower, tt.upper, tt.rate)
+			gotTax := calculateStepTax(tt.income, tt.lower, tt.upper, tt.rate, money.ToSatang)
 			assert.Equal(t, tt.expectedTax, gotTax)
 		})
 	}
@@ -51,35 +88,35 @@ func TestCalculateStepTax(t *testing.T) {
 func TestCalculateProgressiveTax(t *testing.T) {
 	tests := []struct {
 		name          string
-		income        float64
-		expectedTax   float64
+		income        money.Money
+		expectedTax   money.Money
 		expectedSteps []float64
 		expectedErr   error
 	}{
 		{
 			name:          "Story: EXP01",
-			income:        440000,
-			expectedTax:   29000,
+			income:        440000 * money.Baht,
+			expectedTax:   29000 * money.Baht,
 			expectedSteps: []float64{0, 29000, 0, 0, 0},
 			expectedErr:   nil,
 		},
 		{
 			name:          "Story: EXP03",
-			income:        340000,
-			expectedTax:   19000,
+			income:        340000 * money.Baht,
+			expectedTax:   19000 * money.Baht,
 			expectedSteps: []float64{0, 19000, 0, 0, 0},
 			expectedErr:   nil,
 		},
 		{
 			name:          "Story: EXP07",
-			income:        290000,
-			expectedTax:   14000,
+			income:        290000 * money.Baht,
+			expectedTax:   14000 * money.Baht,
 			expectedSteps: []float64{0, 14000, 0, 0, 0},
 			expectedErr:   nil,
 		},
 		{
 			name:        "Negative income",
-			income:      -100,
+			income:      -100 * money.Baht,
 			expectedTax: 0,
 			expectedErr: ErrNegativeIncome,
 		},
@@ -92,64 +129,71 @@ func TestCalculateProgressiveTax(t *testing.T) {
 		},
 		{
 			name:          "Income within first bracket (0% tax)",
-			income:        150000,
+			income:        150000 * money.Baht,
 			expectedTax:   0,
 			expectedSteps: []float64{0, 0, 0, 0, 0},
 			expectedErr:   nil,
 		},
 		{
 			name:          "Income within second bracket on the lower end (10% tax)",
-			income:        150001,
-			expectedTax:   0.1,
+			income:        150001 * money.Baht,
+			expectedTax:   money.FromBaht(0.1),
 			expectedSteps: []float64{0, 0.1, 0, 0, 0},
 			expectedErr:   nil,
 		},
+		{
+			name:          "Tax of satang above a bracket edge is rounded half up to the satang",
+			income:        150000*money.Baht + 5*money.Satang,
+			expectedTax:   1 * money.Satang,
+			expectedSteps: []float64{0, 0.01, 0, 0, 0},
+			expectedErr:   nil,
+		},
 		{
 			name:          "Income within second bracket on the upper end (10% tax)",
-			income:        500000,
-			expectedTax:   35000,
+			income:        500000 * money.Baht,
+			expectedTax:   35000 * money.Baht,
 			expectedSteps: []float64{0, 35000, 0, 0, 0},
 			expectedErr:   nil,
 		},
 		{
 			name:          "Income within third bracket on the lower end (15% tax)",
-			income:        500001,
-			expectedTax:   35000.15,
+			income:        500001 * money.Baht,
+			expectedTax:   money.FromBaht(35000.15),
 			expectedSteps: []float64{0, 35000, 0.15, 0, 0},
 			expectedErr:   nil,
 		},
 		{
 			name:          "Income within third bracket on the upper end (15% tax)",
-			income:        1000000,
-			expectedTax:   110000,
+			income:        1000000 * money.Baht,
+			expectedTax:   110000 * money.Baht,
 			expectedSteps: []float64{0, 35000, 75000, 0, 0},
 			expectedErr:   nil,
 		},
 		{
 			name:          "Income within fourth bracket on the lower end (20% tax)",
-			income:        1000001,
-			expectedTax:   110000.2,
+			income:        1000001 * money.Baht,
+			expectedTax:   money.FromBaht(110000.2),
 			expectedSteps: []float64{0, 35000, 75000, 0.2, 0},
 			expectedErr:   nil,
 		},
 		{
 			name:          "Income within fourth bracket on the upper end (20% tax)",
-			income:        2000000,
-			expectedTax:   310000,
+			income:        2000000 * money.Baht,
+			expectedTax:   310000 * money.Baht,
 			expectedSteps: []float64{0, 35000, 75000, 200000, 0},
 			expectedErr:   nil,
 		},
 		{
 			name:          "Income within fifth bracket on the lower end (35% tax)",
-			income:        2000001,
-			expectedTax:   310000.35,
+			income:        2000001 * money.Baht,
+			expectedTax:   money.FromBaht(310000.35),
 			expectedSteps: []float64{0, 35000, 75000, 200000, 0.35},
 			expectedErr:   nil,
 		},
 		{
 			name:          "Income within fifth bracket (35% tax)",
-			income:        5000000,
-			expectedTax:   1360000,
+			income:        5000000 * money.Baht,
+			expectedTax:   1360000 * money.Baht,
 			expectedSteps: []float64{0, 35000, 75000, 200000, 1050000},
 			expectedErr:   nil,
 		},
@@ -157,124 +201,317 @@ func TestCalculateProgressiveTax(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotTax, getStep, gotErr := calculateProgressiveTax(tt.income)
+			var expectedLevels []BracketTax
+			if tt.expectedSteps != nil {
+				expectedLevels = toTaxLevels(tt.expectedSteps...)
+			}
+
+			gotTax, gotLevels, gotErr := calculateProgressiveTax(tt.income, defaultBrackets, money.ToSatang)
 			assert.Equal(t, tt.expectedErr, gotErr)
-			assert.Equal(t, tt.expectedSteps, getStep)
+			assert.Equal(t, expectedLevels, gotLevels)
 			assert.Equal(t, tt.expectedTax, gotTax)
 		})
 	}
 }
 
-func TestCalculateAllowances(t *testing.T) {
-	defaultMockBehavior := func(mock sqlmock.Sqlmock) {
-		rows := sqlmock.NewRows([]string{"personal", "donation", "k_receipt"}).AddRow(60000, 100000, 50000)
-		mock.ExpectPrepare("SELECT personal, donation, k_receipt FROM allowances").ExpectQuery().WillReturnRows(rows)
+func TestCalculateProgressiveTaxWithCustomBrackets(t *testing.T) {
+	brackets := []Bracket{
+		{Lower: 0, Upper: 100000 * money.Baht, Rate: 0},
+		{Lower: 100000 * money.Baht, Upper: money.Max, Rate: 0.25},
+	}
+
+	gotTax, gotLevels, gotErr := calculateProgressiveTax(300000*money.Baht, brackets, money.ToSatang)
+
+	assert.NoError(t, gotErr)
+	assert.Equal(t, 50000*money.Baht, gotTax)
+	assert.Equal(t, []BracketTax{{Bracket: brackets[0], Tax: 0}, {Bracket: brackets[1], Tax: 50000 * money.Baht}}, gotLevels)
+}
+
+func TestGetAllowances(t *testing.T) {
+	at := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockBehavior   func(sqlmock.Sqlmock)
+		expectedResult AllowanceList
+		expectedErr    error
+	}{
+		{
+			name: "Allowances active at the calculation date",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"type", "amount"}).
+					AddRow("donation", 10).
+					AddRow("k-receipt", 50000).
+					AddRow("personal", 70000)
+				mock.ExpectPrepare("WHERE tax_year <= \\$1 AND effective_from <= \\$2 AND deleted_at IS NULL").ExpectQuery().WithArgs(2024, at).WillReturnRows(rows)
+			},
+			expectedResult: AllowanceList{Personal: 70000 * money.Baht, Donation: 10 * money.Baht, KReceipt: 50000 * money.Baht},
+		},
+		{
+			name: "No allowances active yet",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"type", "amount"})
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").ExpectQuery().WithArgs(2024, at).WillReturnRows(rows)
+			},
+			expectedErr: ErrNoAllowances,
+		},
+		{
+			name: "Error with database",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").ExpectQuery().WithArgs(2024, at).WillReturnError(assert.AnError)
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr, mock, close := setup(t)
+			defer close()
+
+			tt.mockBehavior(mock)
+
+			result, err := svr.getAllowances(2024, at)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedResult, result)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
 	}
+}
 
+func TestGetBrackets(t *testing.T) {
 	tests := []struct {
 		name           string
 		mockBehavior   func(sqlmock.Sqlmock)
+		expectedResult []Bracket
+		expectedErr    error
+	}{
+		{
+			name:           "Default brackets",
+			mockBehavior:   func(mock sqlmock.Sqlmock) { mockBrackets(mock, 2024) },
+			expectedResult: defaultBrackets,
+		},
+		{
+			name: "No brackets configured",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"lower_bound", "upper_bound", "rate"})
+				mock.ExpectPrepare("SELECT lower_bound, upper_bound, rate FROM tax_brackets").ExpectQuery().WithArgs(2024).WillReturnRows(rows)
+			},
+			expectedErr: ErrNoTaxBrackets,
+		},
+		{
+			name: "Error with database",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare("SELECT lower_bound, upper_bound, rate FROM tax_brackets").ExpectQuery().WithArgs(2024).WillReturnError(assert.AnError)
+			},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr, mock, close := setup(t)
+			defer close()
+
+			tt.mockBehavior(mock)
+
+			result, err := svr.getBrackets(2024)
+
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedResult, result)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestCalculateAllowances(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         FilingStatus
+		residency      Residency
 		allowances     []Allowance
-		expectedResult float64
+		expectedResult money.Money
 		wantErr        bool
 	}{
 		{
 			name:           "Story: EXP01",
-			mockBehavior:   defaultMockBehavior,
 			allowances:     []Allowance{{Type: "donation", Amount: 0}},
-			expectedResult: 60000,
+			expectedResult: 60000 * money.Baht,
 			wantErr:        false,
 		},
 		{
 			name:           "Story: EXP03",
-			mockBehavior:   defaultMockBehavior,
-			allowances:     []Allowance{{Type: Donation, Amount: 200000}},
-			expectedResult: 60000 + 100000,
+			allowances:     []Allowance{{Type: Donation, Amount: 200000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + (1000000-60000)*0.10),
 			wantErr:        false,
 		},
 		{
 			name:           "Story: EXP07",
-			mockBehavior:   defaultMockBehavior,
-			allowances:     []Allowance{{Type: KReceipt, Amount: 200000}, {Type: Donation, Amount: 100000}},
-			expectedResult: 60000 + 100000 + 50000,
+			allowances:     []Allowance{{Type: KReceipt, Amount: 200000 * money.Baht}, {Type: Donation, Amount: 100000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + 50000 + (1000000-60000-50000)*0.10),
 			wantErr:        false,
 		},
 		{
 			name:           "No allowances",
-			mockBehavior:   defaultMockBehavior,
 			allowances:     []Allowance{},
-			expectedResult: 60000,
+			expectedResult: 60000 * money.Baht,
 			wantErr:        false,
 		},
 		{
 			name:           "All minimum values",
-			mockBehavior:   defaultMockBehavior,
 			allowances:     []Allowance{{Type: Donation, Amount: 0}, {Type: KReceipt, Amount: 0}},
-			expectedResult: 60000,
+			expectedResult: 60000 * money.Baht,
 			wantErr:        false,
 		},
 		{
 			name:           "All maximum values",
-			mockBehavior:   defaultMockBehavior,
-			allowances:     []Allowance{{Type: Donation, Amount: 100000}, {Type: KReceipt, Amount: 50000}},
-			expectedResult: 60000 + 100000 + 50000,
+			allowances:     []Allowance{{Type: Donation, Amount: 100000 * money.Baht}, {Type: KReceipt, Amount: 50000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + 50000 + (1000000-60000-50000)*0.10),
 			wantErr:        false,
 		},
 		{
-			name:         "Negative amounts",
-			mockBehavior: defaultMockBehavior,
-			allowances:   []Allowance{{Type: Donation, Amount: -50}, {Type: KReceipt, Amount: -20}},
-			wantErr:      true,
+			name:       "Negative amounts",
+			allowances: []Allowance{{Type: Donation, Amount: -50 * money.Baht}, {Type: KReceipt, Amount: -20 * money.Baht}},
+			wantErr:    true,
 		},
 		{
 			name:           "Above maximum limits",
-			mockBehavior:   defaultMockBehavior,
-			allowances:     []Allowance{{Type: Donation, Amount: 100001}, {Type: KReceipt, Amount: 50001}},
-			expectedResult: 60000 + 100000 + 50000,
+			allowances:     []Allowance{{Type: Donation, Amount: 100001 * money.Baht}, {Type: KReceipt, Amount: 50001 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + 50000 + (1000000-60000-50000)*0.10),
 			wantErr:        false,
 		},
 		{
 			name:           "Multi allowances and below maximum limits",
-			mockBehavior:   defaultMockBehavior,
-			allowances:     []Allowance{{Type: Donation, Amount: 30000}, {Type: Donation, Amount: 30000}},
-			expectedResult: 60000 + 60000,
+			allowances:     []Allowance{{Type: Donation, Amount: 30000 * money.Baht}, {Type: Donation, Amount: 30000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + 60000),
 			wantErr:        false,
 		},
 		{
 			name:           "Multi allowances and above maximum limits",
-			mockBehavior:   defaultMockBehavior,
-			allowances:     []Allowance{{Type: Donation, Amount: 60000}, {Type: Donation, Amount: 80000}},
-			expectedResult: 60000 + 100000,
+			allowances:     []Allowance{{Type: Donation, Amount: 60000 * money.Baht}, {Type: Donation, Amount: 80000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + (1000000-60000)*0.10),
 			wantErr:        false,
 		},
 		{
 			name:           "Multi KReceipt and below maximum limits",
-			mockBehavior:   defaultMockBehavior,
-			allowances:     []Allowance{{Type: KReceipt, Amount: 20000}, {Type: KReceipt, Amount: 10000}},
-			expectedResult: 60000 + 30000,
+			allowances:     []Allowance{{Type: KReceipt, Amount: 20000 * money.Baht}, {Type: KReceipt, Amount: 10000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + 30000),
 			wantErr:        false,
 		},
 		{
 			name:           "Multi KReceipt and above maximum limits",
-			mockBehavior:   defaultMockBehavior,
-			allowances:     []Allowance{{Type: KReceipt, Amount: 30000}, {Type: KReceipt, Amount: 30000}},
-			expectedResult: 60000 + 50000,
+			allowances:     []Allowance{{Type: KReceipt, Amount: 30000 * money.Baht}, {Type: KReceipt, Amount: 30000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + 50000),
 			wantErr:        false,
 		},
 		{
-			name:         "Unknown allowance type",
-			mockBehavior: defaultMockBehavior,
-			allowances:   []Allowance{{Type: "uknnown", Amount: 30000}},
-			wantErr:      true,
+			name:       "Unknown allowance type",
+			allowances: []Allowance{{Type: "uknnown", Amount: 30000 * money.Baht}},
+			wantErr:    true,
 		},
 		{
-			name: "Error with database",
-			mockBehavior: func(mock sqlmock.Sqlmock) {
-				mock.ExpectPrepare("SELECT personal, donation, k_receipt FROM allowances").ExpectQuery().WillReturnError(errors.New("some error"))
-			},
-			allowances: []Allowance{},
+			name:           "Spouse above maximum limit",
+			status:         SpouseNoIncome,
+			allowances:     []Allowance{{Type: Spouse, Amount: 80000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + 60000),
+			wantErr:        false,
+		},
+		{
+			name:           "Spouse granted without a claim when filing jointly",
+			status:         Joint,
+			allowances:     []Allowance{},
+			expectedResult: money.FromBaht(60000 + 60000),
+			wantErr:        false,
+		},
+		{
+			name:       "Spouse claimed by a single filer",
+			status:     Single,
+			allowances: []Allowance{{Type: Spouse, Amount: 60000 * money.Baht}},
+			wantErr:    true,
+		},
+		{
+			name:       "Spouse claimed when filing separately",
+			status:     Separate,
+			allowances: []Allowance{{Type: Spouse, Amount: 60000 * money.Baht}},
+			wantErr:    true,
+		},
+		{
+			name:           "Children are capped per child",
+			allowances:     []Allowance{{Type: Child, Amount: 30000 * money.Baht}, {Type: Child, Amount: 50000 * money.Baht}, {Type: Child, Amount: 10000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + 30000 + 30000 + 10000),
+			wantErr:        false,
+		},
+		{
+			name:           "Parental care is capped per parent and in total",
+			allowances:     []Allowance{{Type: ParentalCare, Amount: 40000 * money.Baht}, {Type: ParentalCare, Amount: 30000 * money.Baht}, {Type: ParentalCare, Amount: 30000 * money.Baht}, {Type: ParentalCare, Amount: 30000 * money.Baht}, {Type: ParentalCare, Amount: 30000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + 120000),
+			wantErr:        false,
+		},
+		{
+			name:           "Life and health insurance share their maximum",
+			allowances:     []Allowance{{Type: LifeInsurance, Amount: 90000 * money.Baht}, {Type: HealthInsurance, Amount: 25000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + 100000),
+			wantErr:        false,
+		},
+		{
+			name:           "Education and hospital donations count double",
+			allowances:     []Allowance{{Type: EducationDonation, Amount: 10000 * money.Baht}, {Type: HospitalDonation, Amount: 5000 * money.Baht}, {Type: Donation, Amount: 20000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + 20000 + 20000 + 10000),
+			wantErr:        false,
+		},
+		{
+			name:           "Donations share a cap on the income left after the other allowances",
+			status:         SpouseNoIncome,
+			allowances:     []Allowance{{Type: EducationDonation, Amount: 50000 * money.Baht}, {Type: Donation, Amount: 10000 * money.Baht}, {Type: Spouse, Amount: 60000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + 60000 + (1000000-60000-60000)*0.10),
+			wantErr:        false,
+		},
+		{
+			name:           "Retirement funds are capped by the income and share their maximum",
+			allowances:     []Allowance{{Type: ProvidentFund, Amount: 200000 * money.Baht}, {Type: RMF, Amount: 400000 * money.Baht}, {Type: SSF, Amount: 100000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + 150000 + 300000 + 50000),
+			wantErr:        false,
+		},
+		{
+			name:           "ThaiESG is capped by the income apart from retirement funds",
+			allowances:     []Allowance{{Type: RMF, Amount: 500000 * money.Baht}, {Type: ThaiESG, Amount: 400000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + 300000 + 300000),
+			wantErr:        false,
+		},
+		{
+			name:           "Non-resident claims allowances open to everyone",
+			residency:      NonResident,
+			allowances:     []Allowance{{Type: Donation, Amount: 20000 * money.Baht}, {Type: LifeInsurance, Amount: 50000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + 50000 + 20000),
+			wantErr:        false,
+		},
+		{
+			name:       "Non-resident claims k-receipt",
+			residency:  NonResident,
+			allowances: []Allowance{{Type: KReceipt, Amount: 20000 * money.Baht}},
+			wantErr:    true,
+		},
+		{
+			name:       "Non-resident claims a child",
+			residency:  NonResident,
+			allowances: []Allowance{{Type: Child, Amount: 30000 * money.Baht}},
 			wantErr:    true,
 		},
+		{
+			name:           "Spouse not granted to a non-resident filing jointly",
+			status:         Joint,
+			residency:      NonResident,
+			allowances:     []Allowance{},
+			expectedResult: money.FromBaht(60000),
+			wantErr:        false,
+		},
+		{
+			name:           "Home loan interest and social security above maximum limits",
+			allowances:     []Allowance{{Type: HomeLoanInterest, Amount: 150000 * money.Baht}, {Type: SocialSecurity, Amount: 10000 * money.Baht}},
+			expectedResult: money.FromBaht(60000 + 100000 + 9000),
+			wantErr:        false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -282,9 +519,8 @@ func TestCalculateAllowances(t *testing.T) {
 			svr, mock, close := setup(t)
 			defer close()
 
-			tt.mockBehavior(mock)
-
-			result, err := svr.calculateAllowances(tt.allowances)
+			deductions, err := svr.calculateAllowances(defaultAllowances, tt.status, tt.residency, 1000000*money.Baht, 0, tt.allowances)
+			result := totalAllowances(deductions)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -297,3 +533,44 @@ func TestCalculateAllowances(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateAllowancesTrace(t *testing.T) {
+	svr, mock, close := setup(t)
+	defer close()
+
+	deductions, err := svr.calculateAllowances(defaultAllowances, SpouseNoIncome, Resident, 1000000*money.Baht, 0, []Allowance{
+		{Type: Child, Amount: 40000 * money.Baht},
+		{Type: Child, Amount: 20000 * money.Baht},
+		{Type: Spouse, Amount: 10000 * money.Baht},
+		{Type: KReceipt, Amount: 80000 * money.Baht},
+		{Type: LifeInsurance, Amount: 90000 * money.Baht},
+		{Type: HealthInsurance, Amount: 25000 * money.Baht},
+		{Type: RMF, Amount: 400000 * money.Baht},
+		{Type: EducationDonation, Amount: 100000 * money.Baht},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []AllowanceDeduction{
+		{Type: Personal, Claimed: 60000 * money.Baht, Deducted: 60000 * money.Baht},
+		{Type: KReceipt, Claimed: 80000 * money.Baht, Deducted: 50000 * money.Baht, Limit: LimitConfigured},
+		{Type: Spouse, Claimed: 60000 * money.Baht, Deducted: 60000 * money.Baht},
+		{Type: Child, Claimed: 60000 * money.Baht, Deducted: 50000 * money.Baht, Limit: LimitPerClaim},
+		{Type: LifeInsurance, Claimed: 90000 * money.Baht, Deducted: 90000 * money.Baht, Group: "insurance"},
+		{Type: HealthInsurance, Claimed: 25000 * money.Baht, Deducted: 10000 * money.Baht, Limit: LimitGroup, Group: "insurance"},
+		{Type: RMF, Claimed: 400000 * money.Baht, Deducted: 300000 * money.Baht, Limit: LimitIncomeRate, Group: "retirement"},
+		{Type: EducationDonation, Claimed: 100000 * money.Baht, Deducted: 38000 * money.Baht, Limit: LimitGroup, Group: "donation"},
+	}, deductions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCalculateProgressiveTaxRounding(t *testing.T) {
+	income := 150000*money.Baht + 5*money.Satang
+
+	gotTax, _, gotErr := calculateProgressiveTax(income, defaultBrackets, money.ToSatang)
+	assert.NoError(t, gotErr)
+	assert.Equal(t, 1*money.Satang, gotTax)
+
+	gotTax, _, gotErr = calculateProgressiveTax(income, defaultBrackets, RevenueDepartmentRounding.TaxLevel)
+	assert.NoError(t, gotErr)
+	assert.Equal(t, money.Money(0), gotTax)
+}
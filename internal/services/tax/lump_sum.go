@@ -0,0 +1,102 @@
+package tax
+
+import (
+	"fmt"
+
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+// LumpSumType is the kind of payment made once on leaving employment.
+type LumpSumType string
+
+const (
+	Severance         LumpSumType = "severance"
+	ProvidentFundLump LumpSumType = "provident-fund"
+)
+
+// LumpSumDeductionPerYear is deducted from a lump sum for every year of service, before half of
+// what is left is deducted too.
+const LumpSumDeductionPerYear = 7000 * money.Baht
+
+// MaximumYearsOfService is the most years of service a lump sum can be paid for.
+const MaximumYearsOfService = 100
+
+var (
+	ErrUnsupportedLumpSumType = fmt.Errorf("lump sum type not supported")
+	ErrInvalidYearsOfService  = fmt.Errorf("years of service must be between 1 and %d", MaximumYearsOfService)
+)
+
+// LumpSum is a payment made once on leaving employment, such as severance pay or a provident fund
+// lump sum on retirement, which is taxed apart from the other income.
+type LumpSum struct {
+	Type           LumpSumType
+	Amount         money.Money
+	YearsOfService int
+}
+
+// LumpSumTax is the tax of the lump sums, worked out by the brackets apart from the other income and
+// without any allowance.
+type LumpSumTax struct {
+	Income money.Money
+	// Deduction is LumpSumDeductionPerYear for every year of service, and half of what is left.
+	Deduction money.Money
+	NetIncome money.Money
+	Tax       money.Money
+	TaxLevel  []BracketTax
+}
+
+// IsLumpSumType reports whether the lump sum type is supported.
+func IsLumpSumType(ltype LumpSumType) bool {
+	return ltype == Severance || ltype == ProvidentFundLump
+}
+
+// calculateLumpSums returns the separate tax of the lump sums, nil when there are none.
+func (s *service) calculateLumpSums(lumpSums []LumpSum, brackets []Bracket) (*LumpSumTax, error) {
+	if len(lumpSums) == 0 {
+		return nil, nil
+	}
+
+	res := &LumpSumTax{}
+	for _, lumpSum := range lumpSums {
+		if err := lumpSum.validate(); err != nil {
+			s.log.Err(err).Fields(map[string]interface{}{"lumpSum": lumpSum}).E("Invalid lump sum")
+			return nil, err
+		}
+
+		res.Income += lumpSum.Amount
+		res.Deduction += lumpSum.deduction()
+	}
+
+	res.NetIncome = (res.Income - res.Deduction).Round(s.rounding.NetIncome)
+	tax, levels, err := calculateProgressiveTax(res.NetIncome, brackets, s.rounding.TaxLevel)
+	if err != nil {
+		s.log.Err(err).Fields(map[string]interface{}{"netIncome": res.NetIncome}).E("Failed to calculate lump sum tax")
+		return nil, err
+	}
+	res.Tax, res.TaxLevel = tax.Round(s.rounding.Tax), levels
+
+	return res, nil
+}
+
+func (l LumpSum) validate() error {
+	if !IsLumpSumType(l.Type) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedLumpSumType, l.Type)
+	}
+
+	if l.Amount < 0 {
+		return ErrNegativeIncome
+	}
+
+	if l.YearsOfService < 1 || l.YearsOfService > MaximumYearsOfService {
+		return ErrInvalidYearsOfService
+	}
+
+	return nil
+}
+
+// deduction returns LumpSumDeductionPerYear for every year of service up to the amount, and half of
+// what is left.
+func (l LumpSum) deduction() money.Money {
+	perYear := min(LumpSumDeductionPerYear*money.Money(l.YearsOfService), l.Amount)
+	return perYear + (l.Amount-perYear).MulRatio(1, 2)
+}
@@ -0,0 +1,130 @@
+package tax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestLumpSumDeduction(t *testing.T) {
+	tests := []struct {
+		name     string
+		lumpSum  LumpSum
+		expected money.Money
+	}{
+		{"Years of service and half of the rest", LumpSum{Type: Severance, Amount: 1000000 * money.Baht, YearsOfService: 10}, 535000 * money.Baht},
+		{"Years of service above the amount", LumpSum{Type: Severance, Amount: 50000 * money.Baht, YearsOfService: 10}, 50000 * money.Baht},
+		{"Half of the rest rounded to the satang", LumpSum{Type: ProvidentFundLump, Amount: 7000*money.Baht + 1*money.Satang, YearsOfService: 1}, 7000*money.Baht + 1*money.Satang},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.lumpSum.deduction())
+		})
+	}
+}
+
+func TestCalculateLumpSums(t *testing.T) {
+	defaultMockBehavior := func(mock sqlmock.Sqlmock) {
+		mockAllowances(mock, 2024)
+		mockBrackets(mock, 2024)
+	}
+
+	tests := []struct {
+		name           string
+		mockBehavior   func(mock sqlmock.Sqlmock)
+		request        CalculateRequest
+		expectedTax    money.Money
+		expectedRefund money.Money
+		expected       *LumpSumTax
+		expectedErr    error
+	}{
+		{
+			name:         "Severance taxed apart from the salary",
+			mockBehavior: defaultMockBehavior,
+			request: CalculateRequest{
+				TaxYear:  2024,
+				Income:   500000 * money.Baht,
+				LumpSums: []LumpSum{{Type: Severance, Amount: 1000000 * money.Baht, YearsOfService: 10}},
+			},
+			expectedTax: (29000 + 31500) * money.Baht,
+			expected: &LumpSumTax{
+				Income:    1000000 * money.Baht,
+				Deduction: 535000 * money.Baht,
+				NetIncome: 465000 * money.Baht,
+				Tax:       31500 * money.Baht,
+				TaxLevel:  toTaxLevels(0, 31500, 0, 0, 0),
+			},
+		},
+		{
+			name:         "Lump sums are taxed together",
+			mockBehavior: defaultMockBehavior,
+			request: CalculateRequest{
+				TaxYear: 2024,
+				Income:  500000 * money.Baht,
+				WHT:     40000 * money.Baht,
+				LumpSums: []LumpSum{
+					{Type: Severance, Amount: 300000 * money.Baht, YearsOfService: 5},
+					{Type: ProvidentFundLump, Amount: 200000 * money.Baht, YearsOfService: 5},
+				},
+			},
+			expectedRefund: 4500 * money.Baht,
+			expected: &LumpSumTax{
+				Income:    500000 * money.Baht,
+				Deduction: 285000 * money.Baht,
+				NetIncome: 215000 * money.Baht,
+				Tax:       6500 * money.Baht,
+				TaxLevel:  toTaxLevels(0, 6500, 0, 0, 0),
+			},
+		},
+		{
+			name:         "Unsupported lump sum type",
+			mockBehavior: defaultMockBehavior,
+			request:      CalculateRequest{TaxYear: 2024, LumpSums: []LumpSum{{Type: "bonus", Amount: 100000 * money.Baht, YearsOfService: 5}}},
+			expectedErr:  ErrUnsupportedLumpSumType,
+		},
+		{
+			name:         "No years of service",
+			mockBehavior: defaultMockBehavior,
+			request:      CalculateRequest{TaxYear: 2024, LumpSums: []LumpSum{{Type: Severance, Amount: 100000 * money.Baht}}},
+			expectedErr:  ErrInvalidYearsOfService,
+		},
+		{
+			name:         "Years of service above the maximum",
+			mockBehavior: defaultMockBehavior,
+			request:      CalculateRequest{TaxYear: 2024, LumpSums: []LumpSum{{Type: Severance, Amount: 100000 * money.Baht, YearsOfService: 1 << 60}}},
+			expectedErr:  ErrInvalidYearsOfService,
+		},
+		{
+			name:         "Negative lump sum",
+			mockBehavior: defaultMockBehavior,
+			request:      CalculateRequest{TaxYear: 2024, LumpSums: []LumpSum{{Type: Severance, Amount: -1 * money.Baht, YearsOfService: 5}}},
+			expectedErr:  ErrNegativeIncome,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr, mock, close := setup(t)
+			defer close()
+
+			tt.mockBehavior(mock)
+
+			result, err := svr.Calculate(context.Background(), tt.request)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedTax, result.Tax)
+				assert.Equal(t, tt.expectedRefund, result.Refund)
+				assert.Equal(t, tt.expected, result.LumpSum)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
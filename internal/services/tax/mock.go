@@ -20,3 +20,66 @@ func (m *MockService) Calculate(ctx context.Context, req CalculateRequest) (*Cal
 
 	return args.Get(0).(*CalculateResponse), args.Error(1)
 }
+
+func (m *MockService) GrossUp(ctx context.Context, req GrossUpRequest) (*GrossUpResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*GrossUpResponse), args.Error(1)
+}
+
+func (m *MockService) Compare(ctx context.Context, req CompareRequest) (*CompareResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*CompareResponse), args.Error(1)
+}
+
+func (m *MockService) Optimize(ctx context.Context, req OptimizeRequest) (*OptimizeResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*OptimizeResponse), args.Error(1)
+}
+
+func (m *MockService) CalculatePayroll(ctx context.Context, req PayrollRequest) (*PayrollResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*PayrollResponse), args.Error(1)
+}
+
+func (m *MockService) ReconcileWithholding(ctx context.Context, req ReconcileRequest) (*ReconcileResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ReconcileResponse), args.Error(1)
+}
+
+func (m *MockService) CalculateSurcharge(ctx context.Context, req SurchargeRequest) (*SurchargeResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*SurchargeResponse), args.Error(1)
+}
+
+func (m *MockService) PlanInstallments(ctx context.Context, req InstallmentRequest) (*InstallmentResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*InstallmentResponse), args.Error(1)
+}
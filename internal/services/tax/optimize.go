@@ -0,0 +1,171 @@
+package tax
+
+import (
+	"context"
+
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+	"github.com/ztrixack/assessment-tax/internal/utils/taxyear"
+)
+
+// optimizedAllowances are the allowance types a filer can still spend on near the end of the year,
+// in the order they are recommended. Types the filer cannot claim for the residency are left out.
+var optimizedAllowances = []AllowanceType{Donation, KReceipt, RMF, SSF}
+
+type OptimizeRequest struct {
+	CalculateRequest
+}
+
+type OptimizeResponse struct {
+	TaxYear int
+	// Tax and Refund are those of the current claims.
+	Tax             money.Money
+	Refund          money.Money
+	Recommendations []Recommendation
+}
+
+// Recommendation is how much more of an allowance type is worth claiming on top of the current claims.
+// Every type is worked out on its own, so that types sharing a group cap do not add up.
+type Recommendation struct {
+	Type    AllowanceType
+	Claimed money.Money
+	// Headroom is the most that claiming more of the type still lowers the tax by, zero when it does not.
+	Headroom money.Money
+	// TaxSaved is how much lower the tax before the withholding tax is when the headroom is claimed.
+	TaxSaved money.Money
+	// Limit is the cap that ends the headroom, empty when the tax runs out before any cap is reached.
+	Limit AllowanceLimit
+}
+
+func (s *service) Optimize(ctx context.Context, req OptimizeRequest) (*OptimizeResponse, error) {
+	if req.Income < 0 {
+		s.log.Fields(map[string]interface{}{"income": req.Income}).E("Income cannot be negative")
+		return nil, ErrNegativeIncome
+	}
+
+	taxYear := taxyear.Resolve(req.TaxYear)
+	configured, brackets, err := s.getSettings(taxYear)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := s.getExchangeRates(taxYear, req.CalculateRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	converted, _, err := convertCurrencies(req.CalculateRequest, rates, taxYear)
+	if err != nil {
+		s.log.Err(err).E("Failed to convert the amounts to baht")
+		return nil, err
+	}
+
+	current, err := s.calculate(converted, taxYear, configured, brackets)
+	if err != nil {
+		return nil, err
+	}
+
+	income := converted.Income
+	for _, i := range converted.Incomes {
+		income += i.Amount
+	}
+
+	res := &OptimizeResponse{TaxYear: taxYear, Tax: current.Tax, Refund: current.Refund}
+	for _, atype := range optimizedAllowances {
+		if rule, _ := findAllowanceRule(atype); !rule.availableTo(converted.Residency) {
+			continue
+		}
+
+		calculate := func(amount money.Money) (*CalculateResponse, error) {
+			creq := converted
+			creq.Allowances = append(append([]Allowance{}, req.Allowances...), Allowance{Type: atype, Amount: amount})
+			creq.Explain = true
+			return s.calculate(creq, taxYear, configured, brackets)
+		}
+
+		recommendation, err := recommend(atype, income, brackets, current, calculate)
+		if err != nil {
+			return nil, err
+		}
+		recommendation.Claimed = claimedAllowance(req.Allowances, atype)
+		res.Recommendations = append(res.Recommendations, recommendation)
+	}
+
+	return res, nil
+}
+
+// recommend finds the headroom of the allowance type by bisecting the amount claimed on top of the
+// current claims down to the satang, until the net income falls to where claiming the whole income
+// takes it, or to the end of the brackets without tax. Claiming more than the income never lowers
+// the tax further, as the net income cannot fall below zero.
+func recommend(atype AllowanceType, income money.Money, brackets []Bracket, current *CalculateResponse, calculate func(money.Money) (*CalculateResponse, error)) (Recommendation, error) {
+	recommendation := Recommendation{Type: atype}
+
+	full, err := calculate(income)
+	if err != nil {
+		return recommendation, err
+	}
+
+	if full.totalTax() >= current.totalTax() {
+		return recommendation, nil
+	}
+
+	target := max(full.Explanation.NetIncome, taxFreeIncome(brackets))
+	lower, upper, reached := money.Money(0), income, full
+	for upper-lower > money.Satang {
+		middle := lower + (upper-lower)/2
+		res, err := calculate(middle)
+		if err != nil {
+			return recommendation, err
+		}
+		if res.Explanation.NetIncome <= target {
+			upper, reached = middle, res
+		} else {
+			lower = middle
+		}
+	}
+
+	recommendation.Headroom = upper
+	recommendation.TaxSaved = current.totalTax() - reached.totalTax()
+
+	// The cap ends the headroom when claiming the whole income deducts no more than the headroom does.
+	deducted, capped := deductedAllowance(reached, atype), deductedAllowance(full, atype)
+	if deducted.Deducted == capped.Deducted {
+		recommendation.Limit = capped.Limit
+	}
+
+	return recommendation, nil
+}
+
+// taxFreeIncome returns the net income up to which the brackets charge no tax.
+func taxFreeIncome(brackets []Bracket) money.Money {
+	var income money.Money
+	for _, bracket := range brackets {
+		if bracket.Rate > 0 {
+			break
+		}
+		income = bracket.Upper
+	}
+
+	return income
+}
+
+func deductedAllowance(res *CalculateResponse, atype AllowanceType) AllowanceDeduction {
+	for _, d := range res.Explanation.Allowances {
+		if d.Type == atype {
+			return d
+		}
+	}
+
+	return AllowanceDeduction{Type: atype}
+}
+
+func claimedAllowance(allowances []Allowance, atype AllowanceType) money.Money {
+	var claimed money.Money
+	for _, a := range allowances {
+		if a.Type == atype {
+			claimed += a.Amount
+		}
+	}
+
+	return claimed
+}
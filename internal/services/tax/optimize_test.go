@@ -0,0 +1,139 @@
+package tax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestOptimize(t *testing.T) {
+	defaultMockBehavior := func(mock sqlmock.Sqlmock) {
+		mockAllowances(mock, 2024)
+		mockBrackets(mock, 2024)
+	}
+
+	tests := []struct {
+		name         string
+		mockBehavior func(mock sqlmock.Sqlmock)
+		request      OptimizeRequest
+		expected     *OptimizeResponse
+		expectedErr  error
+	}{
+		{
+			name:         "Every allowance is worth claiming up to its cap",
+			mockBehavior: defaultMockBehavior,
+			request:      OptimizeRequest{CalculateRequest{TaxYear: 2024, Income: 500000 * money.Baht}},
+			expected: &OptimizeResponse{
+				TaxYear: 2024,
+				Tax:     29000 * money.Baht,
+				Recommendations: []Recommendation{
+					{Type: Donation, Headroom: 44000 * money.Baht, TaxSaved: 4400 * money.Baht, Limit: LimitGroup},
+					{Type: KReceipt, Headroom: 50000 * money.Baht, TaxSaved: 5000 * money.Baht, Limit: LimitConfigured},
+					{Type: RMF, Headroom: 150000 * money.Baht, TaxSaved: 15000 * money.Baht, Limit: LimitIncomeRate},
+					{Type: SSF, Headroom: 150000 * money.Baht, TaxSaved: 15000 * money.Baht, Limit: LimitIncomeRate},
+				},
+			},
+		},
+		{
+			name:         "Current claims use up part of the caps",
+			mockBehavior: defaultMockBehavior,
+			request: OptimizeRequest{CalculateRequest{
+				TaxYear:    2024,
+				Income:     500000 * money.Baht,
+				WHT:        30000 * money.Baht,
+				Allowances: []Allowance{{Type: KReceipt, Amount: 20000 * money.Baht}, {Type: RMF, Amount: 150000 * money.Baht}},
+			}},
+			expected: &OptimizeResponse{
+				TaxYear: 2024,
+				Tax:     0,
+				Refund:  18000 * money.Baht,
+				Recommendations: []Recommendation{
+					{Type: Donation, Headroom: 27000 * money.Baht, TaxSaved: 2700 * money.Baht, Limit: LimitGroup},
+					{Type: KReceipt, Claimed: 20000 * money.Baht, Headroom: 30000 * money.Baht, TaxSaved: 3000 * money.Baht, Limit: LimitConfigured},
+					{Type: RMF, Claimed: 150000 * money.Baht},
+					{Type: SSF, Headroom: 120000 * money.Baht, TaxSaved: 12000 * money.Baht},
+				},
+			},
+		},
+		{
+			name:         "The tax runs out before the caps",
+			mockBehavior: defaultMockBehavior,
+			request:      OptimizeRequest{CalculateRequest{TaxYear: 2024, Income: 250000 * money.Baht}},
+			expected: &OptimizeResponse{
+				TaxYear: 2024,
+				Tax:     4000 * money.Baht,
+				Recommendations: []Recommendation{
+					{Type: Donation, Headroom: 19000 * money.Baht, TaxSaved: 1900 * money.Baht, Limit: LimitGroup},
+					{Type: KReceipt, Headroom: 40000 * money.Baht, TaxSaved: 4000 * money.Baht},
+					{Type: RMF, Headroom: 40000 * money.Baht, TaxSaved: 4000 * money.Baht},
+					{Type: SSF, Headroom: 40000 * money.Baht, TaxSaved: 4000 * money.Baht},
+				},
+			},
+		},
+		{
+			name:         "No tax to save",
+			mockBehavior: defaultMockBehavior,
+			request:      OptimizeRequest{CalculateRequest{TaxYear: 2024, Income: 100000 * money.Baht}},
+			expected: &OptimizeResponse{
+				TaxYear:         2024,
+				Recommendations: []Recommendation{{Type: Donation}, {Type: KReceipt}, {Type: RMF}, {Type: SSF}},
+			},
+		},
+		{
+			name:         "Resident-only types are left out for non-residents",
+			mockBehavior: defaultMockBehavior,
+			request:      OptimizeRequest{CalculateRequest{TaxYear: 2024, Income: 500000 * money.Baht, Residency: NonResident}},
+			expected: &OptimizeResponse{
+				TaxYear: 2024,
+				Tax:     29000 * money.Baht,
+				Recommendations: []Recommendation{
+					{Type: Donation, Headroom: 44000 * money.Baht, TaxSaved: 4400 * money.Baht, Limit: LimitGroup},
+				},
+			},
+		},
+		{
+			name:         "Negative income",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      OptimizeRequest{CalculateRequest{TaxYear: 2024, Income: -1 * money.Baht}},
+			expectedErr:  ErrNegativeIncome,
+		},
+		{
+			name:         "Unsupported allowance type",
+			mockBehavior: defaultMockBehavior,
+			request:      OptimizeRequest{CalculateRequest{TaxYear: 2024, Income: 500000 * money.Baht, Allowances: []Allowance{{Type: "lottery", Amount: 1}}}},
+			expectedErr:  ErrUnsupportedAllowanceType,
+		},
+		{
+			name: "Error in database",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").ExpectQuery().WithArgs(2024, sqlmock.AnyArg()).WillReturnError(assert.AnError)
+			},
+			request:     OptimizeRequest{CalculateRequest{TaxYear: 2024, Income: 500000 * money.Baht}},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr, mock, close := setup(t)
+			defer close()
+
+			tt.mockBehavior(mock)
+
+			result, err := svr.Optimize(context.Background(), tt.request)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
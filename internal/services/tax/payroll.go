@@ -0,0 +1,129 @@
+package tax
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+	"github.com/ztrixack/assessment-tax/internal/utils/taxyear"
+)
+
+const MonthsInYear = 12
+
+var ErrInvalidPayrollMonth = fmt.Errorf("payroll month must be from 1 to 12")
+
+type PayrollRequest struct {
+	TaxYear       int
+	MonthlySalary money.Money
+	// StartMonth is the first month the salary is paid in the tax year, January when it is zero.
+	StartMonth int
+	Bonus      money.Money
+	// BonusMonth is the month the bonus is paid in, from the start month on, required when there is a bonus.
+	BonusMonth int
+	// Allowances are claimed for the whole tax year.
+	Allowances []Allowance
+}
+
+type PayrollResponse struct {
+	TaxYear int
+	// AnnualIncome is the salary of every month paid in the tax year, with the bonus.
+	AnnualIncome money.Money
+	// AnnualTax is the tax of the annual income, which the schedule withholds in full.
+	AnnualTax money.Money
+	// BonusTax is the part of the annual tax due to the bonus, withheld in the bonus month.
+	BonusTax money.Money
+	Schedule []PayrollMonth
+}
+
+// PayrollMonth is the pay of a month and the tax withheld from it on form PND1.
+type PayrollMonth struct {
+	Month       int
+	Salary      money.Money
+	Bonus       money.Money
+	Withholding money.Money
+}
+
+// CalculatePayroll works out the monthly withholding the Revenue Department way: the salary is
+// annualised over the months it is paid, the tax of that income is spread evenly over those months,
+// and the extra tax due to the bonus is withheld in full in the month it is paid.
+func (s *service) CalculatePayroll(ctx context.Context, req PayrollRequest) (*PayrollResponse, error) {
+	if req.MonthlySalary < 0 || req.Bonus < 0 {
+		s.log.Fields(map[string]interface{}{"salary": req.MonthlySalary, "bonus": req.Bonus}).E("Income cannot be negative")
+		return nil, ErrNegativeIncome
+	}
+
+	startMonth := max(req.StartMonth, 1)
+	if req.StartMonth < 0 || !isMonth(startMonth) || (req.Bonus > 0 && (!isMonth(req.BonusMonth) || req.BonusMonth < startMonth)) {
+		s.log.Fields(map[string]interface{}{"startMonth": req.StartMonth, "bonusMonth": req.BonusMonth}).E("Invalid payroll month")
+		return nil, ErrInvalidPayrollMonth
+	}
+
+	taxYear := taxyear.Resolve(req.TaxYear)
+	configured, brackets, err := s.getSettings(taxYear)
+	if err != nil {
+		return nil, err
+	}
+
+	annualTax := func(income money.Money) (money.Money, error) {
+		res, err := s.calculate(CalculateRequest{
+			TaxYear:    taxYear,
+			Incomes:    []Income{{Type: Salary, Amount: income}},
+			Allowances: req.Allowances,
+		}, taxYear, configured, brackets)
+		if err != nil {
+			return 0, err
+		}
+
+		return res.totalTax(), nil
+	}
+
+	months := MonthsInYear - startMonth + 1
+	salaryIncome := req.MonthlySalary * money.Money(months)
+	salaryTax, err := annualTax(salaryIncome)
+	if err != nil {
+		return nil, err
+	}
+
+	totalTax, err := annualTax(salaryIncome + req.Bonus)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := make([]PayrollMonth, MonthsInYear)
+	for i := range schedule {
+		schedule[i] = PayrollMonth{Month: i + 1}
+	}
+	copy(schedule[startMonth-1:], spreadWithholding(salaryTax, req.MonthlySalary, startMonth))
+
+	bonusTax := totalTax - salaryTax
+	if req.Bonus > 0 {
+		schedule[req.BonusMonth-1].Bonus = req.Bonus
+		schedule[req.BonusMonth-1].Withholding += bonusTax
+	}
+
+	return &PayrollResponse{
+		TaxYear:      taxYear,
+		AnnualIncome: salaryIncome + req.Bonus,
+		AnnualTax:    totalTax,
+		BonusTax:     bonusTax,
+		Schedule:     schedule,
+	}, nil
+}
+
+// spreadWithholding returns the months of the salary from the first month to the end of the tax year,
+// each withholding an even share of the tax rounded down to the satang. The last month withholds what
+// is left, so that the months add up to the tax and the last month never withholds less than the others.
+func spreadWithholding(tax, salary money.Money, firstMonth int) []PayrollMonth {
+	months := make([]PayrollMonth, MonthsInYear-firstMonth+1)
+	monthly := tax / money.Money(len(months))
+	for i := range months {
+		months[i] = PayrollMonth{Month: firstMonth + i, Salary: salary, Withholding: monthly}
+	}
+	months[len(months)-1].Withholding = tax - monthly*money.Money(len(months)-1)
+
+	return months
+}
+
+func isMonth(month int) bool {
+	return month >= 1 && month <= MonthsInYear
+}
@@ -0,0 +1,199 @@
+package tax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+// toSchedule returns the payroll months of a fixed salary from the start month on, withholding the
+// monthly amount every month but December, which withholds the last amount.
+func toSchedule(startMonth int, salary, monthly, last money.Money) []PayrollMonth {
+	schedule := make([]PayrollMonth, MonthsInYear)
+	for i := range schedule {
+		schedule[i] = PayrollMonth{Month: i + 1}
+		if i+1 >= startMonth {
+			schedule[i].Salary, schedule[i].Withholding = salary, monthly
+		}
+	}
+	schedule[MonthsInYear-1].Withholding = last
+
+	return schedule
+}
+
+func TestCalculatePayroll(t *testing.T) {
+	defaultMockBehavior := func(mock sqlmock.Sqlmock) {
+		mockAllowances(mock, 2024)
+		mockBrackets(mock, 2024)
+	}
+
+	withBonus := toSchedule(1, 50000*money.Baht, money.FromBaht(2416.66), money.FromBaht(2416.74))
+	withBonus[2].Bonus, withBonus[2].Withholding = 100000*money.Baht, money.FromBaht(2416.66+12000)
+
+	tests := []struct {
+		name         string
+		mockBehavior func(mock sqlmock.Sqlmock)
+		request      PayrollRequest
+		expected     *PayrollResponse
+		expectedErr  error
+	}{
+		{
+			name:         "Salary of the whole year",
+			mockBehavior: defaultMockBehavior,
+			request:      PayrollRequest{TaxYear: 2024, MonthlySalary: 50000 * money.Baht},
+			expected: &PayrollResponse{
+				TaxYear:      2024,
+				AnnualIncome: 600000 * money.Baht,
+				AnnualTax:    29000 * money.Baht,
+				Schedule:     toSchedule(1, 50000*money.Baht, money.FromBaht(2416.66), money.FromBaht(2416.74)),
+			},
+		},
+		{
+			name:         "Bonus is withheld in the month it is paid",
+			mockBehavior: defaultMockBehavior,
+			request:      PayrollRequest{TaxYear: 2024, MonthlySalary: 50000 * money.Baht, Bonus: 100000 * money.Baht, BonusMonth: 3},
+			expected: &PayrollResponse{
+				TaxYear:      2024,
+				AnnualIncome: 700000 * money.Baht,
+				AnnualTax:    41000 * money.Baht,
+				BonusTax:     12000 * money.Baht,
+				Schedule:     withBonus,
+			},
+		},
+		{
+			name:         "Salary from the middle of the year is spread over the remaining months",
+			mockBehavior: defaultMockBehavior,
+			request:      PayrollRequest{TaxYear: 2024, MonthlySalary: 100000 * money.Baht, StartMonth: 7},
+			expected: &PayrollResponse{
+				TaxYear:      2024,
+				AnnualIncome: 600000 * money.Baht,
+				AnnualTax:    29000 * money.Baht,
+				Schedule:     toSchedule(7, 100000*money.Baht, money.FromBaht(4833.33), money.FromBaht(4833.35)),
+			},
+		},
+		{
+			name:         "Allowances lower the withholding",
+			mockBehavior: defaultMockBehavior,
+			request:      PayrollRequest{TaxYear: 2024, MonthlySalary: 50000 * money.Baht, Allowances: []Allowance{{Type: KReceipt, Amount: 50000 * money.Baht}}},
+			expected: &PayrollResponse{
+				TaxYear:      2024,
+				AnnualIncome: 600000 * money.Baht,
+				AnnualTax:    24000 * money.Baht,
+				Schedule:     toSchedule(1, 50000*money.Baht, 2000*money.Baht, 2000*money.Baht),
+			},
+		},
+		{
+			name:         "Negative salary",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      PayrollRequest{TaxYear: 2024, MonthlySalary: -1 * money.Baht},
+			expectedErr:  ErrNegativeIncome,
+		},
+		{
+			name:         "Start month out of range",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      PayrollRequest{TaxYear: 2024, MonthlySalary: 50000 * money.Baht, StartMonth: 13},
+			expectedErr:  ErrInvalidPayrollMonth,
+		},
+		{
+			name:         "Negative start month",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      PayrollRequest{TaxYear: 2024, MonthlySalary: 50000 * money.Baht, StartMonth: -1},
+			expectedErr:  ErrInvalidPayrollMonth,
+		},
+		{
+			name:         "Bonus without a month",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      PayrollRequest{TaxYear: 2024, MonthlySalary: 50000 * money.Baht, Bonus: 100000 * money.Baht},
+			expectedErr:  ErrInvalidPayrollMonth,
+		},
+		{
+			name:         "Bonus before the start month",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      PayrollRequest{TaxYear: 2024, MonthlySalary: 50000 * money.Baht, StartMonth: 7, Bonus: 100000 * money.Baht, BonusMonth: 3},
+			expectedErr:  ErrInvalidPayrollMonth,
+		},
+		{
+			name:         "Unsupported allowance type",
+			mockBehavior: defaultMockBehavior,
+			request:      PayrollRequest{TaxYear: 2024, MonthlySalary: 50000 * money.Baht, Allowances: []Allowance{{Type: "lottery", Amount: 1}}},
+			expectedErr:  ErrUnsupportedAllowanceType,
+		},
+		{
+			name: "Error in database",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").ExpectQuery().WithArgs(2024, sqlmock.AnyArg()).WillReturnError(assert.AnError)
+			},
+			request:     PayrollRequest{TaxYear: 2024, MonthlySalary: 50000 * money.Baht},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr, mock, close := setup(t)
+			defer close()
+
+			tt.mockBehavior(mock)
+
+			result, err := svr.CalculatePayroll(context.Background(), tt.request)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+
+				var withheld money.Money
+				for _, month := range result.Schedule {
+					withheld += month.Withholding
+				}
+				assert.Equal(t, result.AnnualTax, withheld)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSpreadWithholding(t *testing.T) {
+	tests := []struct {
+		name       string
+		tax        money.Money
+		firstMonth int
+		expected   []PayrollMonth
+	}{
+		{
+			name:       "Even share rounded down with the rest in the last month",
+			tax:        23000 * money.Baht,
+			firstMonth: 4,
+			expected:   toSchedule(4, 50000*money.Baht, money.FromBaht(2555.55), money.FromBaht(2555.60))[3:],
+		},
+		{
+			name:       "Tax smaller than a satang a month",
+			tax:        6 * money.Satang,
+			firstMonth: 1,
+			expected:   toSchedule(1, 50000*money.Baht, 0, 6*money.Satang),
+		},
+		{
+			name:       "No tax",
+			tax:        0,
+			firstMonth: 7,
+			expected:   toSchedule(7, 50000*money.Baht, 0, 0)[6:],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			months := spreadWithholding(tt.tax, 50000*money.Baht, tt.firstMonth)
+
+			assert.Equal(t, tt.expected, months)
+			for _, month := range months {
+				assert.GreaterOrEqual(t, month.Withholding, money.Money(0))
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package tax
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+	"github.com/ztrixack/assessment-tax/internal/utils/taxyear"
+)
+
+var (
+	ErrNoPaidMonths        = fmt.Errorf("no paid months to reconcile")
+	ErrDuplicatePaidMonth  = fmt.Errorf("paid months must be unique")
+	ErrNegativeWithholding = fmt.Errorf("withholding cannot be negative")
+)
+
+// PaidMonth is the salary paid in a month of the tax year and the tax withheld from it.
+type PaidMonth struct {
+	Month       int
+	Income      money.Money
+	Withholding money.Money
+}
+
+type ReconcileRequest struct {
+	TaxYear int
+	Months  []PaidMonth
+	// MonthlyIncome is the salary expected in every month after the last paid month, the income of the
+	// last paid month when it is zero.
+	MonthlyIncome money.Money
+	// Allowances are claimed for the whole tax year.
+	Allowances []Allowance
+}
+
+type ReconcileResponse struct {
+	TaxYear        int
+	IncomeToDate   money.Money
+	WithheldToDate money.Money
+	// ProjectedIncome is the income to date with the monthly income of every month left.
+	ProjectedIncome money.Money
+	ProjectedTax    money.Money
+	// ExpectedToDate is the share of the projected tax due on the income to date.
+	ExpectedToDate money.Money
+	// OverWithheld is how much more than expected was withheld to date, negative when it was less.
+	OverWithheld money.Money
+	// Remaining is the adjusted withholding of every month left in the tax year, which makes up the
+	// projected tax.
+	Remaining []PayrollMonth
+	// Balance is the tax left to pay when filing after the remaining months are withheld, negative
+	// for a refund when more than the projected tax was withheld to date.
+	Balance money.Money
+}
+
+// ReconcileWithholding projects the annual tax from the months paid to date, and adjusts the
+// withholding of the months left so that the tax year withholds the projected tax.
+func (s *service) ReconcileWithholding(ctx context.Context, req ReconcileRequest) (*ReconcileResponse, error) {
+	if err := validatePaidMonths(req.Months); err != nil {
+		s.log.Err(err).Fields(map[string]interface{}{"months": req.Months}).E("Invalid paid months")
+		return nil, err
+	}
+
+	if req.MonthlyIncome < 0 {
+		s.log.Fields(map[string]interface{}{"monthlyIncome": req.MonthlyIncome}).E("Income cannot be negative")
+		return nil, ErrNegativeIncome
+	}
+
+	res := &ReconcileResponse{TaxYear: taxyear.Resolve(req.TaxYear)}
+	last := req.Months[0]
+	for _, month := range req.Months {
+		res.IncomeToDate += month.Income
+		res.WithheldToDate += month.Withholding
+		if month.Month > last.Month {
+			last = month
+		}
+	}
+
+	monthly := req.MonthlyIncome
+	if monthly == 0 {
+		monthly = last.Income
+	}
+	res.ProjectedIncome = res.IncomeToDate + monthly*money.Money(MonthsInYear-last.Month)
+
+	configured, brackets, err := s.getSettings(res.TaxYear)
+	if err != nil {
+		return nil, err
+	}
+
+	projected, err := s.calculate(CalculateRequest{
+		TaxYear:    res.TaxYear,
+		Incomes:    []Income{{Type: Salary, Amount: res.ProjectedIncome}},
+		Allowances: req.Allowances,
+	}, res.TaxYear, configured, brackets)
+	if err != nil {
+		return nil, err
+	}
+
+	res.ProjectedTax = projected.totalTax()
+	res.ExpectedToDate = res.ProjectedTax.MulRatio(res.IncomeToDate, res.ProjectedIncome)
+	res.OverWithheld = res.WithheldToDate - res.ExpectedToDate
+
+	left := res.ProjectedTax - res.WithheldToDate
+	if last.Month < MonthsInYear {
+		res.Remaining = spreadWithholding(max(left, 0), monthly, last.Month+1)
+		left = min(left, 0)
+	}
+	res.Balance = left
+
+	return res, nil
+}
+
+func validatePaidMonths(months []PaidMonth) error {
+	if len(months) == 0 {
+		return ErrNoPaidMonths
+	}
+
+	paid := make(map[int]bool, len(months))
+	for _, month := range months {
+		if !isMonth(month.Month) {
+			return ErrInvalidPayrollMonth
+		}
+		if paid[month.Month] {
+			return fmt.Errorf("%w: %d", ErrDuplicatePaidMonth, month.Month)
+		}
+		if month.Income < 0 {
+			return ErrNegativeIncome
+		}
+		if month.Withholding < 0 {
+			return ErrNegativeWithholding
+		}
+		paid[month.Month] = true
+	}
+
+	return nil
+}
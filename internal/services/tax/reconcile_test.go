@@ -0,0 +1,141 @@
+package tax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+// toPaidMonths returns the months from January on, paying the income and withholding every month.
+func toPaidMonths(months int, income, withholding money.Money) []PaidMonth {
+	paid := make([]PaidMonth, months)
+	for i := range paid {
+		paid[i] = PaidMonth{Month: i + 1, Income: income, Withholding: withholding}
+	}
+
+	return paid
+}
+
+func TestReconcileWithholding(t *testing.T) {
+	defaultMockBehavior := func(mock sqlmock.Sqlmock) {
+		mockAllowances(mock, 2024)
+		mockBrackets(mock, 2024)
+	}
+
+	tests := []struct {
+		name         string
+		mockBehavior func(mock sqlmock.Sqlmock)
+		request      ReconcileRequest
+		expected     *ReconcileResponse
+		expectedErr  error
+	}{
+		{
+			name:         "Raise from April is under-withheld to date",
+			mockBehavior: defaultMockBehavior,
+			request:      ReconcileRequest{TaxYear: 2024, Months: toPaidMonths(3, 40000*money.Baht, 1000*money.Baht), MonthlyIncome: 50000 * money.Baht},
+			expected: &ReconcileResponse{
+				TaxYear:         2024,
+				IncomeToDate:    120000 * money.Baht,
+				WithheldToDate:  3000 * money.Baht,
+				ProjectedIncome: 570000 * money.Baht,
+				ProjectedTax:    26000 * money.Baht,
+				ExpectedToDate:  money.FromBaht(5473.68),
+				OverWithheld:    money.FromBaht(-2473.68),
+				Remaining:       spreadWithholding(23000*money.Baht, 50000*money.Baht, 4),
+			},
+		},
+		{
+			name:         "Over-withheld beyond the projected tax is refunded",
+			mockBehavior: defaultMockBehavior,
+			request:      ReconcileRequest{TaxYear: 2024, Months: toPaidMonths(3, 50000*money.Baht, 10000*money.Baht)},
+			expected: &ReconcileResponse{
+				TaxYear:         2024,
+				IncomeToDate:    150000 * money.Baht,
+				WithheldToDate:  30000 * money.Baht,
+				ProjectedIncome: 600000 * money.Baht,
+				ProjectedTax:    29000 * money.Baht,
+				ExpectedToDate:  7250 * money.Baht,
+				OverWithheld:    22750 * money.Baht,
+				Remaining:       spreadWithholding(0, 50000*money.Baht, 4),
+				Balance:         -1000 * money.Baht,
+			},
+		},
+		{
+			name:         "Whole year paid leaves the balance to filing",
+			mockBehavior: defaultMockBehavior,
+			request:      ReconcileRequest{TaxYear: 2024, Months: toPaidMonths(12, 50000*money.Baht, 2000*money.Baht)},
+			expected: &ReconcileResponse{
+				TaxYear:         2024,
+				IncomeToDate:    600000 * money.Baht,
+				WithheldToDate:  24000 * money.Baht,
+				ProjectedIncome: 600000 * money.Baht,
+				ProjectedTax:    29000 * money.Baht,
+				ExpectedToDate:  29000 * money.Baht,
+				OverWithheld:    -5000 * money.Baht,
+				Balance:         5000 * money.Baht,
+			},
+		},
+		{
+			name:         "No paid months",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      ReconcileRequest{TaxYear: 2024},
+			expectedErr:  ErrNoPaidMonths,
+		},
+		{
+			name:         "Duplicate paid months",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      ReconcileRequest{TaxYear: 2024, Months: []PaidMonth{{Month: 1}, {Month: 1}}},
+			expectedErr:  ErrDuplicatePaidMonth,
+		},
+		{
+			name:         "Month out of range",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      ReconcileRequest{TaxYear: 2024, Months: []PaidMonth{{Month: 13}}},
+			expectedErr:  ErrInvalidPayrollMonth,
+		},
+		{
+			name:         "Negative income",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      ReconcileRequest{TaxYear: 2024, Months: []PaidMonth{{Month: 1, Income: -1 * money.Baht}}},
+			expectedErr:  ErrNegativeIncome,
+		},
+		{
+			name:         "Negative withholding",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      ReconcileRequest{TaxYear: 2024, Months: []PaidMonth{{Month: 1, Withholding: -1 * money.Baht}}},
+			expectedErr:  ErrNegativeWithholding,
+		},
+		{
+			name: "Error in database",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare("SELECT DISTINCT ON \\(type\\) type, amount FROM deductions").ExpectQuery().WithArgs(2024, sqlmock.AnyArg()).WillReturnError(assert.AnError)
+			},
+			request:     ReconcileRequest{TaxYear: 2024, Months: toPaidMonths(1, 50000*money.Baht, 0)},
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr, mock, close := setup(t)
+			defer close()
+
+			tt.mockBehavior(mock)
+
+			result, err := svr.ReconcileWithholding(context.Background(), tt.request)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
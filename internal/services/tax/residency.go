@@ -0,0 +1,119 @@
+package tax
+
+import (
+	"fmt"
+
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+// Residency is whether the filer is a resident of Thailand, someone staying 180 days or more in the tax year.
+type Residency string
+
+const (
+	// Resident is taxed on the income sourced in Thailand and on the foreign-sourced income remitted to Thailand.
+	Resident Residency = "resident"
+	// NonResident is taxed on the income sourced in Thailand only, and cannot claim the allowances restricted
+	// to residents.
+	NonResident Residency = "non-resident"
+)
+
+var residencies = []Residency{Resident, NonResident}
+
+var (
+	ErrUnsupportedResidency       = fmt.Errorf("residency not supported")
+	ErrAllowanceNotForNonResident = fmt.Errorf("allowance type is not available to non-residents")
+	ErrNegativeForeignTax         = fmt.Errorf("foreign tax cannot be negative")
+	ErrForeignTaxNotForeignIncome = fmt.Errorf("foreign tax only applies to foreign-sourced income")
+	ErrForeignIncomeTaxedAtSource = fmt.Errorf("foreign-sourced income cannot be taxed at source or carry the dividend tax credit")
+)
+
+// IsResidency reports whether the residency is supported.
+func IsResidency(residency Residency) bool {
+	for _, r := range residencies {
+		if r == residency {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveResidency defaults an unset residency to resident.
+func resolveResidency(residency Residency) Residency {
+	if residency == "" {
+		return Resident
+	}
+
+	return residency
+}
+
+// validateResidency checks the residency and the foreign-sourced incomes of the filer and the spouse.
+func validateResidency(residency Residency, incomes []Income, spouse *SpouseIncome) error {
+	if !IsResidency(residency) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedResidency, residency)
+	}
+
+	if spouse != nil {
+		incomes = append(append([]Income{}, incomes...), spouse.Incomes...)
+	}
+
+	for _, income := range incomes {
+		if income.ForeignTax < 0 {
+			return ErrNegativeForeignTax
+		}
+
+		if !income.Foreign && income.ForeignTax > 0 {
+			return fmt.Errorf("%w: %s", ErrForeignTaxNotForeignIncome, income.Type)
+		}
+
+		if income.Foreign && (income.FinalWithholding || income.CorporateRate != 0) {
+			return fmt.Errorf("%w: %s", ErrForeignIncomeTaxedAtSource, income.Type)
+		}
+	}
+
+	return nil
+}
+
+// availableTo reports whether a filer of the residency can claim the type.
+func (r AllowanceRule) availableTo(residency Residency) bool {
+	return !r.ResidentOnly || residency != NonResident
+}
+
+// excludeForeignIncome returns the incomes taxed for the residency, and the foreign-sourced income
+// left out of the return of a non-resident.
+func excludeForeignIncome(incomes []Income, residency Residency) ([]Income, money.Money) {
+	if residency != NonResident {
+		return incomes, 0
+	}
+
+	var taxed []Income
+	var exempt money.Money
+	for _, income := range incomes {
+		if income.Foreign {
+			exempt += income.Amount
+			continue
+		}
+		taxed = append(taxed, income)
+	}
+
+	return taxed, exempt
+}
+
+// foreignTaxCredit returns the foreign tax paid on the foreign-sourced incomes, credited up to the share
+// of the tax that the foreign-sourced income makes up of the income, so that it never lowers the tax on
+// the income sourced in Thailand.
+func foreignTaxCredit(incomes []Income, tax, income money.Money) money.Money {
+	var foreignIncome, foreignTax money.Money
+	for _, i := range incomes {
+		if i.Foreign {
+			foreignIncome += i.Amount
+			foreignTax += i.ForeignTax
+		}
+	}
+
+	if foreignTax == 0 || income == 0 {
+		return 0
+	}
+
+	return min(foreignTax, tax.MulRatio(min(foreignIncome, income), income))
+}
@@ -0,0 +1,174 @@
+package tax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestValidateResidency(t *testing.T) {
+	tests := []struct {
+		name        string
+		residency   Residency
+		incomes     []Income
+		spouse      *SpouseIncome
+		expectedErr error
+	}{
+		{
+			name:      "Resident with foreign tax paid on remitted income",
+			residency: Resident,
+			incomes:   []Income{{Type: Salary, Amount: 200000 * money.Baht, Foreign: true, ForeignTax: 30000 * money.Baht}},
+		},
+		{
+			name:        "Unsupported residency",
+			residency:   "tourist",
+			expectedErr: ErrUnsupportedResidency,
+		},
+		{
+			name:        "Negative foreign tax",
+			residency:   Resident,
+			incomes:     []Income{{Type: Salary, Amount: 200000 * money.Baht, Foreign: true, ForeignTax: -money.Baht}},
+			expectedErr: ErrNegativeForeignTax,
+		},
+		{
+			name:        "Foreign tax of income sourced in Thailand",
+			residency:   Resident,
+			incomes:     []Income{{Type: Salary, Amount: 200000 * money.Baht, ForeignTax: 30000 * money.Baht}},
+			expectedErr: ErrForeignTaxNotForeignIncome,
+		},
+		{
+			name:        "Foreign tax of the spouse income sourced in Thailand",
+			residency:   NonResident,
+			spouse:      &SpouseIncome{Incomes: []Income{{Type: Salary, Amount: 200000 * money.Baht, ForeignTax: 30000 * money.Baht}}},
+			expectedErr: ErrForeignTaxNotForeignIncome,
+		},
+		{
+			name:        "Foreign dividend with the dividend tax credit",
+			residency:   Resident,
+			incomes:     []Income{{Type: Dividend, Amount: 80000 * money.Baht, Foreign: true, CorporateRate: 0.20}},
+			expectedErr: ErrForeignIncomeTaxedAtSource,
+		},
+		{
+			name:        "Foreign interest taxed at source",
+			residency:   Resident,
+			incomes:     []Income{{Type: Interest, Amount: 80000 * money.Baht, Foreign: true, FinalWithholding: true}},
+			expectedErr: ErrForeignIncomeTaxedAtSource,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, validateResidency(tt.residency, tt.incomes, tt.spouse), tt.expectedErr)
+		})
+	}
+}
+
+func TestForeignTaxCredit(t *testing.T) {
+	tests := []struct {
+		name     string
+		incomes  []Income
+		tax      money.Money
+		income   money.Money
+		expected money.Money
+	}{
+		{
+			name:     "Capped at the share of the tax of the foreign income",
+			incomes:  []Income{{Type: Salary, Amount: 400000 * money.Baht}, {Type: Salary, Amount: 200000 * money.Baht, Foreign: true, ForeignTax: 30000 * money.Baht}},
+			tax:      29000 * money.Baht,
+			income:   600000 * money.Baht,
+			expected: money.FromBaht(9666.67),
+		},
+		{
+			name:     "Foreign tax below the cap is credited in full",
+			incomes:  []Income{{Type: Salary, Amount: 400000 * money.Baht}, {Type: Salary, Amount: 200000 * money.Baht, Foreign: true, ForeignTax: 5000 * money.Baht}},
+			tax:      29000 * money.Baht,
+			income:   600000 * money.Baht,
+			expected: 5000 * money.Baht,
+		},
+		{
+			name:    "No foreign tax",
+			incomes: []Income{{Type: Salary, Amount: 200000 * money.Baht, Foreign: true}},
+			tax:     9000 * money.Baht,
+			income:  200000 * money.Baht,
+		},
+		{
+			name:    "No income",
+			incomes: []Income{{Type: Salary, Foreign: true, ForeignTax: 5000 * money.Baht}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, foreignTaxCredit(tt.incomes, tt.tax, tt.income))
+		})
+	}
+}
+
+func TestCalculateResidency(t *testing.T) {
+	defaultMockBehavior := func(mock sqlmock.Sqlmock) {
+		mockAllowances(mock, 2024)
+		mockBrackets(mock, 2024)
+	}
+	incomes := []Income{
+		{Type: Salary, Amount: 400000 * money.Baht},
+		{Type: Salary, Amount: 200000 * money.Baht, Foreign: true, ForeignTax: 30000 * money.Baht},
+	}
+
+	tests := []struct {
+		name              string
+		request           CalculateRequest
+		expectedResidency Residency
+		expectedTax       money.Money
+		expectedCredit    money.Money
+		expectedExempt    money.Money
+		expectedErr       error
+	}{
+		{
+			name:              "Resident is taxed on remitted foreign income with the foreign tax credited",
+			request:           CalculateRequest{TaxYear: 2024, Incomes: incomes},
+			expectedResidency: Resident,
+			expectedTax:       money.FromBaht(19333.33),
+			expectedCredit:    money.FromBaht(9666.67),
+		},
+		{
+			name:              "Non-resident is not taxed on foreign income",
+			request:           CalculateRequest{TaxYear: 2024, Residency: NonResident, Incomes: incomes},
+			expectedResidency: NonResident,
+			expectedTax:       9000 * money.Baht,
+			expectedExempt:    200000 * money.Baht,
+		},
+		{
+			name:        "Non-resident claims RMF",
+			request:     CalculateRequest{TaxYear: 2024, Residency: NonResident, Incomes: incomes, Allowances: []Allowance{{Type: RMF, Amount: 50000 * money.Baht}}},
+			expectedErr: ErrAllowanceNotForNonResident,
+		},
+		{
+			name:        "Unsupported residency",
+			request:     CalculateRequest{TaxYear: 2024, Residency: "tourist", Incomes: incomes},
+			expectedErr: ErrUnsupportedResidency,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svr, mock, close := setup(t)
+			defer close()
+
+			defaultMockBehavior(mock)
+
+			result, err := svr.Calculate(context.Background(), tt.request)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				assert.Equal(t, tt.expectedResidency, result.Residency)
+				assert.Equal(t, tt.expectedTax, result.Tax)
+				assert.Equal(t, tt.expectedCredit, result.ForeignTaxCredit)
+				assert.Equal(t, tt.expectedExempt, result.ExemptForeignIncome)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package tax
+
+import "github.com/ztrixack/assessment-tax/internal/utils/money"
+
+// RoundingPolicy is how the amounts of a calculation are rounded.
+type RoundingPolicy struct {
+	Name      string
+	NetIncome money.Rounding
+	// TaxLevel rounds the tax of every bracket on its own, before they are added up.
+	TaxLevel money.Rounding
+	// Tax rounds the tax of either method, and the tax payable after the withholding tax.
+	Tax    money.Rounding
+	Refund money.Rounding
+}
+
+var (
+	// SatangRounding rounds every amount half up to the satang.
+	SatangRounding = RoundingPolicy{
+		Name:      "satang",
+		NetIncome: money.ToSatang,
+		TaxLevel:  money.ToSatang,
+		Tax:       money.ToSatang,
+		Refund:    money.ToSatang,
+	}
+	// RevenueDepartmentRounding follows the personal income tax forms, which truncate the net
+	// income to whole baht and never round the tax of a bracket or the refund up.
+	RevenueDepartmentRounding = RoundingPolicy{
+		Name:      "revenue-department",
+		NetIncome: money.Rounding{Unit: money.Baht, Mode: money.RoundDown},
+		TaxLevel:  money.Rounding{Unit: money.Satang, Mode: money.RoundDown},
+		Tax:       money.Rounding{Unit: money.Satang, Mode: money.RoundHalfUp},
+		Refund:    money.Rounding{Unit: money.Satang, Mode: money.RoundDown},
+	}
+)
+
+var roundingPolicies = []RoundingPolicy{SatangRounding, RevenueDepartmentRounding}
+
+func findRoundingPolicy(name string) (RoundingPolicy, bool) {
+	for _, policy := range roundingPolicies {
+		if policy.Name == name {
+			return policy, true
+		}
+	}
+
+	return RoundingPolicy{}, false
+}
@@ -0,0 +1,116 @@
+package tax
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+	"github.com/ztrixack/assessment-tax/internal/utils/taxyear"
+)
+
+var (
+	ErrNegativeTaxDue   = fmt.Errorf("tax due cannot be negative")
+	ErrNoSurchargeRules = fmt.Errorf("no late filing surcharge configured")
+)
+
+type SurchargeRequest struct {
+	TaxYear int
+	// Tax is the tax due, such as the tax of a calculation.
+	Tax      money.Money
+	Deadline time.Time
+	PaidAt   time.Time
+}
+
+type SurchargeResponse struct {
+	TaxYear int
+	Tax     money.Money
+	// MonthsLate counts every month or part of a month from the deadline to the payment, zero when it is on time.
+	MonthsLate int
+	// Rate is the configured percentage of the tax charged for every month late, with up to two decimals.
+	Rate float64
+	// Surcharge is the rate of every month late, capped at the tax.
+	Surcharge money.Money
+	// Penalty is the configured fixed penalty, charged whenever the payment is late.
+	Penalty money.Money
+	// Total is the tax with the surcharge and the penalty.
+	Total money.Money
+}
+
+// CalculateSurcharge works out what paying the tax due after the deadline costs, with the late filing
+// rules of the latest tax year configured up to the given one.
+func (s *service) CalculateSurcharge(ctx context.Context, req SurchargeRequest) (*SurchargeResponse, error) {
+	if req.Tax < 0 {
+		s.log.Fields(map[string]interface{}{"tax": req.Tax}).E("Tax due cannot be negative")
+		return nil, ErrNegativeTaxDue
+	}
+
+	taxYear := taxyear.Resolve(req.TaxYear)
+	rate, penalty, err := s.getLateFilingRules(taxYear)
+	if err != nil {
+		s.log.Err(err).Fields(map[string]interface{}{"taxYear": taxYear}).E("Failed to get late filing rules from database.")
+		return nil, err
+	}
+
+	res := &SurchargeResponse{
+		TaxYear:    taxYear,
+		Tax:        req.Tax,
+		MonthsLate: monthsLate(req.Deadline, req.PaidAt),
+		Rate:       rate,
+	}
+	if res.MonthsLate > 0 {
+		// The rate is kept in hundredths of a percent so that the surcharge is worked out exactly.
+		hundredths := money.Money(math.Round(rate * 100))
+		res.Surcharge = min(req.Tax.MulRatio(hundredths*money.Money(res.MonthsLate), 100*100), req.Tax)
+		res.Penalty = penalty
+	}
+	res.Total = res.Tax + res.Surcharge + res.Penalty
+
+	return res, nil
+}
+
+// getLateFilingRules returns the surcharge rate and the penalty of the latest tax year up to the given one.
+func (s *service) getLateFilingRules(taxYear int) (float64, money.Money, error) {
+	var rate float64
+	var penalty money.Money
+	row, err := s.db.QueryOne(`SELECT surcharge_rate, penalty FROM late_filing_rules
+		WHERE tax_year <= $1 AND deleted_at IS NULL ORDER BY tax_year DESC LIMIT 1`, taxYear)
+	if err == nil {
+		err = row.Scan(&rate, &penalty)
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, 0, ErrNoSurchargeRules
+	}
+
+	return rate, penalty, err
+}
+
+// monthsLate counts every month or part of a month the payment date is past the deadline, by calendar date.
+// A month after the deadline falls on the same day of the month, or on the last day of a shorter month.
+func monthsLate(deadline, paidAt time.Time) int {
+	deadline, paidAt = toDate(deadline), toDate(paidAt)
+
+	months := 0
+	for paidAt.After(addMonths(deadline, months)) {
+		months++
+	}
+
+	return months
+}
+
+func toDate(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func addMonths(date time.Time, months int) time.Time {
+	year, month, day := date.Date()
+	first := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+
+	return first.AddDate(0, 0, min(day, last)-1)
+}
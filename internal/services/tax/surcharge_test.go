@@ -0,0 +1,170 @@
+package tax
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func mockLateFilingRules(mock sqlmock.Sqlmock, taxYear int) {
+	rows := sqlmock.NewRows([]string{"surcharge_rate", "penalty"}).AddRow(1.5, 200)
+	mock.ExpectPrepare("SELECT surcharge_rate, penalty FROM late_filing_rules").ExpectQuery().WithArgs(taxYear).WillReturnRows(rows)
+}
+
+func TestCalculateSurcharge(t *testing.T) {
+	deadline := time.Date(2025, time.April, 8, 0, 0, 0, 0, time.UTC)
+	defaultMockBehavior := func(mock sqlmock.Sqlmock) {
+		mockLateFilingRules(mock, 2024)
+	}
+
+	tests := []struct {
+		name         string
+		mockBehavior func(mock sqlmock.Sqlmock)
+		request      SurchargeRequest
+		expected     *SurchargeResponse
+		expectedErr  error
+	}{
+		{
+			name:         "Paid on the deadline",
+			mockBehavior: defaultMockBehavior,
+			request:      SurchargeRequest{TaxYear: 2024, Tax: 100000 * money.Baht, Deadline: deadline, PaidAt: deadline.Add(20 * time.Hour)},
+			expected: &SurchargeResponse{
+				TaxYear: 2024,
+				Tax:     100000 * money.Baht,
+				Rate:    1.5,
+				Total:   100000 * money.Baht,
+			},
+		},
+		{
+			name:         "A day late is a whole month",
+			mockBehavior: defaultMockBehavior,
+			request:      SurchargeRequest{TaxYear: 2024, Tax: 100000 * money.Baht, Deadline: deadline, PaidAt: deadline.AddDate(0, 0, 1)},
+			expected: &SurchargeResponse{
+				TaxYear:    2024,
+				Tax:        100000 * money.Baht,
+				MonthsLate: 1,
+				Rate:       1.5,
+				Surcharge:  1500 * money.Baht,
+				Penalty:    200 * money.Baht,
+				Total:      101700 * money.Baht,
+			},
+		},
+		{
+			name:         "Part of a month is rounded up",
+			mockBehavior: defaultMockBehavior,
+			request:      SurchargeRequest{TaxYear: 2024, Tax: money.FromBaht(12345.67), Deadline: deadline, PaidAt: deadline.AddDate(0, 2, 1)},
+			expected: &SurchargeResponse{
+				TaxYear:    2024,
+				Tax:        money.FromBaht(12345.67),
+				MonthsLate: 3,
+				Rate:       1.5,
+				Surcharge:  money.FromBaht(555.56),
+				Penalty:    200 * money.Baht,
+				Total:      money.FromBaht(13101.23),
+			},
+		},
+		{
+			name:         "Surcharge is capped at the tax",
+			mockBehavior: defaultMockBehavior,
+			request:      SurchargeRequest{TaxYear: 2024, Tax: 10000 * money.Baht, Deadline: deadline, PaidAt: deadline.AddDate(6, 0, 0)},
+			expected: &SurchargeResponse{
+				TaxYear:    2024,
+				Tax:        10000 * money.Baht,
+				MonthsLate: 72,
+				Rate:       1.5,
+				Surcharge:  10000 * money.Baht,
+				Penalty:    200 * money.Baht,
+				Total:      20200 * money.Baht,
+			},
+		},
+		{
+			name:         "Late without tax due is only the penalty",
+			mockBehavior: defaultMockBehavior,
+			request:      SurchargeRequest{TaxYear: 2024, Deadline: deadline, PaidAt: deadline.AddDate(0, 1, 0)},
+			expected: &SurchargeResponse{
+				TaxYear:    2024,
+				MonthsLate: 1,
+				Rate:       1.5,
+				Penalty:    200 * money.Baht,
+				Total:      200 * money.Baht,
+			},
+		},
+		{
+			name:         "Negative tax due",
+			mockBehavior: func(mock sqlmock.Sqlmock) {},
+			request:      SurchargeRequest{TaxYear: 2024, Tax: -money.Baht, Deadline: deadline, PaidAt: deadline},
+			expectedErr:  ErrNegativeTaxDue,
+		},
+		{
+			name: "Rate with two decimals",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"surcharge_rate", "penalty"}).AddRow(1.25, 500)
+				mock.ExpectPrepare("SELECT surcharge_rate, penalty FROM late_filing_rules").ExpectQuery().WithArgs(2024).WillReturnRows(rows)
+			},
+			request: SurchargeRequest{TaxYear: 2024, Tax: money.FromBaht(3333.33), Deadline: deadline, PaidAt: deadline.AddDate(0, 3, 0)},
+			expected: &SurchargeResponse{
+				TaxYear:    2024,
+				Tax:        money.FromBaht(3333.33),
+				MonthsLate: 3,
+				Rate:       1.25,
+				Surcharge:  money.FromBaht(125),
+				Penalty:    500 * money.Baht,
+				Total:      money.FromBaht(3958.33),
+			},
+		},
+		{
+			name: "No late filing rules configured",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare("SELECT surcharge_rate, penalty FROM late_filing_rules").ExpectQuery().WithArgs(2024).WillReturnRows(sqlmock.NewRows([]string{"surcharge_rate", "penalty"}))
+			},
+			request:     SurchargeRequest{TaxYear: 2024, Tax: 100000 * money.Baht, Deadline: deadline, PaidAt: deadline},
+			expectedErr: ErrNoSurchargeRules,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, mock, teardown := setup(t)
+			defer teardown()
+
+			tt.mockBehavior(mock)
+
+			result, err := s.CalculateSurcharge(context.Background(), tt.request)
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			assert.Equal(t, tt.expected, result)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMonthsLate(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		deadline time.Time
+		paidAt   time.Time
+		expected int
+	}{
+		{"Paid early", date(2025, time.April, 8), date(2025, time.March, 1), 0},
+		{"Paid later on the day of the deadline", date(2025, time.April, 8).Add(time.Hour), date(2025, time.April, 8).Add(23 * time.Hour), 0},
+		{"A month to the day", date(2025, time.April, 8), date(2025, time.May, 8), 1},
+		{"A day past a month", date(2025, time.April, 8), date(2025, time.May, 9), 2},
+		{"End of a shorter month", date(2024, time.January, 31), date(2024, time.February, 29), 1},
+		{"Past the end of a shorter month", date(2024, time.January, 31), date(2024, time.March, 1), 2},
+		{"Across the tax year", date(2024, time.December, 15), date(2025, time.January, 10), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, monthsLate(tt.deadline, tt.paidAt))
+		})
+	}
+}
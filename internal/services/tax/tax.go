@@ -8,11 +8,12 @@ import (
 var _ Servicer = (*service)(nil)
 
 type service struct {
-	log logger.Logger
-	db  database.Database
+	log      logger.Logger
+	db       database.Database
+	rounding RoundingPolicy
 }
 
-func New(log logger.Logger, db database.Database) *service {
-	services := &service{log, db}
+func New(log logger.Logger, db database.Database, cfg *config) *service {
+	services := &service{log, db, cfg.Rounding}
 	return services
 }
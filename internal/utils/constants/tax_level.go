@@ -1,9 +0,0 @@
-package constants
-
-const (
-	T0_150k    = "0-150,000"
-	T150k_500k = "150,000-500,000"
-	T500k_1M   = "500,000-1,000,000"
-	T1M_2M     = "1,000,000-2,000,000"
-	T2M        = "2,000,001 ขึ้นไป"
-)
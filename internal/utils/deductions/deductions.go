@@ -0,0 +1,35 @@
+package deductions
+
+import (
+	"time"
+
+	"github.com/ztrixack/assessment-tax/internal/modules/database"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+// Active returns the amount of every deduction active at the given time for the latest tax year up to the given one,
+// by type. It is empty when no deduction is configured up to the tax year.
+func Active[T ~string](db database.Executor, taxYear int, at time.Time) (map[T]money.Money, error) {
+	rows, err := db.Query(`SELECT DISTINCT ON (type) type, amount FROM deductions
+		WHERE tax_year <= $1 AND effective_from <= $2 AND deleted_at IS NULL
+		ORDER BY type, tax_year DESC, effective_from DESC`, taxYear, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	amounts := map[T]money.Money{}
+	for rows.Next() {
+		var dtype T
+		var amount money.Money
+		if err := rows.Scan(&dtype, &amount); err != nil {
+			return nil, err
+		}
+		amounts[dtype] = amount
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return amounts, nil
+}
@@ -0,0 +1,64 @@
+package deductions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/ztrixack/assessment-tax/internal/modules/database"
+	"github.com/ztrixack/assessment-tax/internal/utils/money"
+)
+
+func TestActive(t *testing.T) {
+	at := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	query := "SELECT DISTINCT ON \\(type\\) type, amount FROM deductions"
+
+	tests := []struct {
+		name          string
+		mockBehaviour func(mock sqlmock.Sqlmock)
+		expected      map[string]money.Money
+		expectedError error
+	}{
+		{
+			name: "Amount of every type",
+			mockBehaviour: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows([]string{"type", "amount"}).AddRow("donation", 10).AddRow("personal", 60000)
+				mock.ExpectPrepare(query).ExpectQuery().WithArgs(2024, at).WillReturnRows(rows)
+			},
+			expected: map[string]money.Money{"donation": 10 * money.Baht, "personal": 60000 * money.Baht},
+		},
+		{
+			name: "Nothing configured up to the tax year",
+			mockBehaviour: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(query).ExpectQuery().WithArgs(2024, at).WillReturnRows(sqlmock.NewRows([]string{"type", "amount"}))
+			},
+			expected: map[string]money.Money{},
+		},
+		{
+			name: "Error in database",
+			mockBehaviour: func(mock sqlmock.Sqlmock) {
+				mock.ExpectPrepare(query).ExpectQuery().WithArgs(2024, at).WillReturnError(assert.AnError)
+			},
+			expectedError: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := database.NewMockDB()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			tt.mockBehaviour(mock)
+
+			amounts, err := Active[string](db, 2024, at)
+
+			assert.Equal(t, tt.expectedError, err)
+			assert.Equal(t, tt.expected, amounts)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
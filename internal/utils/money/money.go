@@ -0,0 +1,196 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in satang, the hundredth of a baht, so that adding and comparing amounts is exact.
+// Amounts with a finer precision are rounded half away from zero to the satang.
+type Money int64
+
+const (
+	Satang Money = 1
+	Baht   Money = 100
+	// Max is the largest amount, for limits without a cap.
+	Max Money = math.MaxInt64
+)
+
+// RoundingMode is the direction an amount is rounded to a multiple of a unit.
+type RoundingMode string
+
+const (
+	// RoundHalfUp rounds to the nearest multiple, and halves away from zero.
+	RoundHalfUp RoundingMode = "half-up"
+	// RoundDown truncates towards zero.
+	RoundDown RoundingMode = "down"
+	// RoundUp rounds away from zero.
+	RoundUp RoundingMode = "up"
+)
+
+// Rounding rounds amounts to a multiple of Unit, such as Baht to drop the satang.
+type Rounding struct {
+	Unit Money
+	Mode RoundingMode
+}
+
+// ToSatang is the rounding of every amount unless told otherwise.
+var ToSatang = Rounding{Unit: Satang, Mode: RoundHalfUp}
+
+var ErrInvalid = fmt.Errorf("invalid money amount")
+
+// decimal is a plain decimal number, without a sign other than minus, an exponent or another base.
+var decimal = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// FromBaht converts an amount in baht to money, rounding it to the satang.
+func FromBaht(baht float64) Money {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(baht, 'f', -1, 64))
+	m, _ := ToSatang.rat(r.Mul(r, big.NewRat(int64(Baht), 1)))
+	return m
+}
+
+// Parse parses a plain decimal amount in baht, such as "29000.50", rounding it to the satang.
+// The amount is left out of the error so that a long input is not echoed back.
+func Parse(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	if !decimal.MatchString(s) {
+		return 0, fmt.Errorf("%w: not a decimal number", ErrInvalid)
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return 0, fmt.Errorf("%w: not a decimal number", ErrInvalid)
+	}
+
+	m, err := ToSatang.rat(r.Mul(r, big.NewRat(int64(Baht), 1)))
+	if err != nil {
+		return 0, fmt.Errorf("%w: out of range", ErrInvalid)
+	}
+
+	return m, nil
+}
+
+// rat rounds an amount in satang to a multiple of the unit. An amount out of range saturates to
+// the largest amount of its sign, with an error.
+func (r Rounding) rat(amount *big.Rat) (Money, error) {
+	unit := max(r.Unit, Satang)
+	d := new(big.Int).Mul(amount.Denom(), big.NewInt(int64(unit)))
+	q, m := new(big.Int).QuoRem(amount.Num(), d, new(big.Int))
+	if m.Sign() != 0 {
+		switch r.Mode {
+		case RoundUp:
+			q.Add(q, big.NewInt(int64(amount.Sign())))
+		case RoundDown:
+		default:
+			if m.Lsh(m.Abs(m), 1).Cmp(d) >= 0 {
+				q.Add(q, big.NewInt(int64(amount.Sign())))
+			}
+		}
+	}
+
+	q.Mul(q, big.NewInt(int64(unit)))
+	if !q.IsInt64() {
+		err := fmt.Errorf("%w: %s is out of range", ErrInvalid, amount.FloatString(2))
+		if q.Sign() < 0 {
+			return -Max, err
+		}
+		return Max, err
+	}
+
+	return Money(q.Int64()), nil
+}
+
+// Round returns the amount rounded to a multiple of the unit of the rounding.
+func (m Money) Round(r Rounding) Money {
+	result, _ := r.rat(new(big.Rat).SetInt64(int64(m)))
+	return result
+}
+
+// Mul returns the amount multiplied by a rate, such as 0.3 for 30%, rounded to the satang.
+// The rate is read as the shortest decimal that represents it.
+func (m Money) Mul(rate float64) Money {
+	return m.MulRound(rate, ToSatang)
+}
+
+// MulRound returns the amount multiplied by a rate, rounded by the rounding.
+func (m Money) MulRound(rate float64, r Rounding) Money {
+	rat, _ := new(big.Rat).SetString(strconv.FormatFloat(rate, 'f', -1, 64))
+	result, _ := r.rat(rat.Mul(rat, new(big.Rat).SetInt64(int64(m))))
+	return result
+}
+
+// MulRatio returns the amount multiplied by num/den, rounded to the satang.
+func (m Money) MulRatio(num, den Money) Money {
+	if den == 0 {
+		return 0
+	}
+
+	r := new(big.Rat).SetFrac64(int64(num), int64(den))
+	result, _ := ToSatang.rat(r.Mul(r, new(big.Rat).SetInt64(int64(m))))
+	return result
+}
+
+// Baht returns the amount in baht, for ratios and logs only.
+func (m Money) Baht() float64 {
+	return float64(m) / float64(Baht)
+}
+
+// String formats the amount in baht with two decimals, such as "29000.50".
+func (m Money) String() string {
+	sign, satang := "", uint64(m)
+	if m < 0 {
+		sign, satang = "-", uint64(-m)
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, satang/uint64(Baht), satang%uint64(Baht))
+}
+
+// MarshalJSON encodes the amount as a JSON number in baht with two decimals.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON decodes an amount in baht from a JSON number or string.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	value, err := Parse(string(bytes.Trim(data, `"`)))
+	if err != nil {
+		return err
+	}
+
+	*m = value
+	return nil
+}
+
+// Scan reads a DECIMAL column in baht.
+func (m *Money) Scan(src interface{}) error {
+	var err error
+	switch v := src.(type) {
+	case []byte:
+		*m, err = Parse(string(v))
+	case string:
+		*m, err = Parse(v)
+	case int64:
+		*m = Money(v) * Baht
+	case float64:
+		*m = FromBaht(v)
+	default:
+		err = fmt.Errorf("%w: cannot scan %T", ErrInvalid, src)
+	}
+
+	return err
+}
+
+// Value writes the amount to a DECIMAL column in baht.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
@@ -0,0 +1,163 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Money
+		wantErr  bool
+	}{
+		{"Whole baht", "29000", 29000 * Baht, false},
+		{"Satang", "29000.05", 29000*Baht + 5*Satang, false},
+		{"One decimal", "0.5", 50 * Satang, false},
+		{"Spaces", " 100.00 ", 100 * Baht, false},
+		{"Negative", "-1.25", -125 * Satang, false},
+		{"Rounds half up", "0.285", 29 * Satang, false},
+		{"Rounds down", "0.284", 28 * Satang, false},
+		{"Rounds half away from zero", "-0.285", -29 * Satang, false},
+		{"Empty", "", 0, true},
+		{"Not a number", "abc", 0, true},
+		{"Fraction", "1/3", 0, true},
+		{"Out of range", "1000000000000000000000000000000", 0, true},
+		{"Exponent", "5e5", 0, true},
+		{"Large exponent", "1e99999", 0, true},
+		{"Hexadecimal", "0x10", 0, true},
+		{"Plus sign", "+1", 0, true},
+		{"No digits after the point", "1.", 0, true},
+		{"No digits before the point", ".5", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Parse(tt.input)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalid)
+				if tt.input != "" {
+					assert.NotContains(t, err.Error(), tt.input)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFromBaht(t *testing.T) {
+	assert.Equal(t, 60000*Baht, FromBaht(60000))
+	assert.Equal(t, 30*Satang, FromBaht(0.1+0.2))
+	assert.Equal(t, 29*Satang, FromBaht(0.285))
+	assert.Equal(t, Max, FromBaht(1e30))
+}
+
+func TestMul(t *testing.T) {
+	assert.Equal(t, 150000*Baht, (500000 * Baht).Mul(0.30))
+	assert.Equal(t, 5000*Baht, (1000000 * Baht).Mul(0.005))
+	assert.Equal(t, 1*Satang, (5 * Satang).Mul(0.1))
+	assert.Equal(t, 0*Satang, (4 * Satang).Mul(0.1))
+	assert.Equal(t, Max, Max.Mul(2))
+}
+
+func TestMulRatio(t *testing.T) {
+	assert.Equal(t, 94000*Baht, (940000*Baht).MulRatio(10*Baht, 100*Baht))
+	assert.Equal(t, 33333*Satang, (1000*Baht).MulRatio(1, 3))
+	assert.Equal(t, Money(0), (1000*Baht).MulRatio(1, 0))
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "29000.00", (29000 * Baht).String())
+	assert.Equal(t, "0.05", (5 * Satang).String())
+	assert.Equal(t, "-1.25", (-125 * Satang).String())
+	assert.Equal(t, 29000.5, (29000*Baht + 50*Satang).Baht())
+}
+
+func TestJSON(t *testing.T) {
+	var value struct {
+		Amount  Money  `json:"amount"`
+		Pointer *Money `json:"pointer"`
+	}
+
+	err := json.Unmarshal([]byte(`{"amount": 500000.0, "pointer": "0.1"}`), &value)
+	assert.NoError(t, err)
+	assert.Equal(t, 500000*Baht, value.Amount)
+	assert.Equal(t, 10*Satang, *value.Pointer)
+
+	data, err := json.Marshal(value)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount": 500000.00, "pointer": 0.10}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"amount": true}`), &value)
+	assert.ErrorIs(t, err, ErrInvalid)
+}
+
+func TestScanAndValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      interface{}
+		expected Money
+		wantErr  bool
+	}{
+		{"Bytes", []byte("60000.00"), 60000 * Baht, false},
+		{"String", "0.25", 25 * Satang, false},
+		{"Integer", int64(10), 10 * Baht, false},
+		{"Float", 0.1, 10 * Satang, false},
+		{"Null", nil, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result Money
+			err := result.Scan(tt.src)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalid)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+
+	value, err := (60000*Baht + 5*Satang).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "60000.05", value)
+}
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   Money
+		rounding Rounding
+		expected Money
+	}{
+		{"Half up to the baht", 100*Baht + 50*Satang, Rounding{Unit: Baht, Mode: RoundHalfUp}, 101 * Baht},
+		{"Below half to the baht", 100*Baht + 49*Satang, Rounding{Unit: Baht, Mode: RoundHalfUp}, 100 * Baht},
+		{"Down to the baht", 100*Baht + 99*Satang, Rounding{Unit: Baht, Mode: RoundDown}, 100 * Baht},
+		{"Up to the baht", 100*Baht + 1*Satang, Rounding{Unit: Baht, Mode: RoundUp}, 101 * Baht},
+		{"Negative half up to the baht", -100*Baht - 50*Satang, Rounding{Unit: Baht, Mode: RoundHalfUp}, -101 * Baht},
+		{"Whole baht is left as is", 100 * Baht, Rounding{Unit: Baht, Mode: RoundUp}, 100 * Baht},
+		{"To the satang is left as is", 100*Baht + 1*Satang, ToSatang, 100*Baht + 1*Satang},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.amount.Round(tt.rounding))
+		})
+	}
+}
+
+func TestMulRound(t *testing.T) {
+	assert.Equal(t, 1*Satang, (5*Satang).MulRound(0.1, ToSatang))
+	assert.Equal(t, Money(0), (5*Satang).MulRound(0.1, Rounding{Unit: Satang, Mode: RoundDown}))
+	assert.Equal(t, 1*Satang, (1*Satang).MulRound(0.1, Rounding{Unit: Satang, Mode: RoundUp}))
+	assert.Equal(t, 3333*Baht, (10000*Baht).MulRound(1.0/3, Rounding{Unit: Baht, Mode: RoundDown}))
+	assert.Equal(t, -Max, (-Max).MulRound(2, ToSatang))
+}
@@ -0,0 +1,12 @@
+package taxyear
+
+import "time"
+
+// Resolve defaults an unset tax year to the current one.
+func Resolve(taxYear int) int {
+	if taxYear == 0 {
+		return time.Now().Year()
+	}
+
+	return taxYear
+}
@@ -0,0 +1,13 @@
+package taxyear
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	assert.Equal(t, time.Now().Year(), Resolve(0))
+	assert.Equal(t, 2023, Resolve(2023))
+}
@@ -37,7 +37,7 @@ func main() {
 	defer db.Close()
 
 	// services
-	taxService := tax_service.New(log, db)
+	taxService := tax_service.New(log, db, tax_service.Config())
 	adminService := admin_service.New(log, db)
 
 	// handlers